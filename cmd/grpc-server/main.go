@@ -0,0 +1,41 @@
+package main
+
+import (
+	"log"
+	"os"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/api"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/database"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/repository"
+	grpcserver "github.com/Melanjnk/equipment-monitor/internal/app/registry_service/server/grpc"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+	pb "github.com/melanjnk/equipment-monitor/pkg/equipment_api"
+)
+
+func main() {
+	db, err := database.Connect(
+		"postgres", "localhost", 54327, "equipment_api", "postgres", "postgres", false,
+	)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer db.Close()
+
+	kindRepository := repository.NewKind(db)
+	kindService := service.NewKind(&kindRepository)
+	model.UseKindCatalog(&kindService)	// Kinds are taken by their names in the catalog
+
+	// Status transition graph may be overridden by JSON file keyed by status names
+	transitionGraph, err := model.LoadTransitionGraph(os.Getenv("TRANSITION_GRAPH_FILE"))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	equipmentRepository := repository.NewEquipment(db)
+	equipmentService := service.NewEquipment(&equipmentRepository, &kindService, transitionGraph)
+	equipmentAPI := api.NewEquipment(&equipmentService)
+
+	server := grpcserver.NewGrpcServer()
+	pb.RegisterEquipmentServiceServer(server, &equipmentAPI)
+	server.Start(":50051")
+}
@@ -1,16 +1,53 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strings"
+	"time"
 	"github.com/Melanjnk/equipment-monitor/cmd/rest-server/corsrouter"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/api"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/controller"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/database"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/publisher"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/repository"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/server/rest"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
 )
 
+// newPublisher chooses Kafka if KAFKA_BROKERS (comma separated) is set, otherwise the JSON lines file.
+func newPublisher() (publisher.Publisher, error) {
+	if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" {
+		topic := os.Getenv("KAFKA_TOPIC")
+		if topic == "" {
+			topic = "equipment-events"
+		}
+		return publisher.NewKafka(strings.Split(brokers, ","), topic), nil
+	}
+	path := os.Getenv("OUTBOX_FILE")
+	if path == "" {
+		path = "equipment-events.jsonl"
+	}
+	return publisher.NewFile(path)
+}
+
+// telemetryRetention is the environment variable (Go duration, at least atLeast), fallback by default (atLeast if longer).
+func telemetryRetention(name string, fallback, atLeast time.Duration) (time.Duration, error) {
+	retention := os.Getenv(name)
+	if retention == "" {
+		return max(fallback, atLeast), nil
+	}
+	parsed, err := time.ParseDuration(retention)
+	if err == nil && parsed < atLeast {
+		err = fmt.Errorf("%s must be at least %v, got %s", name, atLeast, retention)
+	}
+	return parsed, err
+}
+
 func main() {
 	db, err := database.Connect(
 		"postgres", "localhost", 54327, "equipment_api", "postgres", "postgres", false,
@@ -21,10 +58,110 @@ func main() {
 	}
 	defer db.Close()
 
+	kindRepository := repository.NewKind(db)
+	kindService := service.NewKind(&kindRepository)
+	model.UseKindCatalog(&kindService)	// Kinds are taken by their names in the catalog
+	kindController := controller.NewKind(&kindService)
+
+	// Status transition graph may be overridden by JSON file keyed by status names
+	transitionGraph, err := model.LoadTransitionGraph(os.Getenv("TRANSITION_GRAPH_FILE"))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	webhookRepository := repository.NewWebhook(db)
+	webhookService := service.NewWebhook(&webhookRepository, time.Second, 20)
+	webhookController := controller.NewWebhook(&webhookService)
+
 	equipmentRepository := repository.NewEquipment(db)
-	equipmentController := controller.NewEquipment(
-		service.NewEquipment(&equipmentRepository),
+	equipmentService := service.NewEquipment(&equipmentRepository, &kindService, transitionGraph)
+	equipmentController := controller.NewEquipment(&equipmentService)
+	equipmentAPI := api.NewEquipment(&equipmentService)
+
+	retention, err := telemetryRetention("TELEMETRY_RETENTION", 30 * 24 * time.Hour, 24 * time.Hour)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	minutesRetention, err := telemetryRetention("TELEMETRY_1M_RETENTION", 90 * 24 * time.Hour, retention)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	hoursRetention, err := telemetryRetention("TELEMETRY_1H_RETENTION", 2 * 365 * 24 * time.Hour, minutesRetention)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	telemetryRepository := repository.NewTelemetry(db)
+	telemetryService := service.NewTelemetry(telemetryRepository, &equipmentRepository, &kindService, retention, time.Second, 100)
+	telemetryRollup := service.NewTelemetryRollup(telemetryRepository, time.Minute, retention, minutesRetention, hoursRetention)
+	telemetryController := controller.NewTelemetry(&telemetryService)
+
+	alertRepository := repository.NewAlert(db)
+	alertingService := service.NewAlerting(&alertRepository, &kindService)
+	telemetryService.AddListener("alerting", &alertingService)
+	alertController := controller.NewAlert(&alertingService)
+
+	anomalyRepository := repository.NewAnomaly(db)
+	anomalyService := service.NewAnomalyDetection(&anomalyRepository, &equipmentRepository, &kindService)
+	telemetryService.AddListener("anomaly_detection", &anomalyService)
+	anomalyController := controller.NewAnomaly(&anomalyService)
+
+	effectivenessService := service.NewEffectiveness(&equipmentRepository, &equipmentRepository, telemetryRepository)
+	oeeController := controller.NewOEE(&effectivenessService)
+	reliabilityService := service.NewReliability(&equipmentRepository, &equipmentRepository)
+	reliabilityController := controller.NewReliability(&reliabilityService)
+
+	maintenanceRepository := repository.NewMaintenance(db)
+	maintenanceService := service.NewMaintenance(
+		&maintenanceRepository, &equipmentRepository, &equipmentRepository, telemetryRepository, &kindService, 5 * time.Minute,
+	)
+	maintenanceController := controller.NewMaintenance(&maintenanceService)
+
+	workOrderRepository := repository.NewWorkOrder(db)
+	workOrderService := service.NewWorkOrders(&workOrderRepository, transitionGraph)
+	workOrderController := controller.NewWorkOrder(&workOrderService)
+
+	partRepository := repository.NewPart(db)
+	inventoryService := service.NewInventory(&partRepository, &equipmentRepository, &kindService, &workOrderService)
+	inventoryController := controller.NewInventory(&inventoryService)
+
+	connectivityRepository := repository.NewConnectivity(db)
+	connectivityService := service.NewConnectivity(&connectivityRepository, 10 * time.Second)
+	telemetryService.AddListener("connectivity", &connectivityService)
+	connectivityController := controller.NewConnectivity(&connectivityService)
+
+	outboxPublisher, err := newPublisher()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer outboxPublisher.Close()
+	outboxRepository := repository.NewOutbox(db)
+	outboxRelay := service.NewOutboxRelay(&outboxRepository, outboxPublisher, time.Second, 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go outboxRelay.Run(ctx)
+	go webhookService.Run(ctx)
+	go connectivityService.Run(ctx)
+	go telemetryService.Run(ctx)
+	go telemetryRollup.Run(ctx)
+	go maintenanceService.Run(ctx)
+
+	// Changes committed by any replica are streamed to the clients of this one
+	changeListener, err := repository.NewChangeListener(
+		database.DSN("localhost", 54327, "equipment_api", "postgres", "postgres", false),
 	)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer changeListener.Close()
+	equipmentStream := service.NewEquipmentStream(&outboxRepository)
+	go equipmentStream.Run(ctx, changeListener.Ids())
+	streamController := controller.NewStream(&equipmentStream)
+
+	// The gRPC-defined API served over HTTP/JSON by the same service, so the clients may migrate from /equipment/ to /v1/equipment
+	gateway, err := controller.NewGateway(ctx, &equipmentAPI)
+	if err != nil {
+		log.Fatalln(err)
+	}
 
 	// Configure router
 	router := corsrouter.CORSRouter{}
@@ -32,11 +169,80 @@ func main() {
 	equipmentRouter.HandleFunc("/", equipmentController.Create).Methods(http.MethodPost)
 	equipmentRouter.HandleFunc("/", equipmentController.Update).Methods(http.MethodPatch)
 	equipmentRouter.HandleFunc("/", equipmentController.List).Methods(http.MethodGet)
+	equipmentRouter.HandleFunc("/stream", streamController.Changes).Methods(http.MethodGet)
+	equipmentRouter.HandleFunc("/telemetry", telemetryController.FleetSeries).Methods(http.MethodGet)
+	kindRouter := equipmentRouter.PathPrefix("/kinds").Subrouter()
+	kindRouter.HandleFunc("/", kindController.Create).Methods(http.MethodPost)
+	kindRouter.HandleFunc("/", kindController.Update).Methods(http.MethodPatch)
+	kindRouter.HandleFunc("/", kindController.List).Methods(http.MethodGet)
+	kindRouter.HandleFunc("/{kind}", kindController.Get).Methods(http.MethodGet)
+	kindRouter.HandleFunc("/{kind}", kindController.Delete).Methods(http.MethodDelete)
+	kindRouter.HandleFunc("/{kind}/schema", kindController.ParameterSchema).Methods(http.MethodGet)
 	equipmentRouter.HandleFunc("/{id}", equipmentController.Get).Methods(http.MethodGet)
 	equipmentRouter.HandleFunc("/{id}", equipmentController.Delete).Methods(http.MethodDelete)
+	equipmentRouter.HandleFunc("/{id}/transitions", equipmentController.Transition).Methods(http.MethodPost)
+	equipmentRouter.HandleFunc("/{id}/transitions", equipmentController.Transitions).Methods(http.MethodGet)
+	equipmentRouter.HandleFunc("/{id}/history", equipmentController.History).Methods(http.MethodGet)
+	equipmentRouter.HandleFunc("/{id}/telemetry", telemetryController.Ingest).Methods(http.MethodPost)
+	equipmentRouter.HandleFunc("/{id}/telemetry", telemetryController.Series).Methods(http.MethodGet)
+	equipmentRouter.HandleFunc("/{id}/heartbeat", connectivityController.Heartbeat).Methods(http.MethodPost)
+	equipmentRouter.HandleFunc("/{id}/anomalies", anomalyController.List).Methods(http.MethodGet)
+	equipmentRouter.HandleFunc("/{id}/oee", oeeController.Equipment).Methods(http.MethodGet)
+	equipmentRouter.HandleFunc("/{id}/parts", inventoryController.EquipmentParts).Methods(http.MethodGet)
+	alertRouter := router.PathPrefix("/alerts").Subrouter()
+	alertRouter.HandleFunc("/", alertController.List).Methods(http.MethodGet)
+	alertRouter.HandleFunc("/rules/", alertController.CreateRule).Methods(http.MethodPost)
+	alertRouter.HandleFunc("/rules/", alertController.UpdateRule).Methods(http.MethodPatch)
+	alertRouter.HandleFunc("/rules/", alertController.Rules).Methods(http.MethodGet)
+	alertRouter.HandleFunc("/rules/{id}", alertController.GetRule).Methods(http.MethodGet)
+	alertRouter.HandleFunc("/rules/{id}", alertController.DeleteRule).Methods(http.MethodDelete)
+	anomalyRouter := router.PathPrefix("/anomalies").Subrouter()
+	anomalyRouter.HandleFunc("/detectors/", anomalyController.CreateDetector).Methods(http.MethodPost)
+	anomalyRouter.HandleFunc("/detectors/", anomalyController.UpdateDetector).Methods(http.MethodPatch)
+	anomalyRouter.HandleFunc("/detectors/", anomalyController.Detectors).Methods(http.MethodGet)
+	anomalyRouter.HandleFunc("/detectors/{id}", anomalyController.GetDetector).Methods(http.MethodGet)
+	anomalyRouter.HandleFunc("/detectors/{id}", anomalyController.DeleteDetector).Methods(http.MethodDelete)
+	maintenanceRouter := router.PathPrefix("/maintenance").Subrouter()
+	maintenanceRouter.HandleFunc("/due", maintenanceController.Due).Methods(http.MethodGet)
+	maintenanceRouter.HandleFunc("/plans/", maintenanceController.CreatePlan).Methods(http.MethodPost)
+	maintenanceRouter.HandleFunc("/plans/", maintenanceController.UpdatePlan).Methods(http.MethodPatch)
+	maintenanceRouter.HandleFunc("/plans/", maintenanceController.Plans).Methods(http.MethodGet)
+	maintenanceRouter.HandleFunc("/plans/{id}", maintenanceController.GetPlan).Methods(http.MethodGet)
+	maintenanceRouter.HandleFunc("/plans/{id}", maintenanceController.DeletePlan).Methods(http.MethodDelete)
+	workOrderRouter := router.PathPrefix("/work-orders").Subrouter()
+	workOrderRouter.HandleFunc("/", workOrderController.Create).Methods(http.MethodPost)
+	workOrderRouter.HandleFunc("/", workOrderController.List).Methods(http.MethodGet)
+	workOrderRouter.HandleFunc("/{id}", workOrderController.Get).Methods(http.MethodGet)
+	workOrderRouter.HandleFunc("/{id}/assign", workOrderController.Assign).Methods(http.MethodPost)
+	workOrderRouter.HandleFunc("/{id}/start", workOrderController.Start).Methods(http.MethodPost)
+	workOrderRouter.HandleFunc("/{id}/close", workOrderController.Close).Methods(http.MethodPost)
+	workOrderRouter.HandleFunc("/{id}/parts", inventoryController.Reserve).Methods(http.MethodPost)
+	workOrderRouter.HandleFunc("/{id}/parts", inventoryController.Reservations).Methods(http.MethodGet)
+	workOrderRouter.HandleFunc("/{id}/parts/{reservation_id}", inventoryController.Release).Methods(http.MethodDelete)
+	partRouter := router.PathPrefix("/parts").Subrouter()
+	partRouter.HandleFunc("/", inventoryController.Create).Methods(http.MethodPost)
+	partRouter.HandleFunc("/", inventoryController.Update).Methods(http.MethodPatch)
+	partRouter.HandleFunc("/", inventoryController.List).Methods(http.MethodGet)
+	partRouter.HandleFunc("/low-stock", inventoryController.LowStock).Methods(http.MethodGet)
+	partRouter.HandleFunc("/{id}", inventoryController.Get).Methods(http.MethodGet)
+	partRouter.HandleFunc("/{id}", inventoryController.Delete).Methods(http.MethodDelete)
+	partRouter.HandleFunc("/{id}/stock", inventoryController.AdjustStock).Methods(http.MethodPost)
+	reportRouter := router.PathPrefix("/reports").Subrouter()
+	reportRouter.HandleFunc("/oee", oeeController.Report).Methods(http.MethodGet)
+	reportRouter.HandleFunc("/reliability", reliabilityController.Report).Methods(http.MethodGet)
+	webhookRouter := router.PathPrefix("/webhooks").Subrouter()
+	webhookRouter.HandleFunc("/", webhookController.Create).Methods(http.MethodPost)
+	webhookRouter.HandleFunc("/", webhookController.Update).Methods(http.MethodPatch)
+	webhookRouter.HandleFunc("/", webhookController.List).Methods(http.MethodGet)
+	webhookRouter.HandleFunc("/{id}", webhookController.Get).Methods(http.MethodGet)
+	webhookRouter.HandleFunc("/{id}", webhookController.Delete).Methods(http.MethodDelete)
+	webhookRouter.HandleFunc("/{id}/deliveries", webhookController.Deliveries).Methods(http.MethodGet)
+
+	router.PathPrefix("/v1/").Handler(gateway)
 
 	http.Handle("/", http.FileServer(http.Dir("./public")))
 
 	server := rest.RestServer{}
+	server.RegisterOnShutdown(cancel) // Ends the streams which Shutdown does not wait for
 	server.StartHTTP(":8080", &router)
 }
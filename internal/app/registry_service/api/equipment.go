@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"github.com/gofrs/uuid"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+	pb "github.com/melanjnk/equipment-monitor/pkg/equipment_api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Equipment serves pb.EquipmentServiceServer by the same service.Equipment as the REST controller.
+type Equipment struct {
+	pb.UnimplementedEquipmentServiceServer
+	service *service.Equipment
+}
+
+func NewEquipment(service *service.Equipment) Equipment {
+	return Equipment{service: service}
+}
+
+func timeOrNil(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	converted := timestamp.AsTime()
+	return &converted
+}
+
+func timestampOrNil(time *time.Time) *timestamppb.Timestamp {
+	if time == nil {
+		return nil
+	}
+	return timestamppb.New(*time)
+}
+
+func equipmentFromDTO(equipmentGet *dtos.EquipmentGet) (*pb.Equipment, error) {
+	parameters, err := structpb.NewStruct(equipmentGet.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Equipment {
+		Id:				equipmentGet.Id.String(),
+		Kind:			pb.EquipmentKind(equipmentGet.Kind),
+		Status:			pb.OperationalStatus(equipmentGet.Status),
+		Parameters:		parameters,
+		CreatedAt:		timestamppb.New(equipmentGet.CreatedAt),
+		UpdatedAt:		timestamppb.New(equipmentGet.UpdatedAt),
+		LastSeenAt:		timestampOrNil(equipmentGet.LastSeenAt),
+		Connectivity:	string(equipmentGet.Connectivity),
+	}, nil
+}
+
+func kindsFromPB(kinds []pb.EquipmentKind) []model.EquipmentKind {
+	if len(kinds) == 0 {
+		return nil
+	}
+	converted := make([]model.EquipmentKind, 0, len(kinds))
+	for _, kind := range kinds {
+		converted = append(converted, model.EquipmentKind(kind))
+	}
+	return converted
+}
+
+func statusesFromPB(statuses []pb.OperationalStatus) []model.OperationalStatus {
+	if len(statuses) == 0 {
+		return nil
+	}
+	converted := make([]model.OperationalStatus, 0, len(statuses))
+	for _, status := range statuses {
+		converted = append(converted, model.OperationalStatus(status))
+	}
+	return converted
+}
+
+func (api *Equipment) CreateEquipment(ctx context.Context, request *pb.CreateEquipmentRequest) (*pb.CreateEquipmentResponse, error) {
+	if err := validate(request); err != nil {
+		return nil, err
+	}
+	if request.Kind == nil {
+		return nil, status.Error(codes.InvalidArgument, "`kind` is required")
+	}
+	equipmentCreate := dtos.EquipmentCreate {
+		Kind:		model.EquipmentKind(request.GetKind()),
+		Parameters:	request.Parameters.AsMap(),
+	}
+	if err := equipmentCreate.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	id, err := api.service.Create(&equipmentCreate)
+	if err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	}
+	return &pb.CreateEquipmentResponse{Id: id.String()}, nil
+}
+
+func (api *Equipment) GetEquipment(ctx context.Context, request *pb.GetEquipmentRequest) (*pb.GetEquipmentResponse, error) {
+	if err := validate(request); err != nil {
+		return nil, err
+	}
+	var equipmentGet *dtos.EquipmentGet
+	var err error
+	if request.AsOf == nil {
+		equipmentGet, err = api.service.Get(request.Id)
+	} else {
+		equipmentGet, err = api.service.GetAsOf(request.Id, request.AsOf.AsTime())
+	}
+	if err != nil {
+		return nil, statusError(fmt.Errorf("Get equipment #%v error: %w", request.Id, err), codes.NotFound)
+	}
+	value, err := equipmentFromDTO(equipmentGet)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.GetEquipmentResponse{Value: value}, nil
+}
+
+func (api *Equipment) ListEquipment(ctx context.Context, request *pb.ListEquipmentRequest) (*pb.ListEquipmentResponse, error) {
+	if err := validate(request); err != nil {
+		return nil, err
+	}
+	equipmentFilter := dtos.EquipmentFilter {
+		Kinds:			kindsFromPB(request.Kinds),
+		NoKinds:		kindsFromPB(request.NoKinds),
+		Statuses:		statusesFromPB(request.Statuses),
+		NoStatuses:		statusesFromPB(request.NoStatuses),
+		CreatedSince:	timeOrNil(request.CreatedSince),
+		CreatedUntil:	timeOrNil(request.CreatedUntil),
+		UpdatedSince:	timeOrNil(request.UpdatedSince),
+		UpdatedUntil:	timeOrNil(request.UpdatedUntil),
+		AsOf:			timeOrNil(request.AsOf),
+		LastSeenSince:	timeOrNil(request.LastSeenSince),
+		LastSeenUntil:	timeOrNil(request.LastSeenUntil),
+		Filter:			request.Filter,
+	}
+	for _, connectivity := range request.Connectivity {
+		equipmentFilter.Connectivity = append(equipmentFilter.Connectivity, model.Connectivity(connectivity))
+	}
+	equipmentQuery := dtos.EquipmentQuery {
+		EquipmentFilter:	equipmentFilter,
+		EquipmentPage:		dtos.EquipmentPage {
+			Limit:	int(request.Limit),
+			Cursor:	request.Cursor,
+			Sort:	dtos.EquipmentSort(request.Sort),
+			Total:	request.Total,
+		},
+	}
+	if request.Descending {
+		equipmentQuery.Direction = "desc"
+	}
+	if err := equipmentQuery.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	equipmentList, err := api.service.ListPage(&equipmentQuery)
+	if err != nil {
+		return nil, statusError(err, codes.Internal)
+	}
+	response := pb.ListEquipmentResponse{Items: make([]*pb.Equipment, 0, len(equipmentList.Items))}
+	for _, equipmentGet := range equipmentList.Items {
+		item, err := equipmentFromDTO(equipmentGet)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		response.Items = append(response.Items, item)
+	}
+	if equipmentList.NextCursor != nil {
+		response.NextCursor = *equipmentList.NextCursor
+	}
+	if equipmentList.Total != nil {
+		total := int64(*equipmentList.Total)
+		response.Total = &total
+	}
+	return &response, nil
+}
+
+func (api *Equipment) UpdateEquipment(ctx context.Context, request *pb.UpdateEquipmentRequest) (*pb.UpdateEquipmentResponse, error) {
+	if err := validate(request); err != nil {
+		return nil, err
+	}
+	id, err := uuid.FromString(request.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	equipmentUpdate := dtos.EquipmentUpdate {
+		Id:		id,
+		Reason:	request.Reason,
+		Actor:	request.Actor,
+	}
+	if request.Status != nil {
+		operationalStatus := model.OperationalStatus(*request.Status)
+		equipmentUpdate.Status = &operationalStatus
+	}
+	if request.Parameters != nil {
+		parameters := request.Parameters.AsMap()
+		equipmentUpdate.Parameters = &parameters
+	}
+	if err = equipmentUpdate.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if equipmentUpdate.Status == nil && equipmentUpdate.Parameters == nil {
+		return nil, status.Error(codes.InvalidArgument, "Either `status` or `parameters` is required")
+	}
+	if updated, err := api.service.Update(&equipmentUpdate); err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	} else if !updated {
+		return nil, status.Errorf(codes.NotFound, "Unable to find equipment #%v for updating", id)
+	}
+	return &pb.UpdateEquipmentResponse{}, nil
+}
+
+func (api *Equipment) DeleteEquipment(ctx context.Context, request *pb.DeleteEquipmentRequest) (*pb.DeleteEquipmentResponse, error) {
+	if err := validate(request); err != nil {
+		return nil, err
+	}
+	if deleted, err := api.service.Delete(request.Id); err != nil {
+		return nil, statusError(err, codes.InvalidArgument)
+	} else if !deleted {
+		return nil, status.Errorf(codes.NotFound, "Unable to find equipment #%v for deleting", request.Id)
+	}
+	return &pb.DeleteEquipmentResponse{}, nil
+}
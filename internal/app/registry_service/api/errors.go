@@ -0,0 +1,69 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/paramschema"
+)
+
+// serviceError is the status of the error of the service which keeps the error itself,
+// so the gateway handling the calls in-process maps it as the REST controllers do.
+type serviceError struct {
+	status	*status.Status
+	cause	error
+}
+
+func (serviceError *serviceError) Error() string {
+	return serviceError.status.Err().Error()
+}
+
+func (serviceError *serviceError) GRPCStatus() *status.Status {
+	return serviceError.status
+}
+
+func (serviceError *serviceError) Unwrap() error {
+	return serviceError.cause
+}
+
+// statusError maps the error of the service as the REST controllers do: unknown entities are NotFound,
+// conflicts with the stored state are Aborted (409 through the gateway), schema violations of the parameters
+// are InvalidArgument with the field violations in the details; otherwise the error has the code.
+func statusError(err error, code codes.Code) error {
+	var validationError *paramschema.ValidationError
+	switch {
+		case errors.Is(err, sql.ErrNoRows):
+			code = codes.NotFound
+		case errors.Is(err, model.ErrConflict):
+			code = codes.Aborted
+		case errors.As(err, &validationError):
+			badRequest := errdetails.BadRequest{FieldViolations: make([]*errdetails.BadRequest_FieldViolation, 0, len(validationError.Fields))}
+			for _, field := range validationError.Fields {
+				badRequest.FieldViolations = append(
+					badRequest.FieldViolations,
+					&errdetails.BadRequest_FieldViolation{Field: field.Field, Description: field.Message},
+				)
+			}
+			if detailed, detailsErr := status.New(codes.InvalidArgument, err.Error()).WithDetails(&badRequest); detailsErr == nil {
+				return &serviceError{status: detailed, cause: err}
+			}
+			code = codes.InvalidArgument
+	}
+	return &serviceError{status: status.New(code, err.Error()), cause: err}
+}
+
+type validatable interface {
+	Validate() error
+}
+
+// validate rejects the request violating the validation rules of the proto as InvalidArgument;
+// the requests are validated by the methods since the gateway calls them bypassing the interceptors.
+func validate(request validatable) error {
+	if err := request.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+const (
+	unableToFindRule string = "Unable to find alert rule #%v for %s"
+	ruleError = "%s alert rule #%v error: %v"
+	ruleActionIsPerformed = "Alert rule #%v is %s"
+)
+
+type Alert struct {
+	service *service.Alerting
+}
+
+func NewAlert(service *service.Alerting) Alert {
+	return Alert{service: service}
+}
+
+func (controller *Alert) List(writer http.ResponseWriter, request *http.Request) {
+	if alertFilter, err := dtos.AlertFilterFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if alertList, err := controller.service.Alerts(alertFilter); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, alertList)
+	}
+}
+
+func (controller *Alert) Rules(writer http.ResponseWriter, request *http.Request) {
+	if ruleList, err := controller.service.Rules(); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, ruleList)
+	}
+}
+
+func (controller *Alert) CreateRule(writer http.ResponseWriter, request *http.Request) {
+	if ruleCreate, err := dtos.FromRequestJSON[dtos.AlertRuleCreate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if id, err := controller.service.CreateRule(ruleCreate); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), "Create alert rule error: %v", err)
+	} else {
+		writeMessage(writer, http.StatusCreated, ruleActionIsPerformed, id, "created")
+	}
+}
+
+func (controller *Alert) UpdateRule(writer http.ResponseWriter, request *http.Request) {
+	if ruleUpdate, err := dtos.FromRequestJSON[dtos.AlertRuleUpdate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if updated, err := controller.service.UpdateRule(ruleUpdate); errors.Is(err, sql.ErrNoRows) || err == nil && !updated {
+		writeMessage(writer, http.StatusNotFound, unableToFindRule, ruleUpdate.Id, "updating")
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, ruleError, "Update", ruleUpdate.Id, err)
+	} else {
+		writeMessage(writer, http.StatusOK, ruleActionIsPerformed, ruleUpdate.Id, "updated")
+	}
+}
+
+func (controller *Alert) GetRule(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if ruleGet, err := controller.service.GetRule(id); err != nil {
+		writeMessage(writer, http.StatusNotFound, ruleError, "Get", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, ruleGet)
+	}
+}
+
+func (controller *Alert) DeleteRule(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if deleted, err := controller.service.DeleteRule(id); err != nil {
+		writeMessage(writer, http.StatusBadRequest, ruleError, "Delete", id, err)
+	} else if !deleted {
+		writeMessage(writer, http.StatusNotFound, unableToFindRule, id, "deleting")
+	} else {
+		writeMessage(writer, http.StatusOK, ruleActionIsPerformed, id, "deleted")
+	}
+}
@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+const (
+	unableToFindDetector string = "Unable to find anomaly detector #%v for %s"
+	detectorError = "%s anomaly detector #%v error: %v"
+	detectorActionIsPerformed = "Anomaly detector #%v is %s"
+)
+
+type Anomaly struct {
+	service *service.AnomalyDetection
+}
+
+func NewAnomaly(service *service.AnomalyDetection) Anomaly {
+	return Anomaly{service: service}
+}
+
+func (controller *Anomaly) List(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if anomalyFilter, err := dtos.AnomalyFilterFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if anomalyList, err := controller.service.Anomalies(id, anomalyFilter); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, id, "anomalies")
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, equipmentIdError, "Anomalies of", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, anomalyList)
+	}
+}
+
+func (controller *Anomaly) Detectors(writer http.ResponseWriter, request *http.Request) {
+	if detectorList, err := controller.service.Detectors(); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, detectorList)
+	}
+}
+
+func (controller *Anomaly) CreateDetector(writer http.ResponseWriter, request *http.Request) {
+	if detectorCreate, err := dtos.FromRequestJSON[dtos.AnomalyDetectorCreate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if id, err := controller.service.CreateDetector(detectorCreate); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), "Create anomaly detector error: %v", err)
+	} else {
+		writeMessage(writer, http.StatusCreated, detectorActionIsPerformed, id, "created")
+	}
+}
+
+func (controller *Anomaly) UpdateDetector(writer http.ResponseWriter, request *http.Request) {
+	if detectorUpdate, err := dtos.FromRequestJSON[dtos.AnomalyDetectorUpdate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if updated, err := controller.service.UpdateDetector(detectorUpdate); errors.Is(err, sql.ErrNoRows) || err == nil && !updated {
+		writeMessage(writer, http.StatusNotFound, unableToFindDetector, detectorUpdate.Id, "updating")
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, detectorError, "Update", detectorUpdate.Id, err)
+	} else {
+		writeMessage(writer, http.StatusOK, detectorActionIsPerformed, detectorUpdate.Id, "updated")
+	}
+}
+
+func (controller *Anomaly) GetDetector(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if detectorGet, err := controller.service.GetDetector(id); err != nil {
+		writeMessage(writer, http.StatusNotFound, detectorError, "Get", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, detectorGet)
+	}
+}
+
+func (controller *Anomaly) DeleteDetector(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if deleted, err := controller.service.DeleteDetector(id); err != nil {
+		writeMessage(writer, http.StatusBadRequest, detectorError, "Delete", id, err)
+	} else if !deleted {
+		writeMessage(writer, http.StatusNotFound, unableToFindDetector, id, "deleting")
+	} else {
+		writeMessage(writer, http.StatusOK, detectorActionIsPerformed, id, "deleted")
+	}
+}
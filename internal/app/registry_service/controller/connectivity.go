@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+type Connectivity struct {
+	service *service.Connectivity
+}
+
+func NewConnectivity(service *service.Connectivity) Connectivity {
+	return Connectivity{service: service}
+}
+
+func (controller *Connectivity) Heartbeat(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if err := controller.service.Heartbeat(id); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, id, "heartbeat")
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, equipmentIdError, "Heartbeat of", id, err)
+	} else {
+		writeMessage(writer, http.StatusOK, "Heartbeat of equipment #%v is received", id)
+	}
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"github.com/gorilla/mux"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
@@ -8,17 +10,24 @@ import (
 )
 
 type Equipment struct {
-	service service.Equipment
+	service *service.Equipment
 }
 
-func NewEquipment(service service.Equipment) Equipment {
+func NewEquipment(service *service.Equipment) Equipment {
 	return Equipment{service: service}
 }
 
 func (controller *Equipment) List(writer http.ResponseWriter, request *http.Request) {
-	if equipmentFilter, err := dtos.EquipmentFilterFromRequest(request); err != nil {
+	if equipmentQuery, err := dtos.EquipmentQueryFromRequest(request); err != nil {
 		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
-	} else if equipmentList, err := controller.service.List(equipmentFilter); err != nil {
+	} else if !equipmentQuery.Requested {
+		// The plain array of all the matching equipment unless the page is requested
+		if equipmentGets, err := controller.service.List(&equipmentQuery.EquipmentFilter); err != nil {
+			writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+		} else {
+			writeJSON(writer, http.StatusOK, equipmentGets)
+		}
+	} else if equipmentList, err := controller.service.ListPage(equipmentQuery); err != nil {
 		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
 	} else {
 		writeJSON(writer, http.StatusOK, equipmentList)
@@ -29,6 +38,9 @@ func (controller *Equipment) Create(writer http.ResponseWriter, request *http.Re
 	if equipmentCreate, err := dtos.FromRequestJSON[dtos.EquipmentCreate](request); err != nil {
 		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
 	} else if id, err := controller.service.Create(equipmentCreate); err != nil {
+		if writeParametersError(writer, err) {
+			return
+		}
 		writeMessage(writer, http.StatusBadRequest, "Create equipment error: %v", err)
 	} else {
 		writeMessage(writer, http.StatusCreated, equipmentActionIsPerformed, id, "created")
@@ -39,7 +51,10 @@ func (controller *Equipment) Update(writer http.ResponseWriter, request *http.Re
 	if equipmentUpdate, err := dtos.FromRequestJSON[dtos.EquipmentUpdate](request); err != nil {
 		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
 	} else if updated, err := controller.service.Update(equipmentUpdate); err != nil {
-		writeMessage(writer, http.StatusBadRequest, equipmentIdError, "Update", equipmentUpdate.Id, err)
+		if writeParametersError(writer, err) {
+			return
+		}
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), equipmentIdError, "Update", equipmentUpdate.Id, err)
 	} else if !updated {
 		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, equipmentUpdate.Id, "updating")
 	} else {
@@ -50,13 +65,22 @@ func (controller *Equipment) Update(writer http.ResponseWriter, request *http.Re
 func (controller *Equipment) Get(writer http.ResponseWriter, request *http.Request) {
 	if id, ok := mux.Vars(request)["id"]; !ok {
 		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
-	} else if eqg, err := controller.service.Get(id); err != nil {
+	} else if pointInTime, err := dtos.PointInTimeFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if eqg, err := controller.get(id, pointInTime); err != nil {
 		writeMessage(writer, http.StatusNotFound, equipmentIdError, "Get", id, err)
 	} else {
 		writeJSON(writer, http.StatusOK, eqg)
 	}
 }
 
+func (controller *Equipment) get(id string, pointInTime *dtos.PointInTime) (*dtos.EquipmentGet, error) {
+	if pointInTime.AsOf == nil {
+		return controller.service.Get(id)
+	}
+	return controller.service.GetAsOf(id, *pointInTime.AsOf)
+}
+
 func (controller *Equipment) Delete(writer http.ResponseWriter, request *http.Request) {
 	if id, ok := mux.Vars(request)["id"]; !ok {
 		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
@@ -68,3 +92,42 @@ func (controller *Equipment) Delete(writer http.ResponseWriter, request *http.Re
 		writeMessage(writer, http.StatusOK, equipmentActionIsPerformed, id, "deleted")
 	}
 }
+
+
+func (controller *Equipment) Transition(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if equipmentTransition, err := dtos.FromRequestJSON[dtos.EquipmentTransition](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if transited, err := controller.service.Transition(id, equipmentTransition); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), equipmentIdError, "Transition", id, err)
+	} else if !transited {
+		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, id, "transition")
+	} else {
+		writeMessage(writer, http.StatusOK, equipmentActionIsPerformed, id, equipmentTransition.Status.String())
+	}
+}
+
+func (controller *Equipment) Transitions(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if transitionList, err := controller.service.Transitions(id); err != nil {
+		writeMessage(writer, http.StatusBadRequest, equipmentIdError, "Transitions", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, transitionList)
+	}
+}
+
+func (controller *Equipment) History(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if historyPage, err := dtos.HistoryPageFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if equipmentHistory, err := controller.service.History(id, historyPage); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, id, "history")
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, equipmentIdError, "History", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, equipmentHistory)
+	}
+}
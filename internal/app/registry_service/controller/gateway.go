@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	pb "github.com/melanjnk/equipment-monitor/pkg/equipment_api"
+)
+
+// NewGateway serves the gRPC-defined equipment API over HTTP/JSON calling the server in-process;
+// JSON has the field names and the enum numbers of the proto as the REST API has.
+func NewGateway(ctx context.Context, equipmentServer pb.EquipmentServiceServer) (http.Handler, error) {
+	gateway := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb {
+			MarshalOptions:		protojson.MarshalOptions{UseProtoNames: true, UseEnumNumbers: true, EmitUnpopulated: true},
+			UnmarshalOptions:	protojson.UnmarshalOptions{DiscardUnknown: true},
+		}),
+		runtime.WithErrorHandler(writeGatewayError),
+	)
+	if err := pb.RegisterEquipmentServiceHandlerServer(ctx, gateway, equipmentServer); err != nil {
+		return nil, err
+	}
+	return gateway, nil
+}
+
+// writeGatewayError responds with field-level errors to the schema violations of the parameters as the REST controllers do;
+// other errors are responded by the status (e.g. NOT_FOUND is 404, ABORTED is 409).
+func writeGatewayError(
+	ctx context.Context, gateway *runtime.ServeMux, marshaler runtime.Marshaler, writer http.ResponseWriter, request *http.Request, err error,
+) {
+	if writeParametersError(writer, err) {
+		return
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, gateway, marshaler, writer, request, err)
+}
@@ -1,9 +1,12 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"encoding/json"
 	"net/http"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/paramschema"
 )
 
 const(
@@ -23,3 +26,31 @@ func writeJSON(writer http.ResponseWriter, status int, data interface{}) {
 	writer.WriteHeader(status)
 	_ = json.NewEncoder(writer).Encode(data)
 }
+
+// conflictOr returns 409 for the errors caused by conflicting with the stored state; otherwise returns status.
+func conflictOr(err error, status int) int {
+	if errors.Is(err, model.ErrConflict) {
+		return http.StatusConflict
+	}
+	return status
+}
+
+type parametersErrorBody struct {
+	Kind	model.EquipmentKind			`json:"kind"`
+	Errors	[]paramschema.FieldError	`json:"errors"`
+}
+
+// writeParametersError responds with field-level errors if err is caused by the parameter schema violation;
+// returns false if it is not.
+func writeParametersError(writer http.ResponseWriter, err error) bool {
+	var validationError *paramschema.ValidationError
+	if !errors.As(err, &validationError) {
+		return false
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writeJSON(writer, http.StatusUnprocessableEntity, parametersErrorBody{
+		Kind:	validationError.Kind,
+		Errors:	validationError.Fields,
+	})
+	return true
+}
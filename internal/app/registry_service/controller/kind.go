@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+const (
+	unableToFindKind string = "Unable to find equipment kind `%v` for %s"
+	kindError = "%s equipment kind `%v` error: %v"
+	kindActionIsPerformed = "Equipment kind `%v` is %s"
+)
+
+type Kind struct {
+	service *service.Kind
+}
+
+func NewKind(service *service.Kind) Kind {
+	return Kind{service: service}
+}
+
+func (controller *Kind) List(writer http.ResponseWriter, request *http.Request) {
+	if kindList, err := controller.service.List(); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, kindList)
+	}
+}
+
+func (controller *Kind) Create(writer http.ResponseWriter, request *http.Request) {
+	if kindCreate, err := dtos.FromRequestJSON[dtos.KindCreate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if id, err := controller.service.Create(kindCreate); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), "Create equipment kind error: %v", err)
+	} else {
+		writeMessage(writer, http.StatusCreated, kindActionIsPerformed, id, "created")
+	}
+}
+
+func (controller *Kind) Update(writer http.ResponseWriter, request *http.Request) {
+	if kindUpdate, err := dtos.FromRequestJSON[dtos.KindUpdate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if updated, err := controller.service.Update(kindUpdate); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), kindError, "Update", kindUpdate.Id, err)
+	} else if !updated {
+		writeMessage(writer, http.StatusNotFound, unableToFindKind, kindUpdate.Id, "updating")
+	} else {
+		writeMessage(writer, http.StatusOK, kindActionIsPerformed, kindUpdate.Id, "updated")
+	}
+}
+
+func (controller *Kind) Get(writer http.ResponseWriter, request *http.Request) {
+	if kind, ok := mux.Vars(request)["kind"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "kind")
+	} else if kindGet, err := controller.service.Get(kind); err != nil {
+		writeMessage(writer, http.StatusNotFound, kindError, "Get", kind, err)
+	} else {
+		writeJSON(writer, http.StatusOK, kindGet)
+	}
+}
+
+func (controller *Kind) Delete(writer http.ResponseWriter, request *http.Request) {
+	if kind, ok := mux.Vars(request)["kind"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "kind")
+	} else if deleted, err := controller.service.Delete(kind); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), kindError, "Delete", kind, err)
+	} else if !deleted {
+		writeMessage(writer, http.StatusNotFound, unableToFindKind, kind, "deleting")
+	} else {
+		writeMessage(writer, http.StatusOK, kindActionIsPerformed, kind, "deleted")
+	}
+}
+
+func (controller *Kind) ParameterSchema(writer http.ResponseWriter, request *http.Request) {
+	if kind, ok := mux.Vars(request)["kind"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "kind")
+	} else if kindGet, err := controller.service.Get(kind); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindKind, kind, "getting its parameter schema")
+	} else if err != nil {
+		writeMessage(writer, http.StatusInternalServerError, kindError, "Get", kind, err)
+	} else {
+		writer.Header().Set("Content-Type", "application/schema+json")
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write(kindGet.ParameterSchema)
+	}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+const (
+	unableToFindPlan string = "Unable to find maintenance plan #%v for %s"
+	planError = "%s maintenance plan #%v error: %v"
+	planActionIsPerformed = "Maintenance plan #%v is %s"
+)
+
+type Maintenance struct {
+	service *service.Maintenance
+}
+
+func NewMaintenance(service *service.Maintenance) Maintenance {
+	return Maintenance{service: service}
+}
+
+func (controller *Maintenance) Plans(writer http.ResponseWriter, request *http.Request) {
+	if planList, err := controller.service.Plans(); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, planList)
+	}
+}
+
+func (controller *Maintenance) CreatePlan(writer http.ResponseWriter, request *http.Request) {
+	if planCreate, err := dtos.FromRequestJSON[dtos.MaintenancePlanCreate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if id, err := controller.service.CreatePlan(planCreate); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), "Create maintenance plan error: %v", err)
+	} else {
+		writeMessage(writer, http.StatusCreated, planActionIsPerformed, id, "created")
+	}
+}
+
+func (controller *Maintenance) UpdatePlan(writer http.ResponseWriter, request *http.Request) {
+	if planUpdate, err := dtos.FromRequestJSON[dtos.MaintenancePlanUpdate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if updated, err := controller.service.UpdatePlan(planUpdate); errors.Is(err, sql.ErrNoRows) || err == nil && !updated {
+		writeMessage(writer, http.StatusNotFound, unableToFindPlan, planUpdate.Id, "updating")
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, planError, "Update", planUpdate.Id, err)
+	} else {
+		writeMessage(writer, http.StatusOK, planActionIsPerformed, planUpdate.Id, "updated")
+	}
+}
+
+func (controller *Maintenance) GetPlan(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if planGet, err := controller.service.GetPlan(id); err != nil {
+		writeMessage(writer, http.StatusNotFound, planError, "Get", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, planGet)
+	}
+}
+
+func (controller *Maintenance) DeletePlan(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if deleted, err := controller.service.DeletePlan(id); err != nil {
+		writeMessage(writer, http.StatusBadRequest, planError, "Delete", id, err)
+	} else if !deleted {
+		writeMessage(writer, http.StatusNotFound, unableToFindPlan, id, "deleting")
+	} else {
+		writeMessage(writer, http.StatusOK, planActionIsPerformed, id, "deleted")
+	}
+}
+
+func (controller *Maintenance) Due(writer http.ResponseWriter, request *http.Request) {
+	if dueQuery, err := dtos.MaintenanceDueQueryFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if dueList, err := controller.service.Due(dueQuery); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, dueList)
+	}
+}
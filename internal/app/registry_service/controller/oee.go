@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+type OEE struct {
+	service *service.Effectiveness
+}
+
+func NewOEE(service *service.Effectiveness) OEE {
+	return OEE{service: service}
+}
+
+func (controller *OEE) Equipment(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if reportRange, err := dtos.ReportRangeFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if equipmentOEE, err := controller.service.Equipment(id, reportRange); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, id, "OEE")
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, equipmentIdError, "OEE of", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, equipmentOEE)
+	}
+}
+
+func (controller *OEE) Report(writer http.ResponseWriter, request *http.Request) {
+	if reportQuery, err := dtos.ReportQueryFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if oeeReport, err := controller.service.Report(reportQuery); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "OEE report error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, oeeReport)
+	}
+}
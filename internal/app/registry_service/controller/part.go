@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+const (
+	unableToFindPart string = "Unable to find spare part #%v for %s"
+	partError = "%s spare part #%v error: %v"
+	partActionIsPerformed = "Spare part #%v is %s"
+)
+
+type Inventory struct {
+	service *service.Inventory
+}
+
+func NewInventory(service *service.Inventory) Inventory {
+	return Inventory{service: service}
+}
+
+func (controller *Inventory) list(writer http.ResponseWriter, request *http.Request, list func(*dtos.SparePartFilter) ([]*dtos.SparePartGet, error)) {
+	if partFilter, err := dtos.SparePartFilterFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if partList, err := list(partFilter); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, partList)
+	}
+}
+
+func (controller *Inventory) List(writer http.ResponseWriter, request *http.Request) {
+	controller.list(writer, request, controller.service.Parts)
+}
+
+func (controller *Inventory) LowStock(writer http.ResponseWriter, request *http.Request) {
+	controller.list(writer, request, controller.service.LowStock)
+}
+
+func (controller *Inventory) EquipmentParts(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if partList, err := controller.service.EquipmentParts(id); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, id, "listing parts")
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, partList)
+	}
+}
+
+func (controller *Inventory) Create(writer http.ResponseWriter, request *http.Request) {
+	if partCreate, err := dtos.FromRequestJSON[dtos.SparePartCreate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if id, err := controller.service.CreatePart(partCreate); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), "Create spare part error: %v", err)
+	} else {
+		writeMessage(writer, http.StatusCreated, partActionIsPerformed, id, "created")
+	}
+}
+
+func (controller *Inventory) Update(writer http.ResponseWriter, request *http.Request) {
+	if partUpdate, err := dtos.FromRequestJSON[dtos.SparePartUpdate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if updated, err := controller.service.UpdatePart(partUpdate); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), partError, "Update", partUpdate.Id, err)
+	} else if !updated {
+		writeMessage(writer, http.StatusNotFound, unableToFindPart, partUpdate.Id, "updating")
+	} else {
+		writeMessage(writer, http.StatusOK, partActionIsPerformed, partUpdate.Id, "updated")
+	}
+}
+
+func (controller *Inventory) Get(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if partGet, err := controller.service.GetPart(id); err != nil {
+		writeMessage(writer, http.StatusNotFound, partError, "Get", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, partGet)
+	}
+}
+
+func (controller *Inventory) Delete(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if deleted, err := controller.service.DeletePart(id); err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), partError, "Delete", id, err)
+	} else if !deleted {
+		writeMessage(writer, http.StatusNotFound, unableToFindPart, id, "deleting")
+	} else {
+		writeMessage(writer, http.StatusOK, partActionIsPerformed, id, "deleted")
+	}
+}
+
+func (controller *Inventory) AdjustStock(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if stockAdjustment, err := dtos.FromRequestJSON[dtos.StockAdjustment](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if err = controller.service.AdjustStock(id, stockAdjustment); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindPart, id, "adjusting stock")
+	} else if err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), partError, "Adjust stock of", id, err)
+	} else {
+		writeMessage(writer, http.StatusOK, partActionIsPerformed, id, "adjusted")
+	}
+}
+
+func (controller *Inventory) Reservations(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if reservationList, err := controller.service.Reservations(id); err != nil {
+		writeMessage(writer, http.StatusNotFound, workOrderError, "List parts of", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, reservationList)
+	}
+}
+
+func (controller *Inventory) Reserve(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if reservationCreate, err := dtos.FromRequestJSON[dtos.PartReservationCreate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if reservationId, err := controller.service.Reserve(id, reservationCreate); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, "Unable to find work order #%v or spare part #%v for reserving", id, reservationCreate.PartId)
+	} else if err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), workOrderError, "Reserve parts for", id, err)
+	} else {
+		writeMessage(writer, http.StatusCreated, "Reservation #%v of work order #%v is created", reservationId, id)
+	}
+}
+
+func (controller *Inventory) Release(writer http.ResponseWriter, request *http.Request) {
+	vars := mux.Vars(request)
+	if id, ok := vars["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if reservationId, ok := vars["reservation_id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "reservation_id")
+	} else if released, err := controller.service.Release(id, reservationId); err != nil {
+		writeMessage(writer, http.StatusBadRequest, workOrderError, "Release parts of", id, err)
+	} else if !released {
+		writeMessage(writer, http.StatusNotFound, "Unable to find reservation #%v of work order #%v for releasing", reservationId, id)
+	} else {
+		writeMessage(writer, http.StatusOK, "Reservation #%v of work order #%v is released", reservationId, id)
+	}
+}
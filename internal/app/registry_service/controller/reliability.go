@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"net/http"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+type Reliability struct {
+	service *service.Reliability
+}
+
+func NewReliability(service *service.Reliability) Reliability {
+	return Reliability{service: service}
+}
+
+func (controller *Reliability) Report(writer http.ResponseWriter, request *http.Request) {
+	if reportQuery, err := dtos.ReportQueryFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if reliabilityReport, err := controller.service.Report(reportQuery); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "Reliability report error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, reliabilityReport)
+	}
+}
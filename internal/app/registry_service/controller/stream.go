@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+type Stream struct {
+	stream *service.EquipmentStream
+}
+
+func NewStream(stream *service.EquipmentStream) Stream {
+	return Stream{stream: stream}
+}
+
+// Changes streams changes of the equipment matching the filter as server-sent events;
+// the client reconnecting with Last-Event-ID header receives the changes it has missed or the reset event.
+func (controller *Stream) Changes(writer http.ResponseWriter, request *http.Request) {
+	flusher, ok := writer.(http.Flusher)
+	if !ok {
+		writeMessage(writer, http.StatusInternalServerError, "Streaming is not supported")
+		return
+	}
+	equipmentFilter, err := dtos.EquipmentFilterFromRequest(request)
+	if err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+		return
+	}
+	if equipmentFilter.AsOf != nil || equipmentFilter.FiltersConnectivity() {
+		writeMessage(writer, http.StatusBadRequest, "Parameters `as_of`, `connectivity` and `last_seen_*` are not supported by the stream")
+		return
+	}
+	var after *model.OutboxPosition
+	if header := request.Header.Get("Last-Event-ID"); header != "" {
+		position, err := model.ParseOutboxPosition(header)
+		if err != nil {
+			writeMessage(writer, http.StatusBadRequest, "Invalid Last-Event-ID: `%s`", header)
+			return
+		}
+		after = &position
+	}
+	subscription, backlog, err := controller.stream.Subscribe(equipmentFilter, after)
+	if err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "Stream error: %v", err)
+		return
+	}
+	defer controller.stream.Unsubscribe(subscription)
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("Connection", "keep-alive")
+	writer.WriteHeader(http.StatusOK)
+	var sent model.OutboxPosition
+	for i := range backlog {
+		if writeEvent(writer, &backlog[i]) != nil {
+			return
+		}
+		sent = backlog[i].Position
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+			case <-request.Context().Done():
+				return
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(writer, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			case streamChange, ok := <-subscription.Changes():
+				if !ok {
+					return // The client is expected to reconnect
+				}
+				if !sent.Less(streamChange.Position) {
+					continue // Already sent from the backlog
+				}
+				if writeEvent(writer, &streamChange) != nil {
+					return
+				}
+				sent = streamChange.Position
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes the change as the event; the reset event has the empty object as the data, since the event without data is not dispatched.
+func writeEvent(writer http.ResponseWriter, streamChange *service.StreamChange) error {
+	data := []byte("{}")
+	var err error
+	if streamChange.Change != nil {
+		data, err = json.Marshal(streamChange.Change)
+	}
+	if err == nil {
+		_, err = fmt.Fprintf(writer, "id: %v\nevent: %s\ndata: %s\n\n", streamChange.Position, streamChange.Event, data)
+	}
+	return err
+}
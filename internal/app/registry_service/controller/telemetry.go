@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+type Telemetry struct {
+	service *service.Telemetry
+}
+
+func NewTelemetry(service *service.Telemetry) Telemetry {
+	return Telemetry{service: service}
+}
+
+// writeReadingsError responds with reading-level errors if err is caused by readings not matching the kind;
+// returns false if it is not.
+func writeReadingsError(writer http.ResponseWriter, err error) bool {
+	var readingsError *service.ReadingsError
+	if !errors.As(err, &readingsError) {
+		return false
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writeJSON(writer, http.StatusUnprocessableEntity, parametersErrorBody{
+		Kind:	readingsError.Kind,
+		Errors:	readingsError.Fields,
+	})
+	return true
+}
+
+func (controller *Telemetry) Ingest(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if telemetryBatch, err := dtos.FromRequestJSON[dtos.TelemetryBatch](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if count, err := controller.service.Ingest(id, *telemetryBatch); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, id, "telemetry")
+	} else if err != nil {
+		if writeReadingsError(writer, err) {
+			return
+		}
+		writeMessage(writer, http.StatusBadRequest, equipmentIdError, "Ingest telemetry of", id, err)
+	} else {
+		writeMessage(writer, http.StatusCreated, "%d readings of equipment #%v are stored", count, id)
+	}
+}
+
+func (controller *Telemetry) Series(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if telemetryQuery, err := dtos.TelemetryQueryFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if telemetrySeries, err := controller.service.Series(id, telemetryQuery); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, id, "telemetry")
+	} else if errors.Is(err, service.ErrBeyondRetention) {
+		writeMessage(writer, http.StatusUnprocessableEntity, equipmentIdError, "Telemetry of", id, err)
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, equipmentIdError, "Telemetry of", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, telemetrySeries)
+	}
+}
+
+func (controller *Telemetry) FleetSeries(writer http.ResponseWriter, request *http.Request) {
+	if fleetTelemetryQuery, err := dtos.FleetTelemetryQueryFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if telemetrySeries, err := controller.service.FleetSeries(fleetTelemetryQuery); errors.Is(err, service.ErrBeyondRetention) {
+		writeMessage(writer, http.StatusUnprocessableEntity, "Fleet telemetry error: %v", err)
+	} else if err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "Fleet telemetry error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, telemetrySeries)
+	}
+}
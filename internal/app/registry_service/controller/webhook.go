@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+const (
+	unableToFindWebhook string = "Unable to find webhook #%v for %s"
+	webhookError = "%s webhook #%v error: %v"
+	webhookActionIsPerformed = "Webhook #%v is %s"
+)
+
+type Webhook struct {
+	service *service.Webhook
+}
+
+func NewWebhook(service *service.Webhook) Webhook {
+	return Webhook{service: service}
+}
+
+func (controller *Webhook) List(writer http.ResponseWriter, request *http.Request) {
+	if webhookList, err := controller.service.List(); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, webhookList)
+	}
+}
+
+func (controller *Webhook) Create(writer http.ResponseWriter, request *http.Request) {
+	if webhookCreate, err := dtos.FromRequestJSON[dtos.WebhookCreate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if id, err := controller.service.Create(webhookCreate); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Create webhook error: %v", err)
+	} else {
+		writeMessage(writer, http.StatusCreated, webhookActionIsPerformed, id, "created")
+	}
+}
+
+func (controller *Webhook) Update(writer http.ResponseWriter, request *http.Request) {
+	if webhookUpdate, err := dtos.FromRequestJSON[dtos.WebhookUpdate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if updated, err := controller.service.Update(webhookUpdate); err != nil {
+		writeMessage(writer, http.StatusBadRequest, webhookError, "Update", webhookUpdate.Id, err)
+	} else if !updated {
+		writeMessage(writer, http.StatusNotFound, unableToFindWebhook, webhookUpdate.Id, "updating")
+	} else {
+		writeMessage(writer, http.StatusOK, webhookActionIsPerformed, webhookUpdate.Id, "updated")
+	}
+}
+
+func (controller *Webhook) Get(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if webhookGet, err := controller.service.Get(id); err != nil {
+		writeMessage(writer, http.StatusNotFound, webhookError, "Get", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, webhookGet)
+	}
+}
+
+func (controller *Webhook) Delete(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if deleted, err := controller.service.Delete(id); err != nil {
+		writeMessage(writer, http.StatusBadRequest, webhookError, "Delete", id, err)
+	} else if !deleted {
+		writeMessage(writer, http.StatusNotFound, unableToFindWebhook, id, "deleting")
+	} else {
+		writeMessage(writer, http.StatusOK, webhookActionIsPerformed, id, "deleted")
+	}
+}
+
+func (controller *Webhook) Deliveries(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if page, err := dtos.HistoryPageFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if webhookDeliveries, err := controller.service.Deliveries(id, page); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindWebhook, id, "deliveries")
+	} else if err != nil {
+		writeMessage(writer, http.StatusBadRequest, webhookError, "Deliveries", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, webhookDeliveries)
+	}
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"github.com/gorilla/mux"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/service"
+)
+
+const (
+	unableToFindWorkOrder string = "Unable to find work order #%v for %s"
+	workOrderError = "%s work order #%v error: %v"
+	workOrderActionIsPerformed = "Work order #%v is %s"
+)
+
+type WorkOrder struct {
+	service *service.WorkOrders
+}
+
+func NewWorkOrder(service *service.WorkOrders) WorkOrder {
+	return WorkOrder{service: service}
+}
+
+func (controller *WorkOrder) List(writer http.ResponseWriter, request *http.Request) {
+	if workOrderFilter, err := dtos.WorkOrderFilterFromRequest(request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, "Invalid GET parameters: %v", err)
+	} else if workOrderList, err := controller.service.List(workOrderFilter); err != nil {
+		writeMessage(writer, http.StatusInternalServerError, "List error: %v", err)
+	} else {
+		writeJSON(writer, http.StatusOK, workOrderList)
+	}
+}
+
+func (controller *WorkOrder) Create(writer http.ResponseWriter, request *http.Request) {
+	if workOrderCreate, err := dtos.FromRequestJSON[dtos.WorkOrderCreate](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else if id, err := controller.service.Create(workOrderCreate); errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindEquipment, workOrderCreate.EquipmentId, "work order")
+	} else if err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), "Create work order error: %v", err)
+	} else {
+		writeMessage(writer, http.StatusCreated, workOrderActionIsPerformed, id, "created")
+	}
+}
+
+func (controller *WorkOrder) Get(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if workOrderGet, err := controller.service.Get(id); err != nil {
+		writeMessage(writer, http.StatusNotFound, workOrderError, "Get", id, err)
+	} else {
+		writeJSON(writer, http.StatusOK, workOrderGet)
+	}
+}
+
+// writeChange responds to the change of the work order, e.g. `assigning` it to get it `assigned`.
+func writeChange(writer http.ResponseWriter, id string, changing, changed string, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeMessage(writer, http.StatusNotFound, unableToFindWorkOrder, id, changing)
+	} else if err != nil {
+		writeMessage(writer, conflictOr(err, http.StatusBadRequest), workOrderError, "Update", id, err)
+	} else {
+		writeMessage(writer, http.StatusOK, workOrderActionIsPerformed, id, changed)
+	}
+}
+
+func (controller *WorkOrder) Assign(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if workOrderAssign, err := dtos.FromRequestJSON[dtos.WorkOrderAssign](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else {
+		writeChange(writer, id, "assigning", "assigned", controller.service.Assign(id, workOrderAssign))
+	}
+}
+
+func (controller *WorkOrder) Start(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else {
+		writeChange(writer, id, "starting", "started", controller.service.Start(id))
+	}
+}
+
+func (controller *WorkOrder) Close(writer http.ResponseWriter, request *http.Request) {
+	if id, ok := mux.Vars(request)["id"]; !ok {
+		writeMessage(writer, http.StatusBadRequest, parameterIsRequired, "id")
+	} else if workOrderClose, err := dtos.FromRequestJSON[dtos.WorkOrderClose](request); err != nil {
+		writeMessage(writer, http.StatusBadRequest, invalidJSONData, err)
+	} else {
+		writeChange(writer, id, "closing", "closed", controller.service.Close(id, workOrderClose))
+	}
+}
@@ -6,18 +6,20 @@ import (
 	_ "github.com/lib/pq"
 )
 
-func Connect(driver, host string, port uint16, dbName, user, password string, ssl bool) (*sqlx.DB, error) {
+func DSN(host string, port uint16, dbName, user, password string, ssl bool) string {
 	var prefix string
 	if ssl {
 		prefix += "en"
 	} else {
 		prefix += "dis"
 	}
-	return sqlx.Open(driver,
-		fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s sslmode=%sable",
-			host, port, dbName, user, password, prefix,
-		),
+	return fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s sslmode=%sable",
+		host, port, dbName, user, password, prefix,
 	)
+}
+
+func Connect(driver, host string, port uint16, dbName, user, password string, ssl bool) (*sqlx.DB, error) {
+	return sqlx.Open(driver, DSN(host, port, dbName, user, password, ssl))
 	/*db, err := sqlx.Open(driver, dsn)
 	if err != nil {
 		log.Error().Err(err).Msgf("failed to create database connection")
@@ -0,0 +1,248 @@
+package dtos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	invalidRuleName string = "Rule name should not be empty nor longer than 128 characters"
+	invalidOperator = "`operator` must be one of >, >=, <, <=, got `%s`"
+	thresholdIsRequired = "`threshold` is required"
+	invalidFor = "`for` must be non-negative duration, e.g. `2m`, got `%s`"
+	negativeHysteresis = "`hysteresis` must not be negative"
+	invalidAlertState = "Invalid alert state: `%s`"
+)
+
+func parseFor(duration string) (int64, error) {
+	if duration == "" {
+		return 0, nil
+	}
+	parsed, err := time.ParseDuration(duration)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf(invalidFor, duration)
+	}
+	return int64(math.Ceil(parsed.Seconds())), nil
+}
+
+func validateRuleName(name string) error {
+	if name == "" || len(name) > 128 {
+		return errors.New(invalidRuleName)
+	}
+	return nil
+}
+
+
+type AlertRuleCreate struct {
+	Kind		model.EquipmentKind			`json:"kind"`
+	Name		string						`json:"name"`
+	Metric		string						`json:"metric"`
+	Operator	model.AlertOperator			`json:"operator"`
+	Threshold	*float64					`json:"threshold"`
+	For			string						`json:"for"`			// Go duration, fires at once if empty
+	Hysteresis	float64						`json:"hysteresis"`
+	WhileStatus	*model.OperationalStatus	`json:"while_status"`	// Any status if omitted
+	Active		*bool						`json:"active"`			// True by default
+}
+
+func (ruleCreate AlertRuleCreate) Validate() error {
+	if !ruleCreate.Kind.IsValid() {
+		return fmt.Errorf(invalidFieldValue, "kind", ruleCreate.Kind)
+	}
+	if err := validateRuleName(ruleCreate.Name); err != nil {
+		return err
+	}
+	if ruleCreate.Metric == "" {
+		return fmt.Errorf(parameterIsRequired, "metric")
+	}
+	if !ruleCreate.Operator.IsValid() {
+		return fmt.Errorf(invalidOperator, ruleCreate.Operator)
+	}
+	if ruleCreate.Threshold == nil {
+		return errors.New(thresholdIsRequired)
+	}
+	if ruleCreate.Hysteresis < 0 {
+		return errors.New(negativeHysteresis)
+	}
+	if ruleCreate.WhileStatus != nil && !ruleCreate.WhileStatus.IsValid() {
+		return fmt.Errorf(invalidFieldValue, "while_status", *ruleCreate.WhileStatus)
+	}
+	_, err := parseFor(ruleCreate.For)
+	return err
+}
+
+func (ruleCreate *AlertRuleCreate) ForSeconds() int64 {
+	forSeconds, _ := parseFor(ruleCreate.For)
+	return forSeconds
+}
+
+
+// AlertRuleUpdate cannot change the kind of the rule; `while_status` set to -1 removes the status condition.
+type AlertRuleUpdate struct {
+	Id			int64						`json:"id"`
+	Name		*string						`json:"name"`
+	Metric		*string						`json:"metric"`
+	Operator	*model.AlertOperator		`json:"operator"`
+	Threshold	*float64					`json:"threshold"`
+	For			*string						`json:"for"`
+	Hysteresis	*float64					`json:"hysteresis"`
+	WhileStatus	*model.OperationalStatus	`json:"while_status"`
+	Active		*bool						`json:"active"`	// Deactivation, as well as the change of the condition, closes the open alerts of the rule
+}
+
+func (ruleUpdate AlertRuleUpdate) Validate() error {
+	if ruleUpdate.Name == nil && ruleUpdate.Metric == nil && ruleUpdate.Operator == nil && ruleUpdate.Threshold == nil &&
+		ruleUpdate.For == nil && ruleUpdate.Hysteresis == nil && ruleUpdate.WhileStatus == nil && ruleUpdate.Active == nil {
+		return errors.New(nothingToUpdate)
+	}
+	if ruleUpdate.Name != nil {
+		if err := validateRuleName(*ruleUpdate.Name); err != nil {
+			return err
+		}
+	}
+	if ruleUpdate.Metric != nil && *ruleUpdate.Metric == "" {
+		return fmt.Errorf(parameterIsRequired, "metric")
+	}
+	if ruleUpdate.Operator != nil && !ruleUpdate.Operator.IsValid() {
+		return fmt.Errorf(invalidOperator, *ruleUpdate.Operator)
+	}
+	if ruleUpdate.Hysteresis != nil && *ruleUpdate.Hysteresis < 0 {
+		return errors.New(negativeHysteresis)
+	}
+	if ruleUpdate.WhileStatus != nil && *ruleUpdate.WhileStatus != -1 && !ruleUpdate.WhileStatus.IsValid() {
+		return fmt.Errorf(invalidFieldValue, "while_status", *ruleUpdate.WhileStatus)
+	}
+	if ruleUpdate.For != nil {
+		_, err := parseFor(*ruleUpdate.For)
+		return err
+	}
+	return nil
+}
+
+// ForSeconds returns nil unless `for` is updated.
+func (ruleUpdate *AlertRuleUpdate) ForSeconds() *int64 {
+	if ruleUpdate.For == nil {
+		return nil
+	}
+	forSeconds, _ := parseFor(*ruleUpdate.For)
+	return &forSeconds
+}
+
+// ChangesCondition reports whether the update changes the condition the open alerts of the rule have been evaluated against.
+func (ruleUpdate *AlertRuleUpdate) ChangesCondition() bool {
+	return ruleUpdate.Metric != nil || ruleUpdate.Operator != nil || ruleUpdate.Threshold != nil || ruleUpdate.For != nil ||
+		ruleUpdate.Hysteresis != nil || ruleUpdate.WhileStatus != nil
+}
+
+
+type AlertRuleGet struct {
+	Id			int64						`json:"id"`
+	Kind		model.EquipmentKind			`json:"kind"`
+	Name		string						`json:"name"`
+	Metric		string						`json:"metric"`
+	Operator	model.AlertOperator			`json:"operator"`
+	Threshold	float64						`json:"threshold"`
+	For			string						`json:"for"`
+	Hysteresis	float64						`json:"hysteresis"`
+	WhileStatus	*model.OperationalStatus	`json:"while_status"`
+	Active		bool						`json:"active"`
+	CreatedAt	time.Time					`json:"created_at"`
+	UpdatedAt	time.Time					`json:"updated_at"`
+}
+
+func AlertRuleGetFromModel(ruleModel model.AlertRule) *AlertRuleGet {
+	return &AlertRuleGet {
+		Id:				ruleModel.Id,
+		Kind:			ruleModel.Kind,
+		Name:			ruleModel.Name,
+		Metric:			ruleModel.Metric,
+		Operator:		ruleModel.Operator,
+		Threshold:		ruleModel.Threshold,
+		For:			(time.Duration(ruleModel.ForSeconds) * time.Second).String(),
+		Hysteresis:		ruleModel.Hysteresis,
+		WhileStatus:	ruleModel.WhileStatus,
+		Active:			ruleModel.Active,
+		CreatedAt:		ruleModel.CreatedAt,
+		UpdatedAt:		ruleModel.UpdatedAt,
+	}
+}
+
+
+type AlertGet struct {
+	Id			int64					`json:"id"`
+	RuleId		int64					`json:"rule_id"`
+	EquipmentId	uuid.UUID				`json:"equipment_id"`
+	Kind		model.EquipmentKind		`json:"kind"`
+	Metric		string					`json:"metric"`
+	State		model.AlertState		`json:"state"`
+	Value		float64					`json:"value"`
+	StartedAt	time.Time				`json:"started_at"`
+	FiredAt		*time.Time				`json:"fired_at"`
+	ResolvedAt	*time.Time				`json:"resolved_at"`
+	UpdatedAt	time.Time				`json:"updated_at"`
+}
+
+func AlertGetFromModel(alertModel model.Alert) *AlertGet {
+	return &AlertGet {
+		Id:				alertModel.Id,
+		RuleId:			alertModel.RuleId,
+		EquipmentId:	alertModel.EquipmentId,
+		Kind:			alertModel.Kind,
+		Metric:			alertModel.Metric,
+		State:			alertModel.State,
+		Value:			alertModel.Value,
+		StartedAt:		alertModel.StartedAt,
+		FiredAt:		alertModel.FiredAt,
+		ResolvedAt:		alertModel.ResolvedAt,
+		UpdatedAt:		alertModel.UpdatedAt,
+	}
+}
+
+
+// AlertFilter selects alerts by any of the values of each field; the page lists the newest alerts first.
+type AlertFilter struct {
+	HistoryPage
+	EquipmentIds	[]uuid.UUID				`schema:"equipment_id"`
+	Kinds			[]model.EquipmentKind	`schema:"kind"`
+	States			[]model.AlertState		`schema:"state"`
+	RuleIds			[]int64					`schema:"rule_id"`
+}
+
+func (alertFilter *AlertFilter) Validate() error {
+	for _, kind := range alertFilter.Kinds {
+		if !kind.IsValid() {
+			return fmt.Errorf(invalidFieldValue, "kind", kind)
+		}
+	}
+	for _, state := range alertFilter.States {
+		if !state.IsValid() {
+			return fmt.Errorf(invalidAlertState, state)
+		}
+	}
+	return alertFilter.HistoryPage.Validate()
+}
+
+func AlertFilterFromRequest(request *http.Request) (*AlertFilter, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var alertFilter AlertFilter
+		if err = newDecoder().Decode(&alertFilter, request.Form); err == nil {
+			if err = alertFilter.Validate(); err == nil {
+				return &alertFilter, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+type AlertList struct {
+	Total	int			`json:"total"`
+	Page	int			`json:"page"`
+	PerPage	int			`json:"per_page"`
+	Alerts	[]*AlertGet	`json:"alerts"`
+}
@@ -0,0 +1,251 @@
+package dtos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/schema"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	defaultAlpha float64 = 0.1
+	defaultWindow int = 60
+	maxWindow = 1000
+	defaultAnomalyThreshold float64 = 3
+	defaultWarmup int = 30
+	invalidMethod = "`method` must be `ewma` or `zscore`, got `%s`"
+	invalidAlpha = "`alpha` must be in (0, 1], got %v"
+	invalidWindow = "`window` must be in [2, %d], got %d"
+	invalidAnomalyThreshold = "`threshold` must be positive, got %v"
+	invalidWarmup = "`warmup` must be at least 2, got %d"
+	warmupExceedsWindow = "`warmup` (%d) must not exceed `window` (%d) of `zscore`"
+)
+
+// ValidateDetector checks the detector parameters as a whole.
+func ValidateDetector(detector *model.AnomalyDetector) error {
+	if !detector.Method.IsValid() {
+		return fmt.Errorf(invalidMethod, detector.Method)
+	}
+	if detector.Alpha <= 0 || detector.Alpha > 1 {
+		return fmt.Errorf(invalidAlpha, detector.Alpha)
+	}
+	if detector.Window < 2 || detector.Window > maxWindow {
+		return fmt.Errorf(invalidWindow, maxWindow, detector.Window)
+	}
+	if detector.Threshold <= 0 {
+		return fmt.Errorf(invalidAnomalyThreshold, detector.Threshold)
+	}
+	if detector.Warmup < 2 {
+		return fmt.Errorf(invalidWarmup, detector.Warmup)
+	}
+	if detector.Method == model.ZScore && detector.Warmup > detector.Window {
+		return fmt.Errorf(warmupExceedsWindow, detector.Warmup, detector.Window)
+	}
+	return nil
+}
+
+
+type AnomalyDetectorCreate struct {
+	Kind		model.EquipmentKind		`json:"kind"`
+	Metric		string					`json:"metric"`
+	Method		model.DetectorMethod	`json:"method"`		// `ewma` by default
+	Alpha		*float64				`json:"alpha"`
+	Window		*int					`json:"window"`
+	Threshold	*float64				`json:"threshold"`
+	Warmup		*int					`json:"warmup"`		// The window if it is shorter
+	Active		*bool					`json:"active"`		// True by default
+}
+
+func (detectorCreate AnomalyDetectorCreate) Validate() error {
+	if !detectorCreate.Kind.IsValid() {
+		return fmt.Errorf(invalidFieldValue, "kind", detectorCreate.Kind)
+	}
+	if detectorCreate.Metric == "" {
+		return fmt.Errorf(parameterIsRequired, "metric")
+	}
+	return ValidateDetector(detectorCreate.Detector())
+}
+
+// Detector returns the detector with the defaults for the omitted parameters.
+func (detectorCreate *AnomalyDetectorCreate) Detector() *model.AnomalyDetector {
+	detector := model.AnomalyDetector {
+		Kind:		detectorCreate.Kind,
+		Metric:		detectorCreate.Metric,
+		Method:		detectorCreate.Method,
+		Alpha:		defaultAlpha,
+		Window:		defaultWindow,
+		Threshold:	defaultAnomalyThreshold,
+		Active:		detectorCreate.Active == nil || *detectorCreate.Active,
+	}
+	if detector.Method == "" {
+		detector.Method = model.EWMA
+	}
+	if detectorCreate.Alpha != nil {
+		detector.Alpha = *detectorCreate.Alpha
+	}
+	if detectorCreate.Window != nil {
+		detector.Window = *detectorCreate.Window
+	}
+	if detectorCreate.Threshold != nil {
+		detector.Threshold = *detectorCreate.Threshold
+	}
+	if detectorCreate.Warmup != nil {
+		detector.Warmup = *detectorCreate.Warmup
+	} else if detector.Method == model.ZScore {
+		detector.Warmup = min(defaultWarmup, detector.Window)
+	} else {
+		detector.Warmup = defaultWarmup
+	}
+	return &detector
+}
+
+
+// AnomalyDetectorUpdate cannot change the kind of the detector; the change of the metric or the method parameters
+// resets the baselines.
+type AnomalyDetectorUpdate struct {
+	Id			int64					`json:"id"`
+	Metric		*string					`json:"metric"`
+	Method		*model.DetectorMethod	`json:"method"`
+	Alpha		*float64				`json:"alpha"`
+	Window		*int					`json:"window"`
+	Threshold	*float64				`json:"threshold"`
+	Warmup		*int					`json:"warmup"`
+	Active		*bool					`json:"active"`
+}
+
+func (detectorUpdate AnomalyDetectorUpdate) Validate() error {
+	if detectorUpdate.Metric == nil && detectorUpdate.Method == nil && detectorUpdate.Alpha == nil && detectorUpdate.Window == nil &&
+		detectorUpdate.Threshold == nil && detectorUpdate.Warmup == nil && detectorUpdate.Active == nil {
+		return errors.New(nothingToUpdate)
+	}
+	if detectorUpdate.Metric != nil && *detectorUpdate.Metric == "" {
+		return fmt.Errorf(parameterIsRequired, "metric")
+	}
+	return nil
+}
+
+// Apply changes the detector and reports whether its baselines are to be reset.
+func (detectorUpdate *AnomalyDetectorUpdate) Apply(detector *model.AnomalyDetector) bool {
+	before := *detector
+	if detectorUpdate.Metric != nil {
+		detector.Metric = *detectorUpdate.Metric
+	}
+	if detectorUpdate.Method != nil {
+		detector.Method = *detectorUpdate.Method
+	}
+	if detectorUpdate.Alpha != nil {
+		detector.Alpha = *detectorUpdate.Alpha
+	}
+	if detectorUpdate.Window != nil {
+		detector.Window = *detectorUpdate.Window
+	}
+	if detectorUpdate.Threshold != nil {
+		detector.Threshold = *detectorUpdate.Threshold
+	}
+	if detectorUpdate.Warmup != nil {
+		detector.Warmup = *detectorUpdate.Warmup
+	}
+	if detectorUpdate.Active != nil {
+		detector.Active = *detectorUpdate.Active
+	}
+	return detector.Metric != before.Metric || detector.Method != before.Method ||
+		detector.Alpha != before.Alpha || detector.Window != before.Window
+}
+
+
+type AnomalyDetectorGet struct {
+	Id			int64					`json:"id"`
+	Kind		model.EquipmentKind		`json:"kind"`
+	Metric		string					`json:"metric"`
+	Method		model.DetectorMethod	`json:"method"`
+	Alpha		float64					`json:"alpha"`
+	Window		int						`json:"window"`
+	Threshold	float64					`json:"threshold"`
+	Warmup		int						`json:"warmup"`
+	Active		bool					`json:"active"`
+	CreatedAt	time.Time				`json:"created_at"`
+	UpdatedAt	time.Time				`json:"updated_at"`
+}
+
+func AnomalyDetectorGetFromModel(detectorModel model.AnomalyDetector) *AnomalyDetectorGet {
+	return &AnomalyDetectorGet {
+		Id:			detectorModel.Id,
+		Kind:		detectorModel.Kind,
+		Metric:		detectorModel.Metric,
+		Method:		detectorModel.Method,
+		Alpha:		detectorModel.Alpha,
+		Window:		detectorModel.Window,
+		Threshold:	detectorModel.Threshold,
+		Warmup:		detectorModel.Warmup,
+		Active:		detectorModel.Active,
+		CreatedAt:	detectorModel.CreatedAt,
+		UpdatedAt:	detectorModel.UpdatedAt,
+	}
+}
+
+
+type AnomalyGet struct {
+	Id			int64					`json:"id"`
+	DetectorId	int64					`json:"detector_id"`
+	Metric		string					`json:"metric"`
+	Method		model.DetectorMethod	`json:"method"`
+	Value		float64					`json:"value"`
+	Expected	float64					`json:"expected"`
+	Score		float64					`json:"score"`
+	RecordedAt	time.Time				`json:"recorded_at"`
+	DetectedAt	time.Time				`json:"detected_at"`
+}
+
+func AnomalyGetFromModel(anomalyModel model.Anomaly) *AnomalyGet {
+	return &AnomalyGet {
+		Id:			anomalyModel.Id,
+		DetectorId:	anomalyModel.DetectorId,
+		Metric:		anomalyModel.Metric,
+		Method:		anomalyModel.Method,
+		Value:		anomalyModel.Value,
+		Expected:	anomalyModel.Expected,
+		Score:		anomalyModel.Score,
+		RecordedAt:	anomalyModel.RecordedAt,
+		DetectedAt:	anomalyModel.DetectedAt,
+	}
+}
+
+
+// AnomalyFilter selects the anomalies of the equipment; the page lists the latest readings first.
+type AnomalyFilter struct {
+	HistoryPage
+	Metrics		[]string	`schema:"metric"`
+	From		*time.Time	`schema:"from"`
+	To			*time.Time	`schema:"to"`	// Exclusive
+}
+
+func (anomalyFilter *AnomalyFilter) Validate() error {
+	if anomalyFilter.From != nil && anomalyFilter.To != nil && !anomalyFilter.From.Before(*anomalyFilter.To) {
+		return fmt.Errorf(mustPrecede, "`from`", "`to`")
+	}
+	return anomalyFilter.HistoryPage.Validate()
+}
+
+func AnomalyFilterFromRequest(request *http.Request) (*AnomalyFilter, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var anomalyFilter AnomalyFilter
+		if err = schema.NewDecoder().Decode(&anomalyFilter, request.Form); err == nil {
+			if err = anomalyFilter.Validate(); err == nil {
+				return &anomalyFilter, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+type AnomalyList struct {
+	EquipmentId	uuid.UUID		`json:"equipment_id"`
+	Total		int				`json:"total"`
+	Page		int				`json:"page"`
+	PerPage		int				`json:"per_page"`
+	Anomalies	[]*AnomalyGet	`json:"anomalies"`
+}
@@ -0,0 +1,37 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+// EquipmentChange is the message published for each event of the equipment.
+type EquipmentChange struct {
+	EventId		int64				`json:"event_id"`	// Position of the event in the store
+	Type		model.EventType		`json:"type"`
+	EquipmentId	uuid.UUID			`json:"equipment_id"`
+	Version		int					`json:"version"`
+	OccurredAt	time.Time			`json:"occurred_at"`
+	Event		json.RawMessage		`json:"event"`
+	Equipment	*EquipmentGet		`json:"equipment"`	// State after the event; the last one for EquipmentRemoved
+	Previous	*EquipmentGet		`json:"previous,omitempty"`	// State before the event; none for EquipmentRegistered
+}
+
+func EquipmentChangeFromModel(change *model.AggregateChange) *EquipmentChange {
+	var previous *EquipmentGet
+	if change.Event.Type != model.EquipmentRegistered {
+		previous = EquipmentGetFromModel(change.Previous)
+	}
+	return &EquipmentChange {
+		EventId:		change.Event.Position,
+		Type:			change.Event.Type,
+		EquipmentId:	change.Event.AggregateId,
+		Version:		change.Event.Version,
+		OccurredAt:		change.Event.OccurredAt,
+		Event:			change.Event.Payload,
+		Equipment:		EquipmentGetFromModel(change.State),
+		Previous:		previous,
+	}
+}
@@ -0,0 +1,176 @@
+package dtos
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+const (
+	defaultLimit int = 100
+	maxLimit = 1000
+	invalidLimit = "`limit` must be between 1 and %d, got %d"
+	invalidSort = "`sort` must be `id`, `created_at`, `updated_at`, `kind` or `status`, got `%s`"
+	invalidDirection = "`direction` must be `asc` or `desc`, got `%s`"
+	invalidCursor = "Invalid `cursor`"
+	cursorMismatch = "`cursor` was issued for another `sort` or `direction`"
+)
+
+// EquipmentSort is the column the equipment is ordered by; the ties are ordered by the id.
+type EquipmentSort string
+const (
+	SortById EquipmentSort = "id"	// The ids are UUIDv6, so this is the creation order
+	SortByCreatedAt EquipmentSort = "created_at"
+	SortByUpdatedAt EquipmentSort = "updated_at"
+	SortByKind EquipmentSort = "kind"
+	SortByStatus EquipmentSort = "status"
+)
+
+func (sort EquipmentSort) IsValid() bool {
+	switch sort {
+		case SortById, SortByCreatedAt, SortByUpdatedAt, SortByKind, SortByStatus:
+			return true
+	}
+	return false
+}
+
+// EquipmentCursor is the position after the last equipment of the page: its sort key and id.
+type EquipmentCursor struct {
+	Sort		EquipmentSort	`json:"s"`
+	Descending	bool			`json:"d,omitempty"`
+	Key			string			`json:"k,omitempty"`
+	Id			uuid.UUID		`json:"i"`
+}
+
+// KeyValue returns the sort key as the value of the column.
+func (cursor *EquipmentCursor) KeyValue() (interface{}, error) {
+	switch cursor.Sort {
+		case SortByCreatedAt, SortByUpdatedAt:
+			return time.Parse(time.RFC3339Nano, cursor.Key)
+		case SortByKind, SortByStatus:
+			return strconv.Atoi(cursor.Key)
+	}
+	return nil, nil
+}
+
+func (cursor *EquipmentCursor) Encode() string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(encoded string) (*EquipmentCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New(invalidCursor)
+	}
+	var cursor EquipmentCursor
+	if err = json.Unmarshal(data, &cursor); err != nil || !cursor.Sort.IsValid() {
+		return nil, errors.New(invalidCursor)
+	}
+	if _, err = cursor.KeyValue(); err != nil {
+		return nil, errors.New(invalidCursor)
+	}
+	return &cursor, nil
+}
+
+
+// EquipmentPage selects up to Limit pieces of equipment following the cursor (from the start if it is empty).
+type EquipmentPage struct {
+	Limit		int					`schema:"limit"`
+	Cursor		string				`schema:"cursor"`		// next_cursor of the previous page
+	Sort		EquipmentSort		`schema:"sort"`
+	Direction	string				`schema:"direction"`	// `asc` (default) or `desc`
+	Total		bool				`schema:"total"`		// Count the equipment matching the filter
+	After		*EquipmentCursor	`schema:"-"`			// The decoded cursor
+	Requested	bool				`schema:"-"`			// Any of the parameters of the page is given
+}
+
+func (equipmentPage *EquipmentPage) Validate() error {
+	equipmentPage.Requested = equipmentPage.Limit != 0 || equipmentPage.Cursor != "" || equipmentPage.Sort != "" ||
+		equipmentPage.Direction != "" || equipmentPage.Total
+	if equipmentPage.Limit == 0 {
+		equipmentPage.Limit = defaultLimit
+	} else if equipmentPage.Limit < 0 || equipmentPage.Limit > maxLimit {
+		return fmt.Errorf(invalidLimit, maxLimit, equipmentPage.Limit)
+	}
+	if equipmentPage.Sort == "" {
+		equipmentPage.Sort = SortById
+	} else if !equipmentPage.Sort.IsValid() {
+		return fmt.Errorf(invalidSort, equipmentPage.Sort)
+	}
+	if equipmentPage.Direction == "" {
+		equipmentPage.Direction = "asc"
+	} else if equipmentPage.Direction != "asc" && equipmentPage.Direction != "desc" {
+		return fmt.Errorf(invalidDirection, equipmentPage.Direction)
+	}
+	if equipmentPage.Cursor != "" {
+		cursor, err := decodeCursor(equipmentPage.Cursor)
+		if err != nil {
+			return err
+		}
+		if cursor.Sort != equipmentPage.Sort || cursor.Descending != equipmentPage.Descending() {
+			return errors.New(cursorMismatch)
+		}
+		equipmentPage.After = cursor
+	}
+	return nil
+}
+
+func (equipmentPage *EquipmentPage) Descending() bool {
+	return equipmentPage.Direction == "desc"
+}
+
+// CursorAfter returns the cursor following the equipment in the order of the page.
+func (equipmentPage *EquipmentPage) CursorAfter(equipmentGet *EquipmentGet) *EquipmentCursor {
+	cursor := EquipmentCursor{Sort: equipmentPage.Sort, Descending: equipmentPage.Descending(), Id: equipmentGet.Id}
+	switch equipmentPage.Sort {
+		case SortByCreatedAt:
+			cursor.Key = equipmentGet.CreatedAt.Format(time.RFC3339Nano)
+		case SortByUpdatedAt:
+			cursor.Key = equipmentGet.UpdatedAt.Format(time.RFC3339Nano)
+		case SortByKind:
+			cursor.Key = strconv.Itoa(int(equipmentGet.Kind))
+		case SortByStatus:
+			cursor.Key = strconv.Itoa(int(equipmentGet.Status))
+	}
+	return &cursor
+}
+
+
+// EquipmentQuery is the filter of the equipment along with the page of the list.
+type EquipmentQuery struct {
+	EquipmentFilter
+	EquipmentPage
+}
+
+func (equipmentQuery *EquipmentQuery) Validate() error {
+	if err := equipmentQuery.EquipmentFilter.Validate(); err != nil {
+		return err
+	}
+	return equipmentQuery.EquipmentPage.Validate()
+}
+
+func EquipmentQueryFromRequest(request *http.Request) (*EquipmentQuery, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var equipmentQuery EquipmentQuery
+		if err = decodeFiltered(request.Form, &equipmentQuery, &equipmentQuery.EquipmentFilter); err == nil {
+			if err = equipmentQuery.Validate(); err == nil {
+				return &equipmentQuery, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+// EquipmentList is the page of the equipment listed if the page is requested (see EquipmentPage.Requested).
+type EquipmentList struct {
+	Items		[]*EquipmentGet	`json:"items"`
+	NextCursor	*string			`json:"next_cursor"`		// Null on the last page
+	Total		*int			`json:"total,omitempty"`	// If requested
+}
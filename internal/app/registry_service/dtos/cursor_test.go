@@ -0,0 +1,112 @@
+package dtos
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+func TestEquipmentCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.FixedZone("", 3 * 60 * 60))
+	equipmentGet := EquipmentGet {
+		Id:			uuid.Must(uuid.NewV6()),
+		Kind:		model.RoboticArm,
+		Status:		model.UnderMaintenance,
+		CreatedAt:	createdAt,
+		UpdatedAt:	createdAt.Add(time.Hour),
+	}
+	for _, test := range []struct {
+		sort	EquipmentSort
+		key		interface{}	// The value of the column, nil for the id
+	}{
+		{SortById, nil},
+		{SortByCreatedAt, equipmentGet.CreatedAt},
+		{SortByUpdatedAt, equipmentGet.UpdatedAt},
+		{SortByKind, int(model.RoboticArm)},
+		{SortByStatus, int(model.UnderMaintenance)},
+	} {
+		for _, direction := range []string{"asc", "desc"} {
+			page := EquipmentPage{Sort: test.sort, Direction: direction}
+			if err := page.Validate(); err != nil {
+				t.Fatalf("%s %s: %v", test.sort, direction, err)
+			}
+			next := EquipmentPage{Sort: test.sort, Direction: direction, Cursor: page.CursorAfter(&equipmentGet).Encode()}
+			if err := next.Validate(); err != nil {
+				t.Errorf("%s %s: %v", test.sort, direction, err)
+				continue
+			}
+			if next.After.Id != equipmentGet.Id || next.After.Sort != test.sort || next.After.Descending != (direction == "desc") {
+				t.Errorf("%s %s: cursor %+v after another equipment or in another order", test.sort, direction, next.After)
+			}
+			key, err := next.After.KeyValue()
+			if instant, isTime := test.key.(time.Time); isTime {
+				if decoded, ok := key.(time.Time); err != nil || !ok || !decoded.Equal(instant) {
+					t.Errorf("%s %s: key %v (%v), expected %v", test.sort, direction, key, err, instant)
+				}
+			} else if err != nil || key != test.key {
+				t.Errorf("%s %s: key %v (%v), expected %v", test.sort, direction, key, err, test.key)
+			}
+		}
+	}
+}
+
+func TestEquipmentPageCursorMismatch(t *testing.T) {
+	issuer := EquipmentPage{Sort: SortByKind, Direction: "asc"}
+	if err := issuer.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	cursor := issuer.CursorAfter(&EquipmentGet{Id: uuid.Must(uuid.NewV6()), Kind: model.ConveyorBelt}).Encode()
+	for _, test := range []struct {
+		name	string
+		page	EquipmentPage
+		err		string	// Empty for the cursor accepted
+	}{
+		{"same order", EquipmentPage{Sort: SortByKind, Cursor: cursor}, ""},
+		{"another sort", EquipmentPage{Sort: SortByStatus, Cursor: cursor}, cursorMismatch},
+		{"default sort", EquipmentPage{Cursor: cursor}, cursorMismatch},
+		{"another direction", EquipmentPage{Sort: SortByKind, Direction: "desc", Cursor: cursor}, cursorMismatch},
+	} {
+		err := test.page.Validate()
+		switch {
+			case err == nil && test.err != "":
+				t.Errorf("%s: accepted, expected %q", test.name, test.err)
+			case err != nil && err.Error() != test.err:
+				t.Errorf("%s: error %q, expected %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	encoded := func(data string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(data))
+	}
+	id := uuid.Must(uuid.NewV6())
+	for _, test := range []struct {
+		name	string
+		cursor	string
+		valid	bool
+	}{
+		{"id", encoded(`{"s":"id","i":"` + id.String() + `"}`), true},
+		{"kind", encoded(`{"s":"kind","d":true,"k":"3","i":"` + id.String() + `"}`), true},
+		{"not base64", "!!!", false},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"s":"id"}`)), false},
+		{"not JSON", encoded("id"), false},
+		{"unknown sort", encoded(`{"s":"name","k":"x","i":"` + id.String() + `"}`), false},
+		{"no sort", encoded(`{"i":"` + id.String() + `"}`), false},
+		{"invalid id", encoded(`{"s":"id","i":"1"}`), false},
+		{"invalid time", encoded(`{"s":"created_at","k":"yesterday","i":"` + id.String() + `"}`), false},
+		{"invalid number", encoded(`{"s":"status","k":"x","i":"` + id.String() + `"}`), false},
+	} {
+		cursor, err := decodeCursor(test.cursor)
+		switch {
+			case test.valid && err != nil:
+				t.Errorf("%s: %v", test.name, err)
+			case !test.valid && err == nil:
+				t.Errorf("%s: decoded %+v, expected %q", test.name, cursor, invalidCursor)
+			case !test.valid && err.Error() != invalidCursor:
+				t.Errorf("%s: error %q, expected %q", test.name, err, invalidCursor)
+		}
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strconv"
 	"time"
 	"github.com/gofrs/uuid"
 	"github.com/gorilla/schema"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/rsql"
 )
 
 const (
@@ -37,6 +40,30 @@ func FromJSON[DTO validableDTO](decoder *json.Decoder) (*DTO, error) {
 	return nil, err
 }
 
+// ParseKind takes the kind by its identifier or by its name in the catalog (see model.ParseEquipmentKind).
+func ParseKind(kindIdOrName string) (model.EquipmentKind, bool) {
+	if kind := model.ParseEquipmentKind(kindIdOrName); kind != nil {
+		return *kind, true
+	}
+	number, err := strconv.ParseInt(kindIdOrName, 10, 16)
+	if kind := model.EquipmentKind(number); err == nil && kind.IsValid() {
+		return kind, true
+	}
+	return 0, false
+}
+
+// newDecoder decodes the forms taking the kinds by their identifiers or names.
+func newDecoder() *schema.Decoder {
+	decoder := schema.NewDecoder()
+	decoder.RegisterConverter(model.EquipmentKind(0), func(value string) reflect.Value {
+		if kind, ok := ParseKind(value); ok {
+			return reflect.ValueOf(kind)
+		}
+		return reflect.Value{}	// Fails the conversion
+	})
+	return decoder
+}
+
 func FromRequestJSON[DTO validableDTO](request *http.Request) (*DTO, error) {
 	return FromJSON[DTO](json.NewDecoder(request.Body))
 }
@@ -51,7 +78,7 @@ func (eqc EquipmentCreate) Validate() error {
 	if !eqc.Kind.IsValid() {
 		return fmt.Errorf("Invalid Equipment.Kind: %d", eqc.Kind)
 	}
-	if eqc.Parameters == nil { // TODO: check when not nil
+	if eqc.Parameters == nil { // Contents are checked against the schema of the kind by the service
 		return errors.New(emptyParameters)
 	}
 	return nil
@@ -62,13 +89,14 @@ type EquipmentUpdate struct {
 	Id			uuid.UUID					`json:"id"`
 	Status		*model.OperationalStatus	`json:"status"`
 	Parameters	*map[string]interface{}		`json:"parameters"`
+	Reason		string						`json:"reason"`	// Recorded with the status transition
+	Actor		string						`json:"actor"`
 }
 
 func (equipmentUpdate EquipmentUpdate) Validate() error {
 	if equipmentUpdate.Status != nil && !equipmentUpdate.Status.IsValid() {
 		return fmt.Errorf("Invalid Equipment.Status: %d", *equipmentUpdate.Status)
 	}
-	// TODO: check non-empty Parameters
 	return nil
 }
 
@@ -80,19 +108,26 @@ type EquipmentGet struct {
 	Parameters	map[string]interface{}	`json:"parameters"`
 	CreatedAt	time.Time				`json:"created_at"`
 	UpdatedAt	time.Time				`json:"updated_at"`
+	LastSeenAt	*time.Time				`json:"last_seen_at,omitempty"`	// Omitted for the past states
+	Connectivity	model.Connectivity	`json:"connectivity,omitempty"`
 }
 
 func EquipmentGetFromModel(equipmentModel model.Equipment) *EquipmentGet {
 	var parameters map[string]interface{}
 	_ = json.Unmarshal(equipmentModel.Parameters, &parameters)
-	return &EquipmentGet {
+	equipmentGet := EquipmentGet {
 		Id:			equipmentModel.Id,
 		Kind:		equipmentModel.Kind,
 		Status:		equipmentModel.Status,
 		Parameters:	parameters,
 		CreatedAt:	equipmentModel.CreatedAt,
 		UpdatedAt:	equipmentModel.UpdatedAt,
+		LastSeenAt:	equipmentModel.LastSeenAt,
+	}
+	if equipmentModel.Connectivity != nil {
+		equipmentGet.Connectivity = *equipmentModel.Connectivity
 	}
+	return &equipmentGet
 }
 
 
@@ -105,6 +140,13 @@ type EquipmentFilter struct {
 	CreatedUntil	*time.Time					`schema:"created_until"`
 	UpdatedSince	*time.Time					`schema:"updated_since"`
 	UpdatedUntil	*time.Time					`schema:"updated_until"`
+	AsOf			*time.Time					`schema:"as_of"`	// Filter the state of equipment at the instant
+	LastSeenSince	*time.Time					`schema:"last_seen_since"`
+	LastSeenUntil	*time.Time					`schema:"last_seen_until"`
+	Connectivity	[]model.Connectivity		`schema:"connectivity"`
+	Parameters		[]*rsql.Comparison			`schema:"-"`	// Taken from the `param.` keys by ParameterConditionsFromForm
+	Filter			string						`schema:"filter"`	// The filter expression, see ParseFilter
+	Expression		rsql.Node					`schema:"-"`	// Parsed from Filter by Validate
 }
 
 // precedesOthers returns true if time0 is before or equal to any other non-nil time from params;
@@ -128,7 +170,7 @@ func (equipmentFilter *EquipmentFilter) Validate() error {
 				return fmt.Errorf(invalidFieldValue, "kind", kind)
 			}
 		}
-	} else if equipmentFilter.Kinds != nil {
+	} else if equipmentFilter.NoKinds != nil {
 		for _, kind := range equipmentFilter.NoKinds {
 			if !kind.IsValid() {
 				return fmt.Errorf(invalidFieldValue, "no_kind", kind)
@@ -157,21 +199,107 @@ func (equipmentFilter *EquipmentFilter) Validate() error {
 		if equipmentFilter.CreatedUntil != nil && equipmentFilter.CreatedSince.After(*equipmentFilter.CreatedUntil) {
 			return fmt.Errorf(mustPrecede, "`created_since`", "`created_until`")
 		}
-		if equipmentFilter.UpdatedSince != nil && equipmentFilter.UpdatedSince != nil && equipmentFilter.UpdatedSince.Before(*equipmentFilter.CreatedSince) {
+		if equipmentFilter.UpdatedSince != nil && equipmentFilter.UpdatedSince.Before(*equipmentFilter.CreatedSince) {
 			return fmt.Errorf(mustPrecede, "`created_since`", "`updated_since`")
 		}
 	}
 	if equipmentFilter.UpdatedSince != nil && equipmentFilter.UpdatedUntil != nil && equipmentFilter.UpdatedUntil.Before(*equipmentFilter.UpdatedSince) {
 		return fmt.Errorf(mustPrecede, "`updated_since`", "`updated_until`")
 	}
+	if equipmentFilter.LastSeenSince != nil && equipmentFilter.LastSeenUntil != nil && equipmentFilter.LastSeenUntil.Before(*equipmentFilter.LastSeenSince) {
+		return fmt.Errorf(mustPrecede, "`last_seen_since`", "`last_seen_until`")
+	}
+	for _, connectivity := range equipmentFilter.Connectivity {
+		if !connectivity.IsValid() {
+			return fmt.Errorf("Invalid equipment connectivity value: %s", connectivity)
+		}
+	}
+	if equipmentFilter.Filter != "" {
+		expression, err := ParseFilter(equipmentFilter.Filter)
+		if err != nil {
+			return err
+		}
+		equipmentFilter.Expression = expression
+	}
+	if equipmentFilter.AsOf != nil && equipmentFilter.FiltersConnectivity() {
+		return errors.New("Parameter `as_of` cannot be used with `connectivity`, `last_seen_since` and `last_seen_until`")
+	}
 	return nil
 }
 
+// FiltersConnectivity reports whether the filter has conditions on the connectivity which is not a part of the equipment state.
+func (equipmentFilter *EquipmentFilter) FiltersConnectivity() bool {
+	return equipmentFilter.LastSeenSince != nil || equipmentFilter.LastSeenUntil != nil || len(equipmentFilter.Connectivity) > 0 ||
+		Selects(equipmentFilter.Expression, "last_seen_at", "connectivity")
+}
+
+// Condition returns the filter (except AsOf) as the expression tree: the conditions of the fields, the parameter filters
+// and the expression are AND-ed; nil if there are no conditions.
+func (equipmentFilter *EquipmentFilter) Condition() rsql.Node {
+	operands := make([]rsql.Node, 0, 12)
+	if len(equipmentFilter.Kinds) > 0 {
+		operands = append(operands, listComparison("kind", rsql.In, equipmentFilter.Kinds))
+	} else if len(equipmentFilter.NoKinds) > 0 {
+		operands = append(operands, listComparison("kind", rsql.Out, equipmentFilter.NoKinds))
+	}
+	if len(equipmentFilter.Statuses) > 0 {
+		operands = append(operands, listComparison("status", rsql.In, equipmentFilter.Statuses))
+	} else if len(equipmentFilter.NoStatuses) > 0 {
+		operands = append(operands, listComparison("status", rsql.Out, equipmentFilter.NoStatuses))
+	}
+	for _, bound := range []struct {
+		selector	string
+		operator	rsql.Operator
+		instant		*time.Time
+	} {
+		{"created_at", rsql.Ge, equipmentFilter.CreatedSince},
+		{"created_at", rsql.Le, equipmentFilter.CreatedUntil},
+		{"updated_at", rsql.Ge, equipmentFilter.UpdatedSince},
+		{"updated_at", rsql.Le, equipmentFilter.UpdatedUntil},
+		{"last_seen_at", rsql.Ge, equipmentFilter.LastSeenSince},
+		{"last_seen_at", rsql.Le, equipmentFilter.LastSeenUntil},
+	} {
+		if bound.instant != nil {
+			operands = append(operands, &rsql.Comparison{Selector: bound.selector, Operator: bound.operator, Values: []interface{}{*bound.instant}})
+		}
+	}
+	if len(equipmentFilter.Connectivity) > 0 {
+		operands = append(operands, listComparison("connectivity", rsql.In, equipmentFilter.Connectivity))
+	}
+	for _, parameter := range equipmentFilter.Parameters {
+		operands = append(operands, parameter)
+	}
+	if equipmentFilter.Expression != nil {
+		operands = append(operands, equipmentFilter.Expression)
+	}
+	switch len(operands) {
+		case 0:
+			return nil
+		case 1:
+			return operands[0]
+	}
+	return &rsql.And{Operands: operands}
+}
+
+func listComparison[T any](selector string, operator rsql.Operator, values []T) *rsql.Comparison {
+	comparison := rsql.Comparison{Selector: selector, Operator: operator, Values: make([]interface{}, 0, len(values))}
+	for _, value := range values {
+		comparison.Values = append(comparison.Values, value)
+	}
+	return &comparison
+}
+
+// Matches reports whether the equipment satisfies the filter; AsOf is not taken into account.
+func (equipmentFilter *EquipmentFilter) Matches(equipmentGet *EquipmentGet) bool {
+	condition := equipmentFilter.Condition()
+	return condition == nil || Evaluate(condition, equipmentGet)
+}
+
 func EquipmentFilterFromRequest(request *http.Request) (*EquipmentFilter, error) {
 	var err error
 	if err = request.ParseForm(); err == nil {
 		var equipmentFilter EquipmentFilter
-		if err = schema.NewDecoder().Decode(&equipmentFilter, request.Form); err == nil {
+		if err = decodeFiltered(request.Form, &equipmentFilter, &equipmentFilter); err == nil {
 			if err = equipmentFilter.Validate(); err == nil {
 				return &equipmentFilter, nil
 			}
@@ -179,3 +307,20 @@ func EquipmentFilterFromRequest(request *http.Request) (*EquipmentFilter, error)
 	}
 	return nil, err
 }
+
+
+// PointInTime selects the instant the state of equipment is restored at; nil AsOf means the current state.
+type PointInTime struct {
+	AsOf	*time.Time	`schema:"as_of"`
+}
+
+func PointInTimeFromRequest(request *http.Request) (*PointInTime, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var pointInTime PointInTime
+		if err = schema.NewDecoder().Decode(&pointInTime, request.Form); err == nil {
+			return &pointInTime, nil
+		}
+	}
+	return nil, err
+}
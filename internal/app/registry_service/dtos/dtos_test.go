@@ -0,0 +1,31 @@
+package dtos
+
+import (
+	"testing"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+func TestEquipmentFilterValidateKinds(t *testing.T) {
+	invalid := model.EquipmentKind(-1)
+	for _, test := range []struct {
+		name	string
+		filter	EquipmentFilter
+		err		string	// Empty for the filter valid
+	}{
+		{"kinds", EquipmentFilter{Kinds: []model.EquipmentKind{model.CNCMachine, model.RoboticArm}}, ""},
+		{"no kinds", EquipmentFilter{NoKinds: []model.EquipmentKind{model.ConveyorBelt}}, ""},
+		{"invalid kind", EquipmentFilter{Kinds: []model.EquipmentKind{model.CNCMachine, invalid}}, "Invalid equipment kind value: -1"},
+		{"invalid no kind", EquipmentFilter{NoKinds: []model.EquipmentKind{invalid}}, "Invalid equipment no_kind value: -1"},
+		{"kinds and no kinds", EquipmentFilter {
+			Kinds: []model.EquipmentKind{model.CNCMachine}, NoKinds: []model.EquipmentKind{model.RoboticArm},
+		}, "Fields `kind` and `no_kind` cannot be used together"},
+	} {
+		err := test.filter.Validate()
+		switch {
+			case err == nil && test.err != "":
+				t.Errorf("%s: accepted, expected %q", test.name, test.err)
+			case err != nil && err.Error() != test.err:
+				t.Errorf("%s: error %q, expected %q", test.name, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package dtos
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/rsql"
+)
+
+const (
+	unknownSelector = "Unknown field `%s` at %d in the filter"
+	unsupportedOperator = "Operator `%s` is not supported by the field `%s` at %d in the filter"
+	invalidArgument = "Invalid value `%s` of the field `%s` at %d in the filter: %v"
+	singleArgument = "Operator `%s` of the field `%s` at %d in the filter takes a single value"
+	dateLayout = "2006-01-02"
+)
+
+// FilterType types the values of the fields of the filter expression.
+type FilterType int
+const (
+	UUIDFilter FilterType = iota
+	KindFilter
+	StatusFilter
+	TimeFilter
+	ConnectivityFilter
+	ParameterFilter	// `param.{name}`: a number, a string or a bool
+)
+
+// FilterFields are the fields of the filter expression besides the parameters.
+var FilterFields = map[string]FilterType {
+	"id":			UUIDFilter,
+	"kind":			KindFilter,
+	"status":		StatusFilter,
+	"created_at":	TimeFilter,
+	"updated_at":	TimeFilter,
+	"last_seen_at":	TimeFilter,
+	"connectivity":	ConnectivityFilter,
+}
+
+var (
+	equalities = []rsql.Operator{rsql.Eq, rsql.Ne, rsql.In, rsql.Out}
+	orderings = []rsql.Operator{rsql.Eq, rsql.Ne, rsql.Lt, rsql.Le, rsql.Gt, rsql.Ge}
+	filterOperators = map[FilterType][]rsql.Operator {
+		UUIDFilter:			append(slices.Clone(orderings), rsql.In, rsql.Out),	// The ids are time-ordered
+		KindFilter:			equalities,
+		StatusFilter:		equalities,
+		TimeFilter:			orderings,
+		ConnectivityFilter:	equalities,
+		ParameterFilter:	append(slices.Clone(orderings), rsql.In, rsql.Out, rsql.Exists),
+	}
+)
+
+// FilterTypeOf returns the type of the field along with the name of the parameter for the parameter fields.
+func FilterTypeOf(selector string) (FilterType, string, bool) {
+	if name, isParameter := strings.CutPrefix(selector, parameterPrefix); isParameter {
+		return ParameterFilter, name, parameterName.MatchString(name)
+	}
+	filterType, found := FilterFields[selector]
+	return filterType, "", found
+}
+
+// ParseFilter parses the filter expression typing the values of its comparisons by their fields.
+func ParseFilter(expression string) (rsql.Node, error) {
+	node, err := rsql.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return node, rsql.Walk(node, bindComparison)
+}
+
+func bindComparison(comparison *rsql.Comparison) error {
+	filterType, _, found := FilterTypeOf(comparison.Selector)
+	if !found {
+		return fmt.Errorf(unknownSelector, comparison.Selector, comparison.Position)
+	}
+	if !slices.Contains(filterOperators[filterType], comparison.Operator) {
+		return fmt.Errorf(unsupportedOperator, comparison.Operator, comparison.Selector, comparison.Position)
+	}
+	if !comparison.Operator.IsList() && len(comparison.Arguments) != 1 {
+		return fmt.Errorf(singleArgument, comparison.Operator, comparison.Selector, comparison.Position)
+	}
+	comparison.Values = make([]interface{}, 0, len(comparison.Arguments))
+	for _, argument := range comparison.Arguments {
+		value, err := parseFilterValue(filterType, comparison.Operator, argument)
+		if err != nil {
+			return fmt.Errorf(invalidArgument, argument.Text, comparison.Selector, comparison.Position, err)
+		}
+		comparison.Values = append(comparison.Values, value)
+	}
+	return nil
+}
+
+// parseFilterValue types the argument by the field; the quoted argument of the parameter is a string.
+func parseFilterValue(filterType FilterType, operator rsql.Operator, argument rsql.Argument) (interface{}, error) {
+	text := argument.Text
+	switch filterType {
+		case UUIDFilter:
+			return uuid.FromString(text)
+		case KindFilter:
+			if kind, ok := ParseKind(text); ok {
+				return kind, nil
+			}
+			return nil, errors.New("not a kind")
+		case StatusFilter:
+			if status := model.ParseOperationalStatus(text); status != nil {
+				return *status, nil
+			}
+			number, err := strconv.ParseInt(text, 10, 8)
+			if status := model.OperationalStatus(number); err != nil || !status.IsValid() {
+				return nil, errors.New("not a status")
+			}
+			return model.OperationalStatus(number), nil
+		case TimeFilter:
+			if instant, err := time.Parse(time.RFC3339Nano, text); err == nil {
+				return instant, nil
+			}
+			return time.Parse(dateLayout, text)
+		case ConnectivityFilter:
+			if connectivity := model.Connectivity(text); connectivity.IsValid() {
+				return connectivity, nil
+			}
+			return nil, errors.New("not a connectivity")
+	}
+	value := parseParameterValue(argument)
+	_, isBool := value.(bool)
+	if operator == rsql.Exists && !isBool {
+		return nil, errors.New("`true` or `false` expected")
+	}
+	if isBool && !slices.Contains(equalities, operator) && operator != rsql.Exists {
+		return nil, errors.New("a number or a string expected")
+	}
+	return value, nil
+}
+
+// Selects reports whether the tree has comparisons of any of the fields.
+func Selects(node rsql.Node, selectors ...string) bool {
+	selected := false
+	_ = rsql.Walk(node, func(comparison *rsql.Comparison) error {
+		selected = selected || slices.Contains(selectors, comparison.Selector)
+		return nil
+	})
+	return selected
+}
+
+
+// Evaluate reports whether the equipment satisfies the expression the way the repository does:
+// the comparisons of the missing values (parameters, last_seen_at) are false.
+func Evaluate(node rsql.Node, equipmentGet *EquipmentGet) bool {
+	switch node := node.(type) {
+		case *rsql.And:
+			for _, operand := range node.Operands {
+				if !Evaluate(operand, equipmentGet) {
+					return false
+				}
+			}
+			return true
+		case *rsql.Or:
+			for _, operand := range node.Operands {
+				if Evaluate(operand, equipmentGet) {
+					return true
+				}
+			}
+			return false
+		case *rsql.Not:
+			return !Evaluate(node.Operand, equipmentGet)
+		case *rsql.Comparison:
+			return evaluateComparison(node, equipmentGet)
+	}
+	return true
+}
+
+func evaluateComparison(comparison *rsql.Comparison, equipmentGet *EquipmentGet) bool {
+	filterType, name, _ := FilterTypeOf(comparison.Selector)
+	var actual interface{}
+	switch comparison.Selector {
+		case "id":
+			actual = equipmentGet.Id
+		case "kind":
+			actual = equipmentGet.Kind
+		case "status":
+			actual = equipmentGet.Status
+		case "created_at":
+			actual = equipmentGet.CreatedAt
+		case "updated_at":
+			actual = equipmentGet.UpdatedAt
+		case "last_seen_at":
+			if equipmentGet.LastSeenAt != nil {
+				actual = *equipmentGet.LastSeenAt
+			}
+		case "connectivity":
+			actual = equipmentGet.Connectivity
+			if actual == model.Connectivity("") {
+				actual = model.Unknown
+			}
+		default:
+			var found bool
+			actual, found = equipmentGet.Parameters[name]
+			if comparison.Operator == rsql.Exists {
+				return found == comparison.Values[0].(bool)
+			}
+	}
+	if actual == nil {
+		return false
+	}
+	switch comparison.Operator {
+		case rsql.In:
+			return slices.ContainsFunc(comparison.Values, func(value interface{}) bool { return equal(actual, value) })
+		case rsql.Out:
+			return !slices.ContainsFunc(comparison.Values, func(value interface{}) bool { return equal(actual, value) })
+		case rsql.Eq:
+			return equal(actual, comparison.Values[0])
+		case rsql.Ne:
+			return !equal(actual, comparison.Values[0])
+	}
+	ordered, comparable := compareValues(filterType, actual, comparison.Values[0])
+	if !comparable {
+		return false
+	}
+	switch comparison.Operator {
+		case rsql.Lt:
+			return ordered < 0
+		case rsql.Le:
+			return ordered <= 0
+		case rsql.Gt:
+			return ordered > 0
+		case rsql.Ge:
+			return ordered >= 0
+	}
+	return false
+}
+
+func equal(actual, value interface{}) bool {
+	if instant, isTime := actual.(time.Time); isTime {
+		return instant.Equal(value.(time.Time))
+	}
+	return actual == value
+}
+
+// compareValues orders the values of the same type; the parameters of other types are not comparable.
+func compareValues(filterType FilterType, actual, value interface{}) (int, bool) {
+	switch actual := actual.(type) {
+		case uuid.UUID:
+			id := value.(uuid.UUID)
+			return bytes.Compare(actual.Bytes(), id.Bytes()), true
+		case time.Time:
+			return actual.Compare(value.(time.Time)), true
+		case float64:
+			if number, isNumber := value.(float64); isNumber {
+				return cmp.Compare(actual, number), true
+			}
+		case string:
+			if text, isString := value.(string); isString && filterType == ParameterFilter {
+				return strings.Compare(actual, text), true
+			}
+	}
+	return 0, false
+}
@@ -0,0 +1,56 @@
+package dtos
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PatchOperation is an operation of JSON Patch (RFC 6902).
+type PatchOperation struct {
+	Op		string		`json:"op"`
+	Path	string		`json:"path"`
+	Value	interface{}	`json:"value,omitempty"`
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// DiffParameters returns JSON Patch turning from into to; nested objects are compared key by key,
+// other values (including arrays) are replaced as a whole.
+func DiffParameters(from, to map[string]interface{}) []PatchOperation {
+	return diffObjects("", from, to, []PatchOperation{})
+}
+
+func diffObjects(path string, from, to map[string]interface{}, patch []PatchOperation) []PatchOperation {
+	keys := make([]string, 0, len(from) + len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys) // Deterministic order of operations
+
+	for _, key := range keys {
+		keyPath := path + "/" + pointerEscaper.Replace(key)
+		fromValue, inFrom := from[key]
+		toValue, inTo := to[key]
+		switch {
+			case !inTo:
+				patch = append(patch, PatchOperation{Op: "remove", Path: keyPath})
+			case !inFrom:
+				patch = append(patch, PatchOperation{Op: "add", Path: keyPath, Value: toValue})
+			default:
+				fromObject, fromIsObject := fromValue.(map[string]interface{})
+				toObject, toIsObject := toValue.(map[string]interface{})
+				if fromIsObject && toIsObject {
+					patch = diffObjects(keyPath, fromObject, toObject, patch)
+				} else if !reflect.DeepEqual(fromValue, toValue) {
+					patch = append(patch, PatchOperation{Op: "replace", Path: keyPath, Value: toValue})
+				}
+		}
+	}
+	return patch
+}
@@ -0,0 +1,166 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	invalidKindName string = "Kind name must start with a letter and consist of up to 64 letters, digits and underscores, got `%s`"
+	emptyParameterSchema = "Kind parameter schema should not be empty"
+	nothingToUpdate = "At least one field to update is required"
+	invalidMetricName = "Metric name must start with a lowercase letter and consist of up to 64 lowercase letters, digits and underscores, got `%s`"
+	duplicateMetric = "Metric `%s` is declared more than once"
+	invalidMetricBounds = "Metric `%s` has `min` greater than `max`"
+	invalidHeartbeatInterval = "`heartbeat_interval` must be a duration of at least 1s, e.g. `30s`, got `%s`"
+)
+
+var (
+	kindNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{0,63}$`)
+	metricNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+)
+
+func validateKindName(name string) error {
+	if !kindNameRegexp.MatchString(name) {
+		return fmt.Errorf(invalidKindName, name)
+	}
+	return nil
+}
+
+func validateMetrics(metrics []model.Metric) error {
+	names := make(map[string]struct{}, len(metrics))
+	for _, metric := range metrics {
+		if !metricNameRegexp.MatchString(metric.Name) {
+			return fmt.Errorf(invalidMetricName, metric.Name)
+		}
+		if _, ok := names[metric.Name]; ok {
+			return fmt.Errorf(duplicateMetric, metric.Name)
+		}
+		names[metric.Name] = struct{}{}
+		if metric.Min != nil && metric.Max != nil && *metric.Min > *metric.Max {
+			return fmt.Errorf(invalidMetricBounds, metric.Name)
+		}
+	}
+	return nil
+}
+
+// parseHeartbeatInterval returns the interval in seconds.
+func parseHeartbeatInterval(interval string) (int64, error) {
+	parsed, err := time.ParseDuration(interval)
+	if err != nil || parsed < time.Second {
+		return 0, fmt.Errorf(invalidHeartbeatInterval, interval)
+	}
+	return int64(math.Ceil(parsed.Seconds())), nil
+}
+
+
+type KindCreate struct {
+	Name			string			`json:"name"`
+	Description		string			`json:"description"`
+	ParameterSchema	json.RawMessage	`json:"parameter_schema"`
+	Icon			string			`json:"icon"`
+	Metrics			[]model.Metric	`json:"metrics"`	// Telemetry the equipment of the kind may report
+	HeartbeatInterval	string		`json:"heartbeat_interval"`	// Go duration, 1m by default
+}
+
+func (kindCreate KindCreate) Validate() error {
+	if err := validateKindName(kindCreate.Name); err != nil {
+		return err
+	}
+	if len(kindCreate.ParameterSchema) == 0 || string(kindCreate.ParameterSchema) == "null" {
+		return errors.New(emptyParameterSchema)
+	}
+	if kindCreate.HeartbeatInterval != "" {
+		if _, err := parseHeartbeatInterval(kindCreate.HeartbeatInterval); err != nil {
+			return err
+		}
+	}
+	return validateMetrics(kindCreate.Metrics)
+}
+
+// HeartbeatIntervalSeconds returns nil unless the interval is given.
+func (kindCreate *KindCreate) HeartbeatIntervalSeconds() *int64 {
+	if kindCreate.HeartbeatInterval == "" {
+		return nil
+	}
+	seconds, _ := parseHeartbeatInterval(kindCreate.HeartbeatInterval)
+	return &seconds
+}
+
+
+type KindUpdate struct {
+	Id				model.EquipmentKind	`json:"id"`
+	Name			*string				`json:"name"`
+	Description		*string				`json:"description"`
+	ParameterSchema	*json.RawMessage	`json:"parameter_schema"`
+	Icon			*string				`json:"icon"`
+	Metrics			*[]model.Metric		`json:"metrics"`	// Replace the declared ones as a whole
+	HeartbeatInterval	*string			`json:"heartbeat_interval"`
+}
+
+func (kindUpdate KindUpdate) Validate() error {
+	if kindUpdate.Name == nil && kindUpdate.Description == nil && kindUpdate.ParameterSchema == nil &&
+		kindUpdate.Icon == nil && kindUpdate.Metrics == nil && kindUpdate.HeartbeatInterval == nil {
+		return errors.New(nothingToUpdate)
+	}
+	if kindUpdate.Name != nil {
+		if err := validateKindName(*kindUpdate.Name); err != nil {
+			return err
+		}
+	}
+	if kindUpdate.ParameterSchema != nil && (len(*kindUpdate.ParameterSchema) == 0 || string(*kindUpdate.ParameterSchema) == "null") {
+		return errors.New(emptyParameterSchema)
+	}
+	if kindUpdate.HeartbeatInterval != nil {
+		if _, err := parseHeartbeatInterval(*kindUpdate.HeartbeatInterval); err != nil {
+			return err
+		}
+	}
+	if kindUpdate.Metrics != nil {
+		return validateMetrics(*kindUpdate.Metrics)
+	}
+	return nil
+}
+
+// HeartbeatIntervalSeconds returns nil unless the interval is updated.
+func (kindUpdate *KindUpdate) HeartbeatIntervalSeconds() *int64 {
+	if kindUpdate.HeartbeatInterval == nil {
+		return nil
+	}
+	seconds, _ := parseHeartbeatInterval(*kindUpdate.HeartbeatInterval)
+	return &seconds
+}
+
+
+type KindGet struct {
+	Id				model.EquipmentKind	`json:"id"`
+	Name			string				`json:"name"`
+	Description		string				`json:"description"`
+	ParameterSchema	json.RawMessage		`json:"parameter_schema"`
+	Icon			string				`json:"icon"`
+	Metrics			[]model.Metric		`json:"metrics"`
+	HeartbeatInterval	string			`json:"heartbeat_interval"`
+	CreatedAt		time.Time			`json:"created_at"`
+	UpdatedAt		time.Time			`json:"updated_at"`
+}
+
+func KindGetFromModel(kindModel model.Kind) *KindGet {
+	metrics := make([]model.Metric, 0)
+	_ = json.Unmarshal(kindModel.Metrics, &metrics)
+	return &KindGet {
+		Id:					kindModel.Id,
+		Name:				kindModel.Name,
+		Description:		kindModel.Description,
+		ParameterSchema:	kindModel.ParameterSchema,
+		Icon:				kindModel.Icon,
+		Metrics:			metrics,
+		HeartbeatInterval:	(time.Duration(kindModel.HeartbeatInterval) * time.Second).String(),
+		CreatedAt:			kindModel.CreatedAt,
+		UpdatedAt:			kindModel.UpdatedAt,
+	}
+}
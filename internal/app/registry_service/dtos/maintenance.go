@@ -0,0 +1,269 @@
+package dtos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/schema"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	defaultWithin string = "7d"
+	invalidPlanName = "Plan name should not be empty nor longer than 128 characters"
+	eitherEquipmentOrKind = "Either `equipment_id` or `kind` is required"
+	invalidTrigger = "`trigger` must be one of calendar, operating_hours, counter, got `%s`"
+	invalidDays = "`%s` must be positive duration, e.g. `30d` or `12h`, got `%s`"
+	invalidPlanThreshold = "`threshold` must be positive, got %v"
+	notApplicableToTrigger = "`%s` does not apply to `%s` trigger"
+)
+
+// parseDays parses the positive Go duration or the number of days followed by `d`, e.g. `30d`.
+func parseDays(name, duration string) (time.Duration, error) {
+	var parsed time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(duration, "d"); ok {
+		var number float64
+		number, err = strconv.ParseFloat(days, 64)
+		parsed = time.Duration(number * float64(24 * time.Hour))
+	} else {
+		parsed, err = time.ParseDuration(duration)
+	}
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf(invalidDays, name, duration)
+	}
+	return parsed, nil
+}
+
+// formatDays formats the duration of whole days as `parseDays` accepts it.
+func formatDays(duration time.Duration) string {
+	if duration > 0 && duration % (24 * time.Hour) == 0 {
+		return strconv.FormatInt(int64(duration / (24 * time.Hour)), 10) + "d"
+	}
+	return duration.String()
+}
+
+func validatePlanName(name string) error {
+	if name == "" || len(name) > 128 {
+		return errors.New(invalidPlanName)
+	}
+	return nil
+}
+
+// ValidatePlan checks that the plan has the parameters of its trigger and only them.
+func ValidatePlan(plan *model.MaintenancePlan) error {
+	if !plan.Trigger.IsValid() {
+		return fmt.Errorf(invalidTrigger, plan.Trigger)
+	}
+	if plan.Trigger == model.CalendarTrigger {
+		if plan.IntervalSeconds <= 0 {
+			return fmt.Errorf(parameterIsRequired, "interval")
+		}
+		if plan.Threshold != 0 {
+			return fmt.Errorf(notApplicableToTrigger, "threshold", plan.Trigger)
+		}
+	} else {
+		if plan.IntervalSeconds != 0 {
+			return fmt.Errorf(notApplicableToTrigger, "interval", plan.Trigger)
+		}
+		if plan.Threshold <= 0 {
+			return fmt.Errorf(invalidPlanThreshold, plan.Threshold)
+		}
+	}
+	if plan.Trigger == model.CounterTrigger && plan.Metric == "" {
+		return fmt.Errorf(parameterIsRequired, "metric")
+	}
+	if plan.Trigger != model.CounterTrigger && plan.Metric != "" {
+		return fmt.Errorf(notApplicableToTrigger, "metric", plan.Trigger)
+	}
+	return nil
+}
+
+
+type MaintenancePlanCreate struct {
+	Name		string						`json:"name"`
+	EquipmentId	*uuid.UUID					`json:"equipment_id"`
+	Kind		*model.EquipmentKind		`json:"kind"`
+	Trigger		model.MaintenanceTrigger	`json:"trigger"`
+	Interval	string						`json:"interval"`	// Calendar trigger, e.g. `30d`
+	Threshold	float64						`json:"threshold"`	// Operating hours or the counter
+	Metric		string						`json:"metric"`		// Counter trigger
+	Active		*bool						`json:"active"`		// True by default
+}
+
+func (planCreate MaintenancePlanCreate) Validate() error {
+	if err := validatePlanName(planCreate.Name); err != nil {
+		return err
+	}
+	if (planCreate.EquipmentId == nil) == (planCreate.Kind == nil) {
+		return errors.New(eitherEquipmentOrKind)
+	}
+	if planCreate.Kind != nil && !planCreate.Kind.IsValid() {
+		return fmt.Errorf(invalidFieldValue, "kind", *planCreate.Kind)
+	}
+	if planCreate.Interval != "" {
+		if _, err := parseDays("interval", planCreate.Interval); err != nil {
+			return err
+		}
+	}
+	return ValidatePlan(planCreate.Plan())
+}
+
+func (planCreate *MaintenancePlanCreate) Plan() *model.MaintenancePlan {
+	plan := model.MaintenancePlan {
+		Name:			planCreate.Name,
+		EquipmentId:	planCreate.EquipmentId,
+		Kind:			planCreate.Kind,
+		Trigger:		planCreate.Trigger,
+		Threshold:		planCreate.Threshold,
+		Metric:			planCreate.Metric,
+		Active:			planCreate.Active == nil || *planCreate.Active,
+	}
+	if interval, err := parseDays("interval", planCreate.Interval); err == nil {
+		plan.IntervalSeconds = int64(interval / time.Second)
+	}
+	return &plan
+}
+
+
+// MaintenancePlanUpdate cannot change the equipment, the kind nor the trigger of the plan.
+type MaintenancePlanUpdate struct {
+	Id			int64		`json:"id"`
+	Name		*string		`json:"name"`
+	Interval	*string		`json:"interval"`
+	Threshold	*float64	`json:"threshold"`
+	Metric		*string		`json:"metric"`
+	Active		*bool		`json:"active"`
+}
+
+func (planUpdate MaintenancePlanUpdate) Validate() error {
+	if planUpdate.Name == nil && planUpdate.Interval == nil && planUpdate.Threshold == nil && planUpdate.Metric == nil && planUpdate.Active == nil {
+		return errors.New(nothingToUpdate)
+	}
+	if planUpdate.Name != nil {
+		if err := validatePlanName(*planUpdate.Name); err != nil {
+			return err
+		}
+	}
+	if planUpdate.Interval != nil {
+		_, err := parseDays("interval", *planUpdate.Interval)
+		return err
+	}
+	return nil
+}
+
+// Apply changes the plan; it is to be validated then.
+func (planUpdate *MaintenancePlanUpdate) Apply(plan *model.MaintenancePlan) {
+	if planUpdate.Name != nil {
+		plan.Name = *planUpdate.Name
+	}
+	if planUpdate.Interval != nil {
+		interval, _ := parseDays("interval", *planUpdate.Interval)
+		plan.IntervalSeconds = int64(interval / time.Second)
+	}
+	if planUpdate.Threshold != nil {
+		plan.Threshold = *planUpdate.Threshold
+	}
+	if planUpdate.Metric != nil {
+		plan.Metric = *planUpdate.Metric
+	}
+	if planUpdate.Active != nil {
+		plan.Active = *planUpdate.Active
+	}
+}
+
+
+type MaintenancePlanGet struct {
+	Id			int64						`json:"id"`
+	Name		string						`json:"name"`
+	EquipmentId	*uuid.UUID					`json:"equipment_id,omitempty"`
+	Kind		*model.EquipmentKind		`json:"kind,omitempty"`
+	Trigger		model.MaintenanceTrigger	`json:"trigger"`
+	Interval	string						`json:"interval,omitempty"`
+	Threshold	float64						`json:"threshold,omitempty"`
+	Metric		string						`json:"metric,omitempty"`
+	Active		bool						`json:"active"`
+	CreatedAt	time.Time					`json:"created_at"`
+	UpdatedAt	time.Time					`json:"updated_at"`
+}
+
+func MaintenancePlanGetFromModel(planModel model.MaintenancePlan) *MaintenancePlanGet {
+	planGet := MaintenancePlanGet {
+		Id:				planModel.Id,
+		Name:			planModel.Name,
+		EquipmentId:	planModel.EquipmentId,
+		Kind:			planModel.Kind,
+		Trigger:		planModel.Trigger,
+		Threshold:		planModel.Threshold,
+		Metric:			planModel.Metric,
+		Active:			planModel.Active,
+		CreatedAt:		planModel.CreatedAt,
+		UpdatedAt:		planModel.UpdatedAt,
+	}
+	if planModel.IntervalSeconds > 0 {
+		planGet.Interval = formatDays(time.Duration(planModel.IntervalSeconds) * time.Second)
+	}
+	return &planGet
+}
+
+
+// MaintenanceDueQuery lists the maintenance due within the duration from now, including the overdue one.
+type MaintenanceDueQuery struct {
+	Within		string			`schema:"within"`	// `7d` by default
+	Duration	time.Duration	`schema:"-"`		// Parsed Within
+}
+
+func (dueQuery *MaintenanceDueQuery) Validate() error {
+	if dueQuery.Within == "" {
+		dueQuery.Within = defaultWithin
+	}
+	var err error
+	dueQuery.Duration, err = parseDays("within", dueQuery.Within)
+	return err
+}
+
+func MaintenanceDueQueryFromRequest(request *http.Request) (*MaintenanceDueQuery, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var dueQuery MaintenanceDueQuery
+		if err = schema.NewDecoder().Decode(&dueQuery, request.Form); err == nil {
+			if err = dueQuery.Validate(); err == nil {
+				return &dueQuery, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+type MaintenanceDueGet struct {
+	PlanId		int64						`json:"plan_id"`
+	PlanName	string						`json:"plan_name"`
+	EquipmentId	uuid.UUID					`json:"equipment_id"`
+	Trigger		model.MaintenanceTrigger	`json:"trigger"`
+	LastDoneAt	time.Time					`json:"last_done_at"`
+	Usage		*float64					`json:"usage,omitempty"`
+	Threshold	float64						`json:"threshold,omitempty"`
+	DueAt		time.Time					`json:"due_at"`
+	Overdue		bool						`json:"overdue"`
+	ComputedAt	time.Time					`json:"computed_at"`
+}
+
+// MaintenanceDueGetFromModel returns the scheduled maintenance of the plan which is due.
+func MaintenanceDueGetFromModel(dueModel model.MaintenanceDue, planModel model.MaintenancePlan, now time.Time) *MaintenanceDueGet {
+	return &MaintenanceDueGet {
+		PlanId:			dueModel.PlanId,
+		PlanName:		planModel.Name,
+		EquipmentId:	dueModel.EquipmentId,
+		Trigger:		planModel.Trigger,
+		LastDoneAt:		dueModel.LastDoneAt,
+		Usage:			dueModel.Usage,
+		Threshold:		planModel.Threshold,
+		DueAt:			*dueModel.DueAt,
+		Overdue:		!dueModel.DueAt.After(now),
+		ComputedAt:		dueModel.ComputedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package dtos
+
+import (
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+// EffectivenessGet lists OEE and its components, each of them is null if it cannot be computed
+// (e.g. performance without the ideal cycle time).
+type EffectivenessGet struct {
+	OEE				*float64	`json:"oee"`
+	Availability	*float64	`json:"availability"`
+	Performance		*float64	`json:"performance"`
+	Quality			*float64	`json:"quality"`
+	PlannedSeconds	float64		`json:"planned_seconds"`
+	RunSeconds		float64		`json:"run_seconds"`
+	TotalCount		float64		`json:"total_count"`
+	GoodCount		float64		`json:"good_count"`
+}
+
+func EffectivenessGetFromModel(effectiveness *model.Effectiveness) EffectivenessGet {
+	return EffectivenessGet {
+		OEE:			effectiveness.OEE(),
+		Availability:	effectiveness.Availability(),
+		Performance:	effectiveness.Performance(),
+		Quality:		effectiveness.Quality(),
+		PlannedSeconds:	effectiveness.PlannedTime.Seconds(),
+		RunSeconds:		effectiveness.RunTime.Seconds(),
+		TotalCount:		effectiveness.TotalCount,
+		GoodCount:		effectiveness.GoodCount,
+	}
+}
+
+type EquipmentOEE struct {
+	EquipmentId		uuid.UUID			`json:"equipment_id"`
+	Kind			model.EquipmentKind	`json:"kind"`
+	From			time.Time			`json:"from"`
+	To				time.Time			`json:"to"`
+	IdealCycleTime	*float64			`json:"ideal_cycle_time_s"`
+	EffectivenessGet
+}
+
+// KindOEE combines the components of the equipment of the kind: e.g. availability is the total run time
+// by the total planned time.
+type KindOEE struct {
+	Kind		model.EquipmentKind	`json:"kind"`
+	EffectivenessGet
+	Equipment	[]*EquipmentOEE		`json:"equipment"`
+}
+
+type OEEReport struct {
+	From	time.Time	`json:"from"`
+	To		time.Time	`json:"to"`
+	Kinds	[]*KindOEE	`json:"kinds"`
+}
@@ -0,0 +1,143 @@
+package dtos
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/rsql"
+)
+
+const (
+	parameterPrefix string = "param."
+	parameterPresence = "param.has"	// Lists the names of the parameters the equipment must have
+	invalidParameterKey = "Invalid parameter filter `%s`: expected `param.{name}` or `param.{name}[{operator}]`"
+	invalidParameterName = "Invalid parameter name `%s`"
+	invalidParameterOperator = "Invalid operator of the parameter filter `%s`: expected `eq`, `ne`, `gt`, `gte`, `lt` or `lte`"
+	parameterIsRepeated = "Parameter filter `%s` is given more than once"
+	unorderedParameterValue = "Parameter filter `%s` requires a number or a string, got `%s`"
+)
+
+// parameterName restricts the names of the parameters, so they are embedded into SQL as the keys of the expression indexes.
+var parameterName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]{0,63}$`)
+
+// parameterOperators map the operators of the parameter filters to the ones of the filter expression.
+var parameterOperators = map[string]rsql.Operator {
+	"eq":	rsql.Eq,
+	"ne":	rsql.Ne,
+	"gt":	rsql.Gt,
+	"gte":	rsql.Ge,
+	"lt":	rsql.Lt,
+	"lte":	rsql.Le,
+}
+
+// parameterArgument takes the value of the parameter filter as the argument, the value in double quotes is quoted.
+func parameterArgument(value string) rsql.Argument {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return rsql.Argument{Text: value[1:len(value) - 1], Quoted: true}
+	}
+	return rsql.Argument{Text: value}
+}
+
+// parseParameterValue types the argument: a quoted argument is a string, otherwise `true`/`false` is a bool,
+// a finite number is a number and anything else is a string.
+func parseParameterValue(argument rsql.Argument) interface{} {
+	value := argument.Text
+	if argument.Quoted {
+		return value
+	}
+	if value == "true" || value == "false" {
+		return value == "true"
+	}
+	if number, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(number, 0) && !math.IsNaN(number) {
+		return number
+	}
+	return value
+}
+
+func parseParameterKey(key string) (string, rsql.Operator, error) {
+	name, operator := strings.TrimPrefix(key, parameterPrefix), rsql.Eq
+	if open := strings.IndexByte(name, '['); open >= 0 {
+		if !strings.HasSuffix(name, "]") {
+			return "", "", fmt.Errorf(invalidParameterKey, key)
+		}
+		var found bool
+		if operator, found = parameterOperators[name[open + 1:len(name) - 1]]; !found {
+			return "", "", fmt.Errorf(invalidParameterOperator, key)
+		}
+		name = name[:open]
+	}
+	if !parameterName.MatchString(name) {
+		return "", "", fmt.Errorf(invalidParameterName, name)
+	}
+	return name, operator, nil
+}
+
+// ParameterConditionsFromForm takes the parameter filters out of the form as the comparisons of the filter expression:
+// `param.{name}[{operator}]={value}` (`eq` if the operator is omitted) and `param.has={name}` (comma separated names).
+// The comparisons are ordered by the keys, so equal filters give equal queries.
+func ParameterConditionsFromForm(form url.Values) ([]*rsql.Comparison, error) {
+	keys := make([]string, 0)
+	for key := range form {
+		if strings.HasPrefix(key, parameterPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var conditions []*rsql.Comparison
+	for _, key := range keys {
+		values := form[key]
+		delete(form, key)
+		if key == parameterPresence {
+			for _, value := range values {
+				for _, name := range strings.Split(value, ",") {
+					if !parameterName.MatchString(name) {
+						return nil, fmt.Errorf(invalidParameterName, name)
+					}
+					conditions = append(conditions, &rsql.Comparison {
+						Selector:	parameterPrefix + name,
+						Operator:	rsql.Exists,
+						Arguments:	[]rsql.Argument{{Text: "true"}},
+						Values:		[]interface{}{true},
+					})
+				}
+			}
+			continue
+		}
+		name, operator, err := parseParameterKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) > 1 {
+			return nil, fmt.Errorf(parameterIsRepeated, key)
+		}
+		argument := parameterArgument(values[0])
+		value := parseParameterValue(argument)
+		if _, isBool := value.(bool); isBool && operator != rsql.Eq && operator != rsql.Ne {
+			return nil, fmt.Errorf(unorderedParameterValue, key, values[0])
+		}
+		conditions = append(conditions, &rsql.Comparison {
+			Selector:	parameterPrefix + name,
+			Operator:	operator,
+			Arguments:	[]rsql.Argument{argument},
+			Values:		[]interface{}{value},
+		})
+	}
+	return conditions, nil
+}
+
+// decodeFiltered decodes the form into the query embedding the equipment filter along with the parameter filters.
+func decodeFiltered(form url.Values, query interface{}, equipmentFilter *EquipmentFilter) error {
+	conditions, err := ParameterConditionsFromForm(form)
+	if err != nil {
+		return err
+	}
+	if err = newDecoder().Decode(query, form); err != nil {
+		return err
+	}
+	equipmentFilter.Parameters = conditions
+	return nil
+}
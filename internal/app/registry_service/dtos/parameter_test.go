@@ -0,0 +1,67 @@
+package dtos
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"testing"
+)
+
+func TestParameterConditionsFromForm(t *testing.T) {
+	for _, test := range []struct {
+		name		string
+		form		url.Values
+		conditions	[]string	// `selector operator value` with the value typed as by %#v
+		err			string		// Empty for the form parsed
+	}{
+		{"equality", url.Values{"param.speed": {"5"}}, []string{"param.speed==5"}, ""},
+		{"operators", url.Values {
+			"param.a[eq]": {"1"}, "param.b[ne]": {"1"}, "param.c[gt]": {"1"}, "param.d[gte]": {"1"}, "param.e[lt]": {"1"}, "param.f[lte]": {"1.5"},
+		}, []string{"param.a==1", "param.b!=1", "param.c=gt=1", "param.d=ge=1", "param.e=lt=1", "param.f=le=1.5"}, ""},
+		{"ordered by the keys", url.Values{"param.b": {"1"}, "param.a": {"2"}}, []string{"param.a==2", "param.b==1"}, ""},
+		// Quoting makes a string of any value
+		{"quoted number", url.Values{"param.serial": {`"0042"`}}, []string{`param.serial=="0042"`}, ""},
+		{"quoted bool", url.Values{"param.flag[gt]": {`"true"`}}, []string{`param.flag=gt="true"`}, ""},
+		{"quoted empty string", url.Values{"param.note": {`""`}}, []string{`param.note==""`}, ""},
+		{"unquoted string", url.Values{"param.name[lt]": {"lathe"}}, []string{`param.name=lt="lathe"`}, ""},
+		{"infinite number is a string", url.Values{"param.x": {"1e999"}}, []string{`param.x=="1e999"`}, ""},
+		{"bool equality", url.Values{"param.active": {"true"}, "param.spare[ne]": {"false"}}, []string{"param.active==true", "param.spare!=false"}, ""},
+		{"bool ordering", url.Values{"param.active[gt]": {"true"}}, nil,
+			"Parameter filter `param.active[gt]` requires a number or a string, got `true`"},
+		{"presence", url.Values{"param.has": {"a,b", "c"}}, []string{"param.a=exists=true", "param.b=exists=true", "param.c=exists=true"}, ""},
+		{"presence of invalid name", url.Values{"param.has": {"a,1b"}}, nil, "Invalid parameter name `1b`"},
+		{"presence of empty name", url.Values{"param.has": {"a,"}}, nil, "Invalid parameter name ``"},
+		{"repeated key", url.Values{"param.speed": {"1", "2"}}, nil, "Parameter filter `param.speed` is given more than once"},
+		{"unknown operator", url.Values{"param.speed[between]": {"1"}}, nil,
+			"Invalid operator of the parameter filter `param.speed[between]`: expected `eq`, `ne`, `gt`, `gte`, `lt` or `lte`"},
+		{"unclosed operator", url.Values{"param.speed[gt": {"1"}}, nil,
+			"Invalid parameter filter `param.speed[gt`: expected `param.{name}` or `param.{name}[{operator}]`"},
+		{"invalid name", url.Values{"param.1speed": {"1"}}, nil, "Invalid parameter name `1speed`"},
+		{"empty name", url.Values{"param.[gt]": {"1"}}, nil, "Invalid parameter name ``"},
+	} {
+		form := url.Values{"status": {"0"}}
+		for key, values := range test.form {
+			form[key] = values
+		}
+		comparisons, err := ParameterConditionsFromForm(form)
+		if err != nil {
+			if err.Error() != test.err {
+				t.Errorf("%s: error %q, expected %q", test.name, err, test.err)
+			}
+			continue
+		} else if test.err != "" {
+			t.Errorf("%s: parsed, expected error %q", test.name, test.err)
+			continue
+		}
+		conditions := make([]string, 0, len(comparisons))
+		for _, comparison := range comparisons {
+			conditions = append(conditions, fmt.Sprintf("%s%s%#v", comparison.Selector, comparison.Operator, comparison.Values[0]))
+		}
+		if !slices.Equal(conditions, test.conditions) {
+			t.Errorf("%s: conditions %q, expected %q", test.name, conditions, test.conditions)
+		}
+		if len(form) != 1 || form.Get("status") != "0" {
+			t.Errorf("%s: the form keeps %v, expected the filters other than the parameters only", test.name, form)
+		}
+	}
+}
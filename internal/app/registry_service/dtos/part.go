@@ -0,0 +1,243 @@
+package dtos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	DefaultPartUnit string = "pcs"
+	invalidSKU = "SKU should not be empty nor longer than 64 characters"
+	invalidStoreroom = "`storeroom` should not be empty nor longer than 64 characters"
+	negativeReorderLevel = "`reorder_level` must not be negative"
+	zeroQuantity = "`quantity` must not be zero"
+	nonPositiveQuantity = "`quantity` must be positive"
+)
+
+func validateSKU(sku string) error {
+	if sku == "" || len(sku) > 64 {
+		return errors.New(invalidSKU)
+	}
+	return nil
+}
+
+func validateStoreroom(storeroom string) error {
+	if storeroom == "" || len(storeroom) > 64 {
+		return errors.New(invalidStoreroom)
+	}
+	return nil
+}
+
+func validateKinds(kinds []model.EquipmentKind) error {
+	for _, kind := range kinds {
+		if !kind.IsValid() {
+			return fmt.Errorf(invalidFieldValue, "kind", kind)
+		}
+	}
+	return nil
+}
+
+
+type SparePartCreate struct {
+	SKU				string					`json:"sku"`
+	Description		string					`json:"description"`
+	Unit			string					`json:"unit"`				// `pcs` by default
+	Kinds			[]model.EquipmentKind	`json:"kinds"`				// The part fits any equipment of the kinds
+	EquipmentIds	[]uuid.UUID				`json:"equipment_ids"`		// and the listed equipment
+	ReorderLevel	float64					`json:"reorder_level"`
+}
+
+func (partCreate SparePartCreate) Validate() error {
+	if err := validateSKU(partCreate.SKU); err != nil {
+		return err
+	}
+	if partCreate.ReorderLevel < 0 {
+		return errors.New(negativeReorderLevel)
+	}
+	return validateKinds(partCreate.Kinds)
+}
+
+
+type SparePartUpdate struct {
+	Id				int64					`json:"id"`
+	SKU				*string					`json:"sku"`
+	Description		*string					`json:"description"`
+	Unit			*string					`json:"unit"`
+	Kinds			*[]model.EquipmentKind	`json:"kinds"`
+	EquipmentIds	*[]uuid.UUID			`json:"equipment_ids"`
+	ReorderLevel	*float64				`json:"reorder_level"`
+}
+
+func (partUpdate SparePartUpdate) Validate() error {
+	if partUpdate.SKU == nil && partUpdate.Description == nil && partUpdate.Unit == nil && partUpdate.Kinds == nil &&
+		partUpdate.EquipmentIds == nil && partUpdate.ReorderLevel == nil {
+		return errors.New(nothingToUpdate)
+	}
+	if partUpdate.SKU != nil {
+		if err := validateSKU(*partUpdate.SKU); err != nil {
+			return err
+		}
+	}
+	if partUpdate.Unit != nil && *partUpdate.Unit == "" {
+		return fmt.Errorf(parameterIsRequired, "unit")
+	}
+	if partUpdate.ReorderLevel != nil && *partUpdate.ReorderLevel < 0 {
+		return errors.New(negativeReorderLevel)
+	}
+	if partUpdate.Kinds != nil {
+		return validateKinds(*partUpdate.Kinds)
+	}
+	return nil
+}
+
+
+type PartStockGet struct {
+	Storeroom	string	`json:"storeroom"`
+	OnHand		float64	`json:"on_hand"`
+	Reserved	float64	`json:"reserved"`
+	Available	float64	`json:"available"`
+}
+
+func PartStockGetFromModel(stockModel model.PartStock) *PartStockGet {
+	return &PartStockGet {
+		Storeroom:	stockModel.Storeroom,
+		OnHand:		stockModel.OnHand,
+		Reserved:	stockModel.Reserved,
+		Available:	stockModel.OnHand - stockModel.Reserved,
+	}
+}
+
+// SparePartGet lists the stock of the part per storeroom along with the total.
+type SparePartGet struct {
+	Id				int64					`json:"id"`
+	SKU				string					`json:"sku"`
+	Description		string					`json:"description"`
+	Unit			string					`json:"unit"`
+	Kinds			[]model.EquipmentKind	`json:"kinds"`
+	EquipmentIds	[]uuid.UUID				`json:"equipment_ids"`
+	ReorderLevel	float64					`json:"reorder_level"`
+	OnHand			float64					`json:"on_hand"`
+	Reserved		float64					`json:"reserved"`
+	Available		float64					`json:"available"`
+	Stock			[]*PartStockGet			`json:"stock"`
+	CreatedAt		time.Time				`json:"created_at"`
+	UpdatedAt		time.Time				`json:"updated_at"`
+}
+
+func SparePartGetFromModel(partModel model.SparePart, stockModels []model.PartStock) *SparePartGet {
+	partGet := SparePartGet {
+		Id:				partModel.Id,
+		SKU:			partModel.SKU,
+		Description:	partModel.Description,
+		Unit:			partModel.Unit,
+		Kinds:			make([]model.EquipmentKind, 0, len(partModel.Kinds)),
+		EquipmentIds:	make([]uuid.UUID, 0, len(partModel.EquipmentIds)),
+		ReorderLevel:	partModel.ReorderLevel,
+		OnHand:			partModel.OnHand,
+		Reserved:		partModel.Reserved,
+		Available:		partModel.OnHand - partModel.Reserved,
+		Stock:			make([]*PartStockGet, 0, len(stockModels)),
+		CreatedAt:		partModel.CreatedAt,
+		UpdatedAt:		partModel.UpdatedAt,
+	}
+	for _, kind := range partModel.Kinds {
+		partGet.Kinds = append(partGet.Kinds, model.EquipmentKind(kind))
+	}
+	for _, equipmentId := range partModel.EquipmentIds {
+		partGet.EquipmentIds = append(partGet.EquipmentIds, uuid.FromStringOrNil(equipmentId))
+	}
+	for _, stockModel := range stockModels {
+		partGet.Stock = append(partGet.Stock, PartStockGetFromModel(stockModel))
+	}
+	return &partGet
+}
+
+
+type SparePartFilter struct {
+	Kinds		[]model.EquipmentKind	`schema:"kind"`		// Parts fitting any equipment of any of the kinds
+	SKUs		[]string				`schema:"sku"`
+	Fits		*EquipmentGet			`schema:"-"`		// Parts fitting the equipment
+	LowStock	bool					`schema:"-"`		// Parts available not above the reorder level
+}
+
+func (partFilter *SparePartFilter) Validate() error {
+	return validateKinds(partFilter.Kinds)
+}
+
+func SparePartFilterFromRequest(request *http.Request) (*SparePartFilter, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var partFilter SparePartFilter
+		if err = newDecoder().Decode(&partFilter, request.Form); err == nil {
+			if err = partFilter.Validate(); err == nil {
+				return &partFilter, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+
+// StockAdjustment changes the quantity of the part on hand in the storeroom, e.g. receives (positive) or writes off (negative) the parts.
+type StockAdjustment struct {
+	Storeroom	string	`json:"storeroom"`
+	Quantity	float64	`json:"quantity"`
+}
+
+func (stockAdjustment StockAdjustment) Validate() error {
+	if err := validateStoreroom(stockAdjustment.Storeroom); err != nil {
+		return err
+	}
+	if stockAdjustment.Quantity == 0 {
+		return errors.New(zeroQuantity)
+	}
+	return nil
+}
+
+
+type PartReservationCreate struct {
+	PartId		int64	`json:"part_id"`
+	Storeroom	string	`json:"storeroom"`
+	Quantity	float64	`json:"quantity"`
+}
+
+func (reservationCreate PartReservationCreate) Validate() error {
+	if reservationCreate.PartId == 0 {
+		return fmt.Errorf(parameterIsRequired, "part_id")
+	}
+	if err := validateStoreroom(reservationCreate.Storeroom); err != nil {
+		return err
+	}
+	if reservationCreate.Quantity <= 0 {
+		return errors.New(nonPositiveQuantity)
+	}
+	return nil
+}
+
+type PartReservationGet struct {
+	Id			int64					`json:"id"`
+	WorkOrderId	int64					`json:"work_order_id"`
+	PartId		int64					`json:"part_id"`
+	Storeroom	string					`json:"storeroom"`
+	Quantity	float64					`json:"quantity"`
+	State		model.ReservationState	`json:"state"`
+	CreatedAt	time.Time				`json:"created_at"`
+	UpdatedAt	time.Time				`json:"updated_at"`
+}
+
+func PartReservationGetFromModel(reservationModel model.PartReservation) *PartReservationGet {
+	return &PartReservationGet {
+		Id:				reservationModel.Id,
+		WorkOrderId:	reservationModel.WorkOrderId,
+		PartId:			reservationModel.PartId,
+		Storeroom:		reservationModel.Storeroom,
+		Quantity:		reservationModel.Quantity,
+		State:			reservationModel.State,
+		CreatedAt:		reservationModel.CreatedAt,
+		UpdatedAt:		reservationModel.UpdatedAt,
+	}
+}
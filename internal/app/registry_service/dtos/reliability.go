@@ -0,0 +1,57 @@
+package dtos
+
+import (
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+// ReliabilityGet lists the reliability metrics, MTBF and MTTR are null without failures and repairs respectively.
+type ReliabilityGet struct {
+	Failures		int			`json:"failures"`
+	Repairs			int			`json:"repairs"`
+	MTBFSeconds		*float64	`json:"mtbf_seconds"`
+	MTTRSeconds		*float64	`json:"mttr_seconds"`
+	UpSeconds		float64		`json:"up_seconds"`
+	RepairSeconds	float64		`json:"repair_seconds"`
+}
+
+func seconds(duration *time.Duration) *float64 {
+	if duration == nil {
+		return nil
+	}
+	value := duration.Seconds()
+	return &value
+}
+
+func ReliabilityGetFromModel(reliability *model.Reliability) ReliabilityGet {
+	return ReliabilityGet {
+		Failures:		reliability.Failures,
+		Repairs:		reliability.Repairs,
+		MTBFSeconds:	seconds(reliability.MTBF()),
+		MTTRSeconds:	seconds(reliability.MTTR()),
+		UpSeconds:		reliability.UpTime.Seconds(),
+		RepairSeconds:	reliability.RepairTime.Seconds(),
+	}
+}
+
+type EquipmentReliability struct {
+	EquipmentId	uuid.UUID			`json:"equipment_id"`
+	Kind		model.EquipmentKind	`json:"kind"`
+	ReliabilityGet
+}
+
+// KindReliability combines the metrics of the equipment of the kind: e.g. MTBF is the total time operational
+// by the total number of failures.
+type KindReliability struct {
+	Kind		model.EquipmentKind		`json:"kind"`
+	ReliabilityGet
+	Equipment	[]*EquipmentReliability	`json:"equipment"`
+}
+
+type ReliabilityReport struct {
+	From	time.Time			`json:"from"`
+	To		time.Time			`json:"to"`
+	Fleet	ReliabilityGet		`json:"fleet"`
+	Kinds	[]*KindReliability	`json:"kinds"`
+}
@@ -0,0 +1,70 @@
+package dtos
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+	"github.com/gorilla/schema"
+)
+
+// ReportRange is the range the reports are computed for.
+type ReportRange struct {
+	From	*time.Time	`schema:"from"`	// 24 hours before `to` by default
+	To		*time.Time	`schema:"to"`	// Now by default, exclusive
+}
+
+func (reportRange *ReportRange) Validate() error {
+	if reportRange.To == nil {
+		to := time.Now()
+		reportRange.To = &to
+	}
+	if reportRange.From == nil {
+		from := reportRange.To.Add(-defaultRange)
+		reportRange.From = &from
+	} else if !reportRange.From.Before(*reportRange.To) {
+		return fmt.Errorf(mustPrecede, "`from`", "`to`")
+	}
+	return nil
+}
+
+func ReportRangeFromRequest(request *http.Request) (*ReportRange, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var reportRange ReportRange
+		if err = schema.NewDecoder().Decode(&reportRange, request.Form); err == nil {
+			if err = reportRange.Validate(); err == nil {
+				return &reportRange, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+// ReportQuery computes the report over all the existing equipment matching the filter.
+type ReportQuery struct {
+	EquipmentFilter
+	ReportRange
+}
+
+func (reportQuery *ReportQuery) Validate() error {
+	if err := reportQuery.EquipmentFilter.Validate(); err != nil {
+		return err
+	}
+	if reportQuery.AsOf != nil {
+		return fmt.Errorf(asOfIsNotSupported, "reports")
+	}
+	return reportQuery.ReportRange.Validate()
+}
+
+func ReportQueryFromRequest(request *http.Request) (*ReportQuery, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var reportQuery ReportQuery
+		if err = decodeFiltered(request.Form, &reportQuery, &reportQuery.EquipmentFilter); err == nil {
+			if err = reportQuery.Validate(); err == nil {
+				return &reportQuery, nil
+			}
+		}
+	}
+	return nil, err
+}
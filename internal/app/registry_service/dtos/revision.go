@@ -0,0 +1,103 @@
+package dtos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/schema"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	defaultPerPage int = 20
+	maxPerPage = 100
+	invalidPage = "`page` must be positive, got %d"
+	invalidPerPage = "`per_page` must be between 1 and %d, got %d"
+)
+
+type HistoryPage struct {
+	Page	int	`schema:"page"`		// Starting from 1, the newest revisions first
+	PerPage	int	`schema:"per_page"`
+}
+
+func (historyPage *HistoryPage) Validate() error {
+	if historyPage.Page == 0 {
+		historyPage.Page = 1
+	} else if historyPage.Page < 0 {
+		return fmt.Errorf(invalidPage, historyPage.Page)
+	}
+	if historyPage.PerPage == 0 {
+		historyPage.PerPage = defaultPerPage
+	} else if historyPage.PerPage < 0 || historyPage.PerPage > maxPerPage {
+		return fmt.Errorf(invalidPerPage, maxPerPage, historyPage.PerPage)
+	}
+	return nil
+}
+
+func HistoryPageFromRequest(request *http.Request) (*HistoryPage, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var historyPage HistoryPage
+		if err = schema.NewDecoder().Decode(&historyPage, request.Form); err == nil {
+			if err = historyPage.Validate(); err == nil {
+				return &historyPage, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+
+type RevisionGet struct {
+	Revision		int						`json:"revision"`
+	Operation		model.RevisionOperation	`json:"operation"`
+	Kind			model.EquipmentKind		`json:"kind"`
+	Status			model.OperationalStatus	`json:"status"`
+	Parameters		map[string]interface{}	`json:"parameters"`
+	ParametersDiff	[]PatchOperation		`json:"parameters_diff"`	// Against the previous revision; null for the first one
+	RecordedAt		time.Time				`json:"recorded_at"`
+}
+
+type EquipmentHistory struct {
+	EquipmentId	uuid.UUID		`json:"equipment_id"`
+	Total		int				`json:"total"`
+	Page		int				`json:"page"`
+	PerPage		int				`json:"per_page"`
+	Revisions	[]*RevisionGet	`json:"revisions"`
+}
+
+// EquipmentHistoryFromModels builds the page of history from revisions ordered from the newest;
+// the revision following the page (if any) is used only as the base for the diff of the oldest one.
+func EquipmentHistoryFromModels(id uuid.UUID, total int, historyPage *HistoryPage, revisionModels []model.Revision) *EquipmentHistory {
+	equipmentHistory := EquipmentHistory {
+		EquipmentId:	id,
+		Total:			total,
+		Page:			historyPage.Page,
+		PerPage:		historyPage.PerPage,
+		Revisions:		make([]*RevisionGet, 0, len(revisionModels)),
+	}
+	parameters := make([]map[string]interface{}, len(revisionModels))
+	for i, revisionModel := range revisionModels {
+		_ = json.Unmarshal(revisionModel.Parameters, &parameters[i])
+	}
+	for i, revisionModel := range revisionModels {
+		if i == historyPage.PerPage {
+			break
+		}
+		revisionGet := RevisionGet {
+			Revision:	revisionModel.Revision,
+			Operation:	revisionModel.Operation,
+			Kind:		revisionModel.Kind,
+			Status:		revisionModel.Status,
+			Parameters:	parameters[i],
+			RecordedAt:	revisionModel.RecordedAt,
+		}
+		if i + 1 < len(revisionModels) {
+			revisionGet.ParametersDiff = DiffParameters(parameters[i + 1], parameters[i])
+		}
+		equipmentHistory.Revisions = append(equipmentHistory.Revisions, &revisionGet)
+	}
+	return &equipmentHistory
+}
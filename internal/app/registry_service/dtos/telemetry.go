@@ -0,0 +1,171 @@
+package dtos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/schema"
+)
+
+const (
+	maxReadingsPerBatch int = 10000
+	emptyBatch string = "At least one reading is required"
+	tooLargeBatch = "Batch must contain at most %d readings, got %d"
+	metricIsRequired = "Reading #%d: `metric` is required"
+	valueIsRequired = "Reading #%d: `value` is required"
+	maxBuckets = 10000
+	defaultRange = 24 * time.Hour
+	defaultStep = 5 * time.Minute
+	invalidStep = "`step` must be a duration of at least 1s, e.g. `5m`, got `%s`"
+	tooManyBuckets = "Range from `from` to `to` must contain at most %d steps"
+	invalidAggregation = "`agg` must be one of avg, min, max, p95, count, got `%s`"
+	asOfIsNotSupported = "Parameter `as_of` is not supported by %s"
+	parameterIsRequired = "Parameter `%s` is required"
+)
+
+type ReadingCreate struct {
+	Metric		string		`json:"metric"`
+	Value		*float64	`json:"value"`
+	Unit		string		`json:"unit"`		// The unit of the metric if omitted
+	Timestamp	*time.Time	`json:"timestamp"`	// The time of receiving if omitted
+}
+
+// TelemetryBatch is the JSON array of readings of one piece of equipment;
+// they are checked against the metrics of its kind by the service.
+type TelemetryBatch []ReadingCreate
+
+func (telemetryBatch TelemetryBatch) Validate() error {
+	if len(telemetryBatch) == 0 {
+		return errors.New(emptyBatch)
+	}
+	if len(telemetryBatch) > maxReadingsPerBatch {
+		return fmt.Errorf(tooLargeBatch, maxReadingsPerBatch, len(telemetryBatch))
+	}
+	for i, readingCreate := range telemetryBatch {
+		if readingCreate.Metric == "" {
+			return fmt.Errorf(metricIsRequired, i)
+		}
+		if readingCreate.Value == nil {
+			return fmt.Errorf(valueIsRequired, i)
+		}
+	}
+	return nil
+}
+
+
+// Aggregation is the function applied to the readings of each step of the series.
+type Aggregation string
+const (
+	AggAvg Aggregation = "avg"
+	AggMin Aggregation = "min"
+	AggMax Aggregation = "max"
+	AggP95 Aggregation = "p95"
+	AggCount Aggregation = "count"
+)
+
+type TelemetryQuery struct {
+	Metric		string			`schema:"metric"`
+	From		*time.Time		`schema:"from"`	// 24 hours before `to` by default
+	To			*time.Time		`schema:"to"`	// Now by default, exclusive
+	Step		string			`schema:"step"`	// Go duration, 5m by default
+	Agg			Aggregation		`schema:"agg"`	// avg by default
+	Interval	time.Duration	`schema:"-"`		// Parsed Step
+}
+
+func (telemetryQuery *TelemetryQuery) Validate() error {
+	if telemetryQuery.Metric == "" {
+		return fmt.Errorf(parameterIsRequired, "metric")
+	}
+	if telemetryQuery.To == nil {
+		to := time.Now()
+		telemetryQuery.To = &to
+	}
+	if telemetryQuery.From == nil {
+		from := telemetryQuery.To.Add(-defaultRange)
+		telemetryQuery.From = &from
+	} else if !telemetryQuery.From.Before(*telemetryQuery.To) {
+		return fmt.Errorf(mustPrecede, "`from`", "`to`")
+	}
+	to, from := telemetryQuery.To.UTC(), telemetryQuery.From.UTC() // Readings are stored in UTC
+	telemetryQuery.To, telemetryQuery.From = &to, &from
+	telemetryQuery.Interval = defaultStep
+	if telemetryQuery.Step != "" {
+		interval, err := time.ParseDuration(telemetryQuery.Step)
+		if err != nil || interval < time.Second {
+			return fmt.Errorf(invalidStep, telemetryQuery.Step)
+		}
+		telemetryQuery.Interval = interval
+	}
+	if to.Sub(from) / telemetryQuery.Interval >= maxBuckets {
+		return fmt.Errorf(tooManyBuckets, maxBuckets)
+	}
+	switch telemetryQuery.Agg {
+		case "":
+			telemetryQuery.Agg = AggAvg
+		case AggAvg, AggMin, AggMax, AggP95, AggCount:
+		default:
+			return fmt.Errorf(invalidAggregation, telemetryQuery.Agg)
+	}
+	return nil
+}
+
+func TelemetryQueryFromRequest(request *http.Request) (*TelemetryQuery, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var telemetryQuery TelemetryQuery
+		if err = schema.NewDecoder().Decode(&telemetryQuery, request.Form); err == nil {
+			if err = telemetryQuery.Validate(); err == nil {
+				return &telemetryQuery, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+// FleetTelemetryQuery aggregates the metric across all the equipment matching the filter.
+type FleetTelemetryQuery struct {
+	EquipmentFilter
+	TelemetryQuery
+}
+
+func (fleetTelemetryQuery *FleetTelemetryQuery) Validate() error {
+	if err := fleetTelemetryQuery.EquipmentFilter.Validate(); err != nil {
+		return err
+	}
+	if fleetTelemetryQuery.AsOf != nil {
+		return fmt.Errorf(asOfIsNotSupported, "telemetry")
+	}
+	return fleetTelemetryQuery.TelemetryQuery.Validate()
+}
+
+func FleetTelemetryQueryFromRequest(request *http.Request) (*FleetTelemetryQuery, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var fleetTelemetryQuery FleetTelemetryQuery
+		if err = decodeFiltered(request.Form, &fleetTelemetryQuery, &fleetTelemetryQuery.EquipmentFilter); err == nil {
+			if err = fleetTelemetryQuery.Validate(); err == nil {
+				return &fleetTelemetryQuery, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+
+type TelemetryPoint struct {
+	Time	time.Time	`json:"time"`	// Start of the step
+	Value	float64		`json:"value"`
+}
+
+// TelemetrySeries lists only the steps having readings.
+type TelemetrySeries struct {
+	EquipmentId	*uuid.UUID			`json:"equipment_id,omitempty"`	// Omitted for the fleet
+	Metric		string				`json:"metric"`
+	Agg			Aggregation			`json:"agg"`
+	Step		string				`json:"step"`
+	From		time.Time			`json:"from"`
+	To			time.Time			`json:"to"`
+	Points		[]TelemetryPoint	`json:"points"`
+}
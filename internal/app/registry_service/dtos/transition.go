@@ -0,0 +1,50 @@
+package dtos
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const statusIsRequired string = "Target `status` is required"
+
+type EquipmentTransition struct {
+	Status	*model.OperationalStatus	`json:"status"`
+	Reason	string						`json:"reason"`
+	Actor	string						`json:"actor"`
+}
+
+func (equipmentTransition EquipmentTransition) Validate() error {
+	if equipmentTransition.Status == nil {
+		return errors.New(statusIsRequired)
+	}
+	if !equipmentTransition.Status.IsValid() {
+		return fmt.Errorf("Invalid Equipment.Status: %d", *equipmentTransition.Status)
+	}
+	return nil
+}
+
+
+type TransitionGet struct {
+	Id			int64						`json:"id"`
+	EquipmentId	uuid.UUID					`json:"equipment_id"`
+	FromStatus	*model.OperationalStatus	`json:"from_status"`
+	ToStatus	model.OperationalStatus		`json:"to_status"`
+	Reason		string						`json:"reason"`
+	Actor		string						`json:"actor"`
+	CreatedAt	time.Time					`json:"created_at"`
+}
+
+func TransitionGetFromModel(transitionModel model.Transition) *TransitionGet {
+	return &TransitionGet {
+		Id:				transitionModel.Id,
+		EquipmentId:	transitionModel.EquipmentId,
+		FromStatus:		transitionModel.FromStatus,
+		ToStatus:		transitionModel.ToStatus,
+		Reason:			transitionModel.Reason,
+		Actor:			transitionModel.Actor,
+		CreatedAt:		transitionModel.CreatedAt,
+	}
+}
@@ -0,0 +1,187 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	minSecretLength int = 16
+	invalidWebhookURL string = "Webhook URL must be absolute http(s) URL, got `%s`"
+	invalidEventType = "Unknown webhook event type: `%s`"
+	shortSecret = "Webhook secret must be at least %d characters long"
+)
+
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf(invalidWebhookURL, rawURL)
+	}
+	return nil
+}
+
+func validateWebhookFilter(eventTypes []model.ChangeEvent, kinds []model.EquipmentKind, statuses []model.OperationalStatus) error {
+	for _, eventType := range eventTypes {
+		if !eventType.IsValid() {
+			return fmt.Errorf(invalidEventType, eventType)
+		}
+	}
+	for _, kind := range kinds {
+		if !kind.IsValid() {
+			return fmt.Errorf(invalidFieldValue, "kind", kind)
+		}
+	}
+	for _, status := range statuses {
+		if !status.IsValid() {
+			return fmt.Errorf(invalidFieldValue, "status", status)
+		}
+	}
+	return nil
+}
+
+
+type WebhookCreate struct {
+	URL			string						`json:"url"`
+	EventTypes	[]model.ChangeEvent			`json:"event_types"`	// Empty for all
+	Kinds		[]model.EquipmentKind		`json:"kinds"`			// Empty for any
+	Statuses	[]model.OperationalStatus	`json:"statuses"`		// Empty for any
+	Secret		string						`json:"secret"`
+	Active		*bool						`json:"active"`			// True by default
+}
+
+func (webhookCreate WebhookCreate) Validate() error {
+	if err := validateWebhookURL(webhookCreate.URL); err != nil {
+		return err
+	}
+	if len(webhookCreate.Secret) < minSecretLength {
+		return fmt.Errorf(shortSecret, minSecretLength)
+	}
+	return validateWebhookFilter(webhookCreate.EventTypes, webhookCreate.Kinds, webhookCreate.Statuses)
+}
+
+
+type WebhookUpdate struct {
+	Id			int64						`json:"id"`
+	URL			*string						`json:"url"`
+	EventTypes	*[]model.ChangeEvent		`json:"event_types"`
+	Kinds		*[]model.EquipmentKind		`json:"kinds"`
+	Statuses	*[]model.OperationalStatus	`json:"statuses"`
+	Secret		*string						`json:"secret"`
+	Active		*bool						`json:"active"`
+}
+
+func (webhookUpdate WebhookUpdate) Validate() error {
+	if webhookUpdate.URL == nil && webhookUpdate.EventTypes == nil && webhookUpdate.Kinds == nil &&
+		webhookUpdate.Statuses == nil && webhookUpdate.Secret == nil && webhookUpdate.Active == nil {
+		return errors.New(nothingToUpdate)
+	}
+	if webhookUpdate.URL != nil {
+		if err := validateWebhookURL(*webhookUpdate.URL); err != nil {
+			return err
+		}
+	}
+	if webhookUpdate.Secret != nil && len(*webhookUpdate.Secret) < minSecretLength {
+		return fmt.Errorf(shortSecret, minSecretLength)
+	}
+	var eventTypes []model.ChangeEvent
+	var kinds []model.EquipmentKind
+	var statuses []model.OperationalStatus
+	if webhookUpdate.EventTypes != nil {
+		eventTypes = *webhookUpdate.EventTypes
+	}
+	if webhookUpdate.Kinds != nil {
+		kinds = *webhookUpdate.Kinds
+	}
+	if webhookUpdate.Statuses != nil {
+		statuses = *webhookUpdate.Statuses
+	}
+	return validateWebhookFilter(eventTypes, kinds, statuses)
+}
+
+
+// WebhookGet omits the secret.
+type WebhookGet struct {
+	Id			int64						`json:"id"`
+	URL			string						`json:"url"`
+	EventTypes	[]model.ChangeEvent			`json:"event_types"`
+	Kinds		[]model.EquipmentKind		`json:"kinds"`
+	Statuses	[]model.OperationalStatus	`json:"statuses"`
+	Active		bool						`json:"active"`
+	CreatedAt	time.Time					`json:"created_at"`
+	UpdatedAt	time.Time					`json:"updated_at"`
+}
+
+func WebhookGetFromModel(webhookModel model.Webhook) *WebhookGet {
+	webhookGet := WebhookGet {
+		Id:			webhookModel.Id,
+		URL:		webhookModel.URL,
+		EventTypes:	make([]model.ChangeEvent, 0, len(webhookModel.EventTypes)),
+		Kinds:		make([]model.EquipmentKind, 0, len(webhookModel.Kinds)),
+		Statuses:	make([]model.OperationalStatus, 0, len(webhookModel.Statuses)),
+		Active:		webhookModel.Active,
+		CreatedAt:	webhookModel.CreatedAt,
+		UpdatedAt:	webhookModel.UpdatedAt,
+	}
+	for _, eventType := range webhookModel.EventTypes {
+		webhookGet.EventTypes = append(webhookGet.EventTypes, model.ChangeEvent(eventType))
+	}
+	for _, kind := range webhookModel.Kinds {
+		webhookGet.Kinds = append(webhookGet.Kinds, model.EquipmentKind(kind))
+	}
+	for _, status := range webhookModel.Statuses {
+		webhookGet.Statuses = append(webhookGet.Statuses, model.OperationalStatus(status))
+	}
+	return &webhookGet
+}
+
+
+// WebhookPayload is the body of the request sent to the webhook.
+type WebhookPayload struct {
+	Event		model.ChangeEvent	`json:"event"`
+	OccurredAt	time.Time			`json:"occurred_at"`
+	Equipment	*EquipmentGet		`json:"equipment"`	// State after the change; the last one for deleted equipment
+}
+
+
+type WebhookDeliveryGet struct {
+	Id				int64					`json:"id"`
+	Event			model.ChangeEvent		`json:"event"`
+	Payload			json.RawMessage			`json:"payload"`
+	Status			model.DeliveryStatus	`json:"status"`
+	Attempts		int						`json:"attempts"`
+	ResponseStatus	*int					`json:"response_status"`
+	LastError		*string					`json:"last_error"`
+	CreatedAt		time.Time				`json:"created_at"`
+	NextAttemptAt	*time.Time				`json:"next_attempt_at"`	// Only for pending deliveries
+	DeliveredAt		*time.Time				`json:"delivered_at"`
+}
+
+func WebhookDeliveryGetFromModel(deliveryModel model.WebhookDelivery) *WebhookDeliveryGet {
+	deliveryGet := WebhookDeliveryGet {
+		Id:				deliveryModel.Id,
+		Event:			deliveryModel.Event,
+		Payload:		deliveryModel.Payload,
+		Status:			deliveryModel.Status,
+		Attempts:		deliveryModel.Attempts,
+		ResponseStatus:	deliveryModel.ResponseStatus,
+		LastError:		deliveryModel.LastError,
+		CreatedAt:		deliveryModel.CreatedAt,
+		DeliveredAt:	deliveryModel.DeliveredAt,
+	}
+	if deliveryModel.Status == model.DeliveryPending {
+		deliveryGet.NextAttemptAt = &deliveryModel.NextAttemptAt
+	}
+	return &deliveryGet
+}
+
+type WebhookDeliveries struct {
+	WebhookId	int64					`json:"webhook_id"`
+	Total		int						`json:"total"`
+	Page		int						`json:"page"`
+	PerPage		int						`json:"per_page"`
+	Deliveries	[]*WebhookDeliveryGet	`json:"deliveries"`	// The newest first
+}
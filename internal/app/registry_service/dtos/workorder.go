@@ -0,0 +1,200 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/schema"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	invalidTitle string = "Work order title should not be empty nor longer than 128 characters"
+	invalidAssignee = "`assignee` should not be empty nor longer than 128 characters"
+	invalidClosingState = "Work order can be closed as `done` or `cancelled`, got `%s`"
+	invalidWorkOrderState = "Invalid work order state: `%s`"
+	invalidPart = "Part #%d must have `name` and positive `quantity`"
+)
+
+type WorkOrderCreate struct {
+	EquipmentId		uuid.UUID	`json:"equipment_id"`
+	PlanId			*int64		`json:"plan_id"`
+	Title			string		`json:"title"`
+	Description		string		`json:"description"`
+	OpenedBy		string		`json:"opened_by"`
+	ManagesStatus	bool		`json:"manages_status"`	// Move the equipment UnderMaintenance until the work order is closed
+}
+
+func (workOrderCreate WorkOrderCreate) Validate() error {
+	if workOrderCreate.EquipmentId.IsNil() {
+		return fmt.Errorf(parameterIsRequired, "equipment_id")
+	}
+	if workOrderCreate.Title == "" || len(workOrderCreate.Title) > 128 {
+		return errors.New(invalidTitle)
+	}
+	return nil
+}
+
+func (workOrderCreate *WorkOrderCreate) WorkOrder() *model.WorkOrder {
+	return &model.WorkOrder {
+		EquipmentId:	workOrderCreate.EquipmentId,
+		PlanId:			workOrderCreate.PlanId,
+		Title:			workOrderCreate.Title,
+		Description:	workOrderCreate.Description,
+		State:			model.WorkOrderOpen,
+		OpenedBy:		workOrderCreate.OpenedBy,
+		Parts:			[]byte("[]"),
+		ManagesStatus:	workOrderCreate.ManagesStatus,
+	}
+}
+
+
+type WorkOrderAssign struct {
+	Assignee	string	`json:"assignee"`
+}
+
+func (workOrderAssign WorkOrderAssign) Validate() error {
+	if workOrderAssign.Assignee == "" || len(workOrderAssign.Assignee) > 128 {
+		return errors.New(invalidAssignee)
+	}
+	return nil
+}
+
+
+type WorkOrderClose struct {
+	State		model.WorkOrderState	`json:"state"`			// `done` by default
+	ClosedBy	string					`json:"closed_by"`
+	Resolution	string					`json:"resolution"`
+	Parts		[]model.WorkOrderPart	`json:"parts"`
+	TimeSpent	string					`json:"time_spent"`	// Since the start by default, e.g. `1h30m`
+}
+
+func (workOrderClose WorkOrderClose) Validate() error {
+	if workOrderClose.State != "" && !workOrderClose.State.IsClosed() {
+		return fmt.Errorf(invalidClosingState, workOrderClose.State)
+	}
+	for i, part := range workOrderClose.Parts {
+		if part.Name == "" || part.Quantity <= 0 {
+			return fmt.Errorf(invalidPart, i)
+		}
+	}
+	if workOrderClose.TimeSpent != "" {
+		_, err := parseDays("time_spent", workOrderClose.TimeSpent)
+		return err
+	}
+	return nil
+}
+
+// Apply closes the work order at now.
+func (workOrderClose *WorkOrderClose) Apply(workOrder *model.WorkOrder, now time.Time) {
+	workOrder.State = workOrderClose.State
+	if workOrder.State == "" {
+		workOrder.State = model.WorkOrderDone
+	}
+	workOrder.ClosedBy = workOrderClose.ClosedBy
+	workOrder.Resolution = workOrderClose.Resolution
+	if workOrderClose.Parts != nil {
+		workOrder.Parts, _ = json.Marshal(workOrderClose.Parts)
+	}
+	var timeSpent time.Duration
+	if workOrderClose.TimeSpent != "" {
+		timeSpent, _ = parseDays("time_spent", workOrderClose.TimeSpent)
+	} else if workOrder.StartedAt != nil {
+		timeSpent = now.Sub(*workOrder.StartedAt)
+	}
+	if timeSpent > 0 {
+		seconds := int64(timeSpent / time.Second)
+		workOrder.TimeSpentSeconds = &seconds
+	}
+	workOrder.ClosedAt = &now
+}
+
+
+type WorkOrderGet struct {
+	Id				int64					`json:"id"`
+	EquipmentId		uuid.UUID				`json:"equipment_id"`
+	PlanId			*int64					`json:"plan_id,omitempty"`
+	Title			string					`json:"title"`
+	Description		string					`json:"description"`
+	State			model.WorkOrderState	`json:"state"`
+	OpenedBy		string					`json:"opened_by"`
+	Assignee		string					`json:"assignee"`
+	ClosedBy		string					`json:"closed_by"`
+	Resolution		string					`json:"resolution"`
+	Parts			[]model.WorkOrderPart	`json:"parts"`
+	TimeSpent		string					`json:"time_spent,omitempty"`
+	ManagesStatus	bool					`json:"manages_status"`
+	CreatedAt		time.Time				`json:"created_at"`
+	AssignedAt		*time.Time				`json:"assigned_at"`
+	StartedAt		*time.Time				`json:"started_at"`
+	ClosedAt		*time.Time				`json:"closed_at"`
+	UpdatedAt		time.Time				`json:"updated_at"`
+}
+
+func WorkOrderGetFromModel(workOrderModel model.WorkOrder) *WorkOrderGet {
+	workOrderGet := WorkOrderGet {
+		Id:				workOrderModel.Id,
+		EquipmentId:	workOrderModel.EquipmentId,
+		PlanId:			workOrderModel.PlanId,
+		Title:			workOrderModel.Title,
+		Description:	workOrderModel.Description,
+		State:			workOrderModel.State,
+		OpenedBy:		workOrderModel.OpenedBy,
+		Assignee:		workOrderModel.Assignee,
+		ClosedBy:		workOrderModel.ClosedBy,
+		Resolution:		workOrderModel.Resolution,
+		Parts:			[]model.WorkOrderPart{},
+		ManagesStatus:	workOrderModel.ManagesStatus,
+		CreatedAt:		workOrderModel.CreatedAt,
+		AssignedAt:		workOrderModel.AssignedAt,
+		StartedAt:		workOrderModel.StartedAt,
+		ClosedAt:		workOrderModel.ClosedAt,
+		UpdatedAt:		workOrderModel.UpdatedAt,
+	}
+	_ = json.Unmarshal(workOrderModel.Parts, &workOrderGet.Parts)
+	if workOrderModel.TimeSpentSeconds != nil {
+		workOrderGet.TimeSpent = (time.Duration(*workOrderModel.TimeSpentSeconds) * time.Second).String()
+	}
+	return &workOrderGet
+}
+
+
+type WorkOrderFilter struct {
+	HistoryPage
+	EquipmentIds	[]uuid.UUID				`schema:"equipment_id"`
+	States			[]model.WorkOrderState	`schema:"state"`
+	Assignees		[]string				`schema:"assignee"`
+	PlanIds			[]int64					`schema:"plan_id"`
+}
+
+func (workOrderFilter *WorkOrderFilter) Validate() error {
+	for _, state := range workOrderFilter.States {
+		if !state.IsValid() {
+			return fmt.Errorf(invalidWorkOrderState, state)
+		}
+	}
+	return workOrderFilter.HistoryPage.Validate()
+}
+
+func WorkOrderFilterFromRequest(request *http.Request) (*WorkOrderFilter, error) {
+	var err error
+	if err = request.ParseForm(); err == nil {
+		var workOrderFilter WorkOrderFilter
+		if err = schema.NewDecoder().Decode(&workOrderFilter, request.Form); err == nil {
+			if err = workOrderFilter.Validate(); err == nil {
+				return &workOrderFilter, nil
+			}
+		}
+	}
+	return nil, err
+}
+
+type WorkOrderList struct {
+	Total		int				`json:"total"`
+	Page		int				`json:"page"`
+	PerPage		int				`json:"per_page"`
+	WorkOrders	[]*WorkOrderGet	`json:"work_orders"`
+}
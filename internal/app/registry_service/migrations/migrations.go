@@ -1,20 +1,172 @@
 package migrations
 
 import (
-	"github.com/jmoiron/sqlx"	
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/paramschema"
 )
 
+// CreateTables creates all the tables in order of their dependencies.
+func CreateTables(db *sqlx.DB) error {
+	for _, create := range []func(*sqlx.DB) error {
+		CreateTableEquipmentKinds,
+		CreateTableEquipment,
+		CreateTableEquipmentTransitions,
+		CreateTableEquipmentRevisions,
+		CreateTableEquipmentEvents,
+		CreateTableOutbox,
+		CreateTableWebhooks,
+		CreateTableWebhookDeliveries,
+		CreateTableTelemetry,
+		CreateTableTelemetryBatches,
+		CreateTablesTelemetryRollups,
+		CreateTableAlertRules,
+		CreateTableAlerts,
+		CreateTableEquipmentConnectivity,
+		CreateTableAnomalyDetectors,
+		CreateTableAnomalyDetectorStates,
+		CreateTableAnomalies,
+		CreateTableMaintenancePlans,
+		CreateTableMaintenanceSchedule,
+		CreateTableWorkOrders,
+		CreateTablesSpareParts,
+	} {
+		if err := create(db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropTables drops all the tables in reverse order of their dependencies.
+func DropTables(db *sqlx.DB) error {
+	for _, drop := range []func(*sqlx.DB) error {
+		DropTablesSpareParts,
+		DropTableWorkOrders,
+		DropTableMaintenanceSchedule,
+		DropTableMaintenancePlans,
+		DropTableAnomalies,
+		DropTableAnomalyDetectorStates,
+		DropTableAnomalyDetectors,
+		DropTableEquipmentConnectivity,
+		DropTableAlerts,
+		DropTableAlertRules,
+		DropTablesTelemetryRollups,
+		DropTableTelemetryBatches,
+		DropTableTelemetry,
+		DropTableWebhookDeliveries,
+		DropTableWebhooks,
+		DropTableOutbox,
+		DropTableEquipmentEvents,
+		DropTableEquipmentRevisions,
+		DropTableEquipmentTransitions,
+		DropTableEquipment,
+		DropTableEquipmentKinds,
+	} {
+		if err := drop(db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func CreateTableEquipmentKinds(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.equipment_kinds (
+			id SMALLSERIAL PRIMARY KEY,
+			name VARCHAR(64) NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			parameter_schema JSONB NOT NULL,
+			icon TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp CHECK (updated_at >= created_at)
+		);
+		ALTER TABLE public.equipment_kinds ADD COLUMN IF NOT EXISTS metrics JSONB NOT NULL DEFAULT '[]';
+		ALTER TABLE public.equipment_kinds ADD COLUMN IF NOT EXISTS heartbeat_interval_seconds INTEGER NOT NULL DEFAULT 60 CHECK(heartbeat_interval_seconds > 0);
+		CREATE UNIQUE INDEX IF NOT EXISTS equipment_kinds_name_idx ON public.equipment_kinds (lower(name));
+	`)
+	if err != nil {
+		return err
+	}
+	return seedEquipmentKinds(db)
+}
+
+// seedEquipmentKinds inserts the predefined kinds keeping their former numeric values as identifiers;
+// the seeded kinds without metrics get the builtin ones, the seeded kinds with metrics get the missing production counters.
+func seedEquipmentKinds(db *sqlx.DB) error {
+	descriptions := map[model.EquipmentKind]string {
+		model.CNCMachine:	"Computer numerical control machine tool",
+		model.ConveyorBelt:	"Belt conveyor",
+		model.DrillMachine:	"Drilling machine",
+		model.RoboticArm:	"Industrial robotic arm",
+	}
+	for kind, description := range descriptions {
+		parameterSchema, _ := paramschema.Builtin(kind)
+		metrics, _ := json.Marshal(model.BuiltinMetrics[kind])
+		_, err := db.Exec(
+			`INSERT INTO public.equipment_kinds (id, name, description, parameter_schema, metrics) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (id) DO UPDATE SET metrics=EXCLUDED.metrics WHERE equipment_kinds.metrics='[]'`,
+			kind, kind.String(), description, parameterSchema, metrics,
+		)
+		if err != nil {
+			return err
+		}
+		for _, counter := range model.ProductionCounters {
+			declared, _ := json.Marshal([]model.Metric{counter})
+			named, _ := json.Marshal([]map[string]string{{"name": counter.Name}})
+			if _, err = db.Exec(
+				`UPDATE public.equipment_kinds SET metrics=metrics || $2 WHERE id=$1 AND NOT metrics @> $3`,
+				kind, declared, named,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	// Kinds added later must not collide with the seeded ones
+	_, err := db.Exec(`SELECT setval(pg_get_serial_sequence('public.equipment_kinds', 'id'), MAX(id)) FROM public.equipment_kinds`)
+	return err
+}
+
+func DropTableEquipmentKinds(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.equipment_kinds`)
+	return err
+}
+
 func CreateTableEquipment(db *sqlx.DB) error {
 	_, err := db.Exec(`
 		CREATE TABLE IF NOT EXISTS public.equipment (
 			id UUID PRIMARY KEY,
-			kind SMALLINT NOT NULL CHECK(kind BETWEEN 0 AND 3),
+			kind SMALLINT NOT NULL REFERENCES public.equipment_kinds (id) ON DELETE RESTRICT,
 			status SMALLINT NOT NULL CHECK(status BETWEEN 0 AND 2),
 			parameters JSONB NOT NULL,
 			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
 			updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp CHECK (updated_at >= created_at)
 		);
+		-- The table created before the kind catalog gets the reference to it (the seeded kinds keep the former values)
+		DO $$
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM pg_constraint WHERE conrelid='public.equipment'::regclass AND contype='f' AND conname='equipment_kind_fkey'
+			) THEN
+				ALTER TABLE public.equipment ADD CONSTRAINT equipment_kind_fkey
+					FOREIGN KEY (kind) REFERENCES public.equipment_kinds (id) ON DELETE RESTRICT;
+			END IF;
+		END
+		$$;
+		-- Keyset pagination of the list: the ties of each sort are ordered by the id
+		DROP INDEX IF EXISTS equipment_kind_idx;
+		CREATE INDEX IF NOT EXISTS equipment_kind_id_idx ON public.equipment (kind, id);
+		CREATE INDEX IF NOT EXISTS equipment_status_id_idx ON public.equipment (status, id);
+		CREATE INDEX IF NOT EXISTS equipment_created_at_id_idx ON public.equipment (created_at, id);
+		CREATE INDEX IF NOT EXISTS equipment_updated_at_id_idx ON public.equipment (updated_at, id);
+		-- Filters on the parameters: the containment and the presence of the keys use the GIN index,
+		-- the ranges of the parameters frequently compared use the expression indexes on their JSON values
+		CREATE INDEX IF NOT EXISTS equipment_parameters_idx ON public.equipment USING GIN (parameters);
+		CREATE INDEX IF NOT EXISTS equipment_spindle_rpm_idx ON public.equipment ((parameters->'spindle_rpm'));
+		CREATE INDEX IF NOT EXISTS equipment_payload_kg_idx ON public.equipment ((parameters->'payload_kg'));
+		CREATE INDEX IF NOT EXISTS equipment_ideal_cycle_time_s_idx ON public.equipment ((parameters->'ideal_cycle_time_s'));
 	`)
 	return err
 }
@@ -23,3 +175,568 @@ func DropTableEquipment(db *sqlx.DB) error {
 	_, err := db.Exec(`DROP TABLE IF EXISTS public.equipment`)
 	return err
 }
+
+// CreateTableEquipmentTransitions creates the status history which outlives the equipment;
+// from_status is NULL for the initial status.
+func CreateTableEquipmentTransitions(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.equipment_transitions (
+			id BIGSERIAL PRIMARY KEY,
+			equipment_id UUID NOT NULL,
+			from_status SMALLINT CHECK(from_status BETWEEN 0 AND 2),
+			to_status SMALLINT NOT NULL CHECK(to_status BETWEEN 0 AND 2),
+			reason TEXT NOT NULL DEFAULT '',
+			actor TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+		);
+		CREATE INDEX IF NOT EXISTS equipment_transitions_equipment_idx ON public.equipment_transitions (equipment_id, created_at);
+	`)
+	return err
+}
+
+func DropTableEquipmentTransitions(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.equipment_transitions`)
+	return err
+}
+
+// CreateTableEquipmentRevisions creates the append-only history of equipment states which outlives the equipment.
+func CreateTableEquipmentRevisions(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.equipment_revisions (
+			equipment_id UUID NOT NULL,
+			revision INTEGER NOT NULL CHECK(revision > 0),
+			operation VARCHAR(16) NOT NULL CHECK(operation IN ('created', 'updated', 'deleted')),
+			kind SMALLINT NOT NULL,
+			status SMALLINT NOT NULL,
+			parameters JSONB NOT NULL,
+			recorded_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (equipment_id, revision)
+		);
+		CREATE INDEX IF NOT EXISTS equipment_revisions_recorded_idx ON public.equipment_revisions (recorded_at);
+		-- Equipment created before the revisions were recorded gets its current state as the first revision
+		INSERT INTO public.equipment_revisions (equipment_id, revision, operation, kind, status, parameters, recorded_at)
+		SELECT id, 1, 'created', kind, status, parameters, created_at FROM public.equipment
+		ON CONFLICT DO NOTHING;
+	`)
+	return err
+}
+
+func DropTableEquipmentRevisions(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.equipment_revisions`)
+	return err
+}
+
+// CreateTableEquipmentEvents creates the event store, the source of truth for the equipment table,
+// its revisions and transitions which are projections of the events.
+func CreateTableEquipmentEvents(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.equipment_events (
+			position BIGSERIAL UNIQUE,
+			aggregate_id UUID NOT NULL,
+			version INTEGER NOT NULL CHECK(version > 0),
+			type VARCHAR(64) NOT NULL,
+			payload JSONB NOT NULL,
+			occurred_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			PRIMARY KEY (aggregate_id, version)
+		);
+		-- The revisions recorded before the events become the events of the same versions, so the history survives the rebuild
+		-- of the projections and the next event continues the numbering; the reason of the status change is taken
+		-- from the transition closest in time
+		INSERT INTO public.equipment_events (aggregate_id, version, type, payload, occurred_at)
+		SELECT
+			revision.equipment_id, revision.revision,
+			CASE revision.operation
+				WHEN 'created' THEN 'EquipmentRegistered'
+				WHEN 'deleted' THEN 'EquipmentRemoved'
+				ELSE 'EquipmentRevised'
+			END,
+			CASE revision.operation
+				WHEN 'created' THEN jsonb_build_object('kind', revision.kind, 'status', revision.status, 'parameters', revision.parameters)
+				WHEN 'deleted' THEN CAST('{}' AS jsonb)
+				ELSE jsonb_build_object(
+					'from', revision.from_status, 'status', revision.status, 'parameters', revision.parameters,
+					'reason', COALESCE(transition.reason, ''), 'actor', COALESCE(transition.actor, '')
+				)
+			END,
+			revision.recorded_at
+		FROM (
+			SELECT *, LAG(status) OVER (PARTITION BY equipment_id ORDER BY revision) AS from_status
+			FROM public.equipment_revisions
+		) AS revision
+		LEFT JOIN LATERAL (
+			SELECT reason, actor FROM public.equipment_transitions
+			WHERE equipment_id=revision.equipment_id AND from_status=revision.from_status AND to_status=revision.status
+				AND revision.from_status<>revision.status
+			ORDER BY abs(EXTRACT(EPOCH FROM created_at - revision.recorded_at))
+			LIMIT 1
+		) AS transition ON true
+		ORDER BY revision.recorded_at, revision.revision
+		ON CONFLICT DO NOTHING;
+	`)
+	return err
+}
+
+func DropTableEquipmentEvents(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.equipment_events`)
+	return err
+}
+
+// CreateTableOutbox creates the queue of messages to publish written in the transactions of the changes.
+func CreateTableOutbox(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.outbox (
+			id BIGSERIAL PRIMARY KEY,
+			aggregate_id UUID NOT NULL,
+			type VARCHAR(64) NOT NULL,
+			payload JSONB NOT NULL,
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			last_error TEXT,
+			published_at TIMESTAMP
+		);
+		-- The lease of the relay publishing the message
+		ALTER TABLE public.outbox ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP;
+		-- The transaction writing the message orders the messages by commit for the stream
+		ALTER TABLE public.outbox ADD COLUMN IF NOT EXISTS transaction_id xid8 NOT NULL DEFAULT pg_current_xact_id();
+		CREATE INDEX IF NOT EXISTS outbox_position_idx ON public.outbox (transaction_id, id);
+		CREATE INDEX IF NOT EXISTS outbox_unpublished_idx ON public.outbox (next_attempt_at) WHERE published_at IS NULL;
+		CREATE INDEX IF NOT EXISTS outbox_aggregate_unpublished_idx ON public.outbox (aggregate_id, id) WHERE published_at IS NULL;
+	`)
+	return err
+}
+
+func DropTableOutbox(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.outbox`)
+	return err
+}
+
+// CreateTableWebhooks creates the subscriptions to the changes of equipment; empty arrays match any value.
+func CreateTableWebhooks(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.webhooks (
+			id BIGSERIAL PRIMARY KEY,
+			url TEXT NOT NULL,
+			event_types TEXT[] NOT NULL DEFAULT '{}',
+			kinds BIGINT[] NOT NULL DEFAULT '{}',
+			statuses BIGINT[] NOT NULL DEFAULT '{}',
+			secret TEXT NOT NULL,
+			active BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp CHECK (updated_at >= created_at)
+		);
+	`)
+	return err
+}
+
+func DropTableWebhooks(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.webhooks`)
+	return err
+}
+
+// CreateTableWebhookDeliveries creates the queue of requests to the webhooks which also serves as their log;
+// deliveries are removed along with the webhook.
+func CreateTableWebhookDeliveries(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.webhook_deliveries (
+			id BIGSERIAL PRIMARY KEY,
+			webhook_id BIGINT NOT NULL REFERENCES public.webhooks (id) ON DELETE CASCADE,
+			event VARCHAR(64) NOT NULL,
+			payload JSONB NOT NULL,
+			status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK(status IN ('pending', 'delivered', 'dead')),
+			attempts INTEGER NOT NULL DEFAULT 0,
+			response_status INTEGER,
+			last_error TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			next_attempt_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			delivered_at TIMESTAMP
+		);
+		-- The lease of the dispatcher attempting the delivery
+		ALTER TABLE public.webhook_deliveries ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP;
+		CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_idx ON public.webhook_deliveries (webhook_id, id);
+		CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON public.webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+	`)
+	return err
+}
+
+func DropTableWebhookDeliveries(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.webhook_deliveries`)
+	return err
+}
+
+// CreateTableTelemetry creates the readings table partitioned by days of recorded_at;
+// partitions are created on demand by the ingestion, so the table may be used right away.
+func CreateTableTelemetry(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.telemetry (
+			equipment_id UUID NOT NULL,
+			metric VARCHAR(64) NOT NULL,
+			value DOUBLE PRECISION NOT NULL,
+			unit VARCHAR(32) NOT NULL,
+			recorded_at TIMESTAMP NOT NULL
+		) PARTITION BY RANGE (recorded_at);
+		CREATE INDEX IF NOT EXISTS telemetry_equipment_metric_idx ON public.telemetry (equipment_id, metric, recorded_at);
+	`)
+	return err
+}
+
+// DropTableTelemetry drops the readings table with all its partitions.
+func DropTableTelemetry(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.telemetry`)
+	return err
+}
+
+// CreateTableTelemetryBatches creates the queue of the batches of readings awaiting each of the reading listeners
+// written in the transaction storing the readings; the batch is removed once the listener has succeeded.
+func CreateTableTelemetryBatches(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.telemetry_batches (
+			id BIGSERIAL PRIMARY KEY,
+			listener VARCHAR(64) NOT NULL,
+			equipment_id UUID NOT NULL,
+			readings JSONB NOT NULL,
+			received_at TIMESTAMP NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			last_error TEXT,
+			locked_until TIMESTAMP
+		);
+		CREATE INDEX IF NOT EXISTS telemetry_batches_due_idx ON public.telemetry_batches (next_attempt_at);
+		CREATE INDEX IF NOT EXISTS telemetry_batches_order_idx ON public.telemetry_batches (listener, equipment_id, id);
+	`)
+	return err
+}
+
+func DropTableTelemetryBatches(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.telemetry_batches`)
+	return err
+}
+
+// CreateTablesTelemetryRollups creates the readings aggregated by minutes and by hours, the watermarks
+// the rollups are complete until (the watermarks start from the earliest reading) and the buckets to roll up again.
+func CreateTablesTelemetryRollups(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.telemetry_1m (
+			equipment_id UUID NOT NULL,
+			metric VARCHAR(64) NOT NULL,
+			bucket TIMESTAMP NOT NULL,
+			value_count BIGINT NOT NULL,
+			value_sum DOUBLE PRECISION NOT NULL,
+			value_min DOUBLE PRECISION NOT NULL,
+			value_max DOUBLE PRECISION NOT NULL,
+			PRIMARY KEY (equipment_id, metric, bucket)
+		);
+		CREATE TABLE IF NOT EXISTS public.telemetry_1h (LIKE public.telemetry_1m INCLUDING ALL);
+		CREATE TABLE IF NOT EXISTS public.telemetry_rollups (
+			resolution VARCHAR(8) PRIMARY KEY,
+			rolled_until TIMESTAMP NOT NULL
+		);
+		INSERT INTO public.telemetry_rollups (resolution, rolled_until)
+		SELECT resolution, date_trunc(field, COALESCE((SELECT min(recorded_at) FROM public.telemetry), now() AT TIME ZONE 'UTC'))
+		FROM (VALUES ('1m', 'minute'), ('1h', 'hour')) AS rollup (resolution, field)
+		ON CONFLICT (resolution) DO NOTHING;
+		CREATE TABLE IF NOT EXISTS public.telemetry_dirty_buckets (
+			resolution VARCHAR(8) NOT NULL,
+			equipment_id UUID NOT NULL,
+			bucket TIMESTAMP NOT NULL,
+			PRIMARY KEY (resolution, equipment_id, bucket)
+		);
+	`)
+	return err
+}
+
+func DropTablesTelemetryRollups(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.telemetry_dirty_buckets, public.telemetry_rollups, public.telemetry_1h, public.telemetry_1m`)
+	return err
+}
+
+func CreateTableAlertRules(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.alert_rules (
+			id BIGSERIAL PRIMARY KEY,
+			kind SMALLINT NOT NULL REFERENCES public.equipment_kinds (id) ON DELETE CASCADE,
+			name VARCHAR(128) NOT NULL,
+			metric VARCHAR(64) NOT NULL,
+			operator VARCHAR(2) NOT NULL CHECK(operator IN ('>', '>=', '<', '<=')),
+			threshold DOUBLE PRECISION NOT NULL,
+			for_seconds INTEGER NOT NULL DEFAULT 0 CHECK(for_seconds >= 0),
+			hysteresis DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK(hysteresis >= 0),
+			while_status SMALLINT CHECK(while_status BETWEEN 0 AND 2),
+			active BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp CHECK (updated_at >= created_at)
+		);
+		CREATE INDEX IF NOT EXISTS alert_rules_kind_idx ON public.alert_rules (kind, metric);
+	`)
+	return err
+}
+
+func DropTableAlertRules(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.alert_rules`)
+	return err
+}
+
+// CreateTableAlerts creates the alerts raised by the rules; each rule has at most one open (pending or firing) alert per equipment.
+func CreateTableAlerts(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.alerts (
+			id BIGSERIAL PRIMARY KEY,
+			rule_id BIGINT NOT NULL REFERENCES public.alert_rules (id) ON DELETE CASCADE,
+			equipment_id UUID NOT NULL,
+			kind SMALLINT NOT NULL,
+			metric VARCHAR(64) NOT NULL,
+			state VARCHAR(16) NOT NULL CHECK(state IN ('pending', 'firing', 'resolved')),
+			value DOUBLE PRECISION NOT NULL,
+			started_at TIMESTAMP NOT NULL,
+			fired_at TIMESTAMP,
+			resolved_at TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS alerts_open_idx ON public.alerts (rule_id, equipment_id) WHERE state IN ('pending', 'firing');
+		CREATE INDEX IF NOT EXISTS alerts_equipment_idx ON public.alerts (equipment_id, id);
+		CREATE INDEX IF NOT EXISTS alerts_kind_state_idx ON public.alerts (kind, state);
+	`)
+	return err
+}
+
+func DropTableAlerts(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.alerts`)
+	return err
+}
+
+// CreateTableEquipmentConnectivity creates the connectivity state of the equipment which has ever been seen;
+// it is kept apart from the projections of the events.
+func CreateTableEquipmentConnectivity(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.equipment_connectivity (
+			equipment_id UUID PRIMARY KEY,
+			state VARCHAR(16) NOT NULL CHECK(state IN ('online', 'unreachable')),
+			last_seen_at TIMESTAMP NOT NULL,
+			changed_at TIMESTAMP NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS equipment_connectivity_online_idx ON public.equipment_connectivity (last_seen_at) WHERE state = 'online';
+	`)
+	return err
+}
+
+func DropTableEquipmentConnectivity(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.equipment_connectivity`)
+	return err
+}
+
+func CreateTableAnomalyDetectors(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.anomaly_detectors (
+			id BIGSERIAL PRIMARY KEY,
+			kind SMALLINT NOT NULL REFERENCES public.equipment_kinds (id) ON DELETE CASCADE,
+			metric VARCHAR(64) NOT NULL,
+			method VARCHAR(16) NOT NULL CHECK(method IN ('ewma', 'zscore')),
+			alpha DOUBLE PRECISION NOT NULL CHECK(alpha > 0 AND alpha <= 1),
+			window_size INTEGER NOT NULL CHECK(window_size >= 2),
+			threshold DOUBLE PRECISION NOT NULL CHECK(threshold > 0),
+			warmup INTEGER NOT NULL CHECK(warmup >= 2),
+			active BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp CHECK (updated_at >= created_at)
+		);
+		CREATE INDEX IF NOT EXISTS anomaly_detectors_kind_idx ON public.anomaly_detectors (kind, metric);
+	`)
+	return err
+}
+
+func DropTableAnomalyDetectors(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.anomaly_detectors`)
+	return err
+}
+
+// CreateTableAnomalyDetectorStates creates the baselines of the detectors per equipment updated by each reading.
+func CreateTableAnomalyDetectorStates(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.anomaly_detector_states (
+			detector_id BIGINT NOT NULL REFERENCES public.anomaly_detectors (id) ON DELETE CASCADE,
+			equipment_id UUID NOT NULL,
+			count BIGINT NOT NULL,
+			mean DOUBLE PRECISION NOT NULL,
+			variance DOUBLE PRECISION NOT NULL,
+			recent DOUBLE PRECISION[] NOT NULL DEFAULT '{}',
+			last_recorded_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (detector_id, equipment_id)
+		);
+		CREATE INDEX IF NOT EXISTS anomaly_detector_states_equipment_idx ON public.anomaly_detector_states (equipment_id);
+	`)
+	return err
+}
+
+func DropTableAnomalyDetectorStates(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.anomaly_detector_states`)
+	return err
+}
+
+func CreateTableAnomalies(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.anomalies (
+			id BIGSERIAL PRIMARY KEY,
+			detector_id BIGINT NOT NULL REFERENCES public.anomaly_detectors (id) ON DELETE CASCADE,
+			equipment_id UUID NOT NULL,
+			kind SMALLINT NOT NULL,
+			metric VARCHAR(64) NOT NULL,
+			method VARCHAR(16) NOT NULL,
+			value DOUBLE PRECISION NOT NULL,
+			expected DOUBLE PRECISION NOT NULL,
+			score DOUBLE PRECISION NOT NULL,
+			recorded_at TIMESTAMP NOT NULL,
+			detected_at TIMESTAMP NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS anomalies_equipment_idx ON public.anomalies (equipment_id, recorded_at);
+	`)
+	return err
+}
+
+func DropTableAnomalies(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.anomalies`)
+	return err
+}
+
+// CreateTableMaintenancePlans creates the preventive maintenance plans of either the equipment or the kind.
+func CreateTableMaintenancePlans(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.maintenance_plans (
+			id BIGSERIAL PRIMARY KEY,
+			name VARCHAR(128) NOT NULL,
+			equipment_id UUID,
+			kind SMALLINT REFERENCES public.equipment_kinds (id) ON DELETE CASCADE,
+			trigger_type VARCHAR(16) NOT NULL CHECK(trigger_type IN ('calendar', 'operating_hours', 'counter')),
+			interval_seconds BIGINT NOT NULL DEFAULT 0 CHECK(interval_seconds >= 0),
+			threshold DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK(threshold >= 0),
+			metric VARCHAR(64) NOT NULL DEFAULT '',
+			active BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp CHECK (updated_at >= created_at),
+			CHECK ((equipment_id IS NULL) <> (kind IS NULL))
+		);
+		CREATE INDEX IF NOT EXISTS maintenance_plans_equipment_idx ON public.maintenance_plans (equipment_id);
+	`)
+	return err
+}
+
+func DropTableMaintenancePlans(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.maintenance_plans`)
+	return err
+}
+
+// CreateTableMaintenanceSchedule creates the next due dates of the plans per equipment recomputed by the scheduler.
+func CreateTableMaintenanceSchedule(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.maintenance_schedule (
+			plan_id BIGINT NOT NULL REFERENCES public.maintenance_plans (id) ON DELETE CASCADE,
+			equipment_id UUID NOT NULL,
+			last_done_at TIMESTAMP NOT NULL,
+			usage DOUBLE PRECISION,
+			due_at TIMESTAMP,
+			computed_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (plan_id, equipment_id)
+		);
+		CREATE INDEX IF NOT EXISTS maintenance_schedule_due_idx ON public.maintenance_schedule (due_at);
+	`)
+	return err
+}
+
+func DropTableMaintenanceSchedule(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.maintenance_schedule`)
+	return err
+}
+
+func CreateTableWorkOrders(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.work_orders (
+			id BIGSERIAL PRIMARY KEY,
+			equipment_id UUID NOT NULL,
+			plan_id BIGINT REFERENCES public.maintenance_plans (id) ON DELETE SET NULL,
+			title VARCHAR(128) NOT NULL,
+			description TEXT NOT NULL DEFAULT '',
+			state VARCHAR(16) NOT NULL CHECK(state IN ('open', 'assigned', 'in_progress', 'done', 'cancelled')),
+			opened_by VARCHAR(128) NOT NULL DEFAULT '',
+			assignee VARCHAR(128) NOT NULL DEFAULT '',
+			closed_by VARCHAR(128) NOT NULL DEFAULT '',
+			resolution TEXT NOT NULL DEFAULT '',
+			parts JSONB NOT NULL DEFAULT '[]',
+			time_spent_seconds BIGINT CHECK(time_spent_seconds >= 0),
+			manages_status BOOLEAN NOT NULL DEFAULT FALSE,
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			assigned_at TIMESTAMP,
+			started_at TIMESTAMP,
+			closed_at TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp CHECK (updated_at >= created_at)
+		);
+		-- The version of the equipment aggregate after the work order has moved it UnderMaintenance;
+		-- the open work orders managing the status created before take the last status change of their equipment under maintenance
+		DO $$
+		BEGIN
+			IF NOT EXISTS (
+				SELECT 1 FROM information_schema.columns WHERE table_schema='public' AND table_name='work_orders' AND column_name='status_version'
+			) THEN
+				ALTER TABLE public.work_orders ADD COLUMN status_version INTEGER;
+				UPDATE public.work_orders SET status_version=(
+					SELECT max(version) FROM public.equipment_events
+					WHERE aggregate_id=work_orders.equipment_id AND type IN ('EquipmentRegistered', 'StatusChanged', 'EquipmentRevised')
+				)
+				WHERE manages_status AND state NOT IN ('done', 'cancelled')
+				AND EXISTS (SELECT 1 FROM public.equipment WHERE id=work_orders.equipment_id AND status=1);
+			END IF;
+		END
+		$$;
+		CREATE INDEX IF NOT EXISTS work_orders_equipment_idx ON public.work_orders (equipment_id, id);
+		CREATE INDEX IF NOT EXISTS work_orders_open_idx ON public.work_orders (state) WHERE state NOT IN ('done', 'cancelled');
+	`)
+	return err
+}
+
+func DropTableWorkOrders(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.work_orders`)
+	return err
+}
+
+// CreateTablesSpareParts creates the parts catalog, the stock of the parts per storeroom and the reservations of the stock by the work orders.
+func CreateTablesSpareParts(db *sqlx.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS public.spare_parts (
+			id BIGSERIAL PRIMARY KEY,
+			sku VARCHAR(64) NOT NULL UNIQUE,
+			description TEXT NOT NULL DEFAULT '',
+			unit VARCHAR(16) NOT NULL DEFAULT 'pcs',
+			kinds SMALLINT[] NOT NULL DEFAULT '{}',
+			equipment_ids UUID[] NOT NULL DEFAULT '{}',
+			reorder_level DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK(reorder_level >= 0),
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp CHECK (updated_at >= created_at)
+		);
+		CREATE INDEX IF NOT EXISTS spare_parts_kinds_idx ON public.spare_parts USING GIN (kinds);
+		CREATE INDEX IF NOT EXISTS spare_parts_equipment_idx ON public.spare_parts USING GIN (equipment_ids);
+		CREATE TABLE IF NOT EXISTS public.part_stock (
+			part_id BIGINT NOT NULL REFERENCES public.spare_parts (id) ON DELETE CASCADE,
+			storeroom VARCHAR(64) NOT NULL,
+			on_hand DOUBLE PRECISION NOT NULL DEFAULT 0,
+			reserved DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK(reserved >= 0),
+			PRIMARY KEY (part_id, storeroom),
+			CHECK (on_hand >= reserved)
+		);
+		CREATE TABLE IF NOT EXISTS public.part_reservations (
+			id BIGSERIAL PRIMARY KEY,
+			work_order_id BIGINT NOT NULL REFERENCES public.work_orders (id) ON DELETE CASCADE,
+			part_id BIGINT NOT NULL REFERENCES public.spare_parts (id),
+			storeroom VARCHAR(64) NOT NULL,
+			quantity DOUBLE PRECISION NOT NULL CHECK(quantity > 0),
+			state VARCHAR(16) NOT NULL CHECK(state IN ('reserved', 'consumed', 'released')),
+			created_at TIMESTAMP NOT NULL DEFAULT current_timestamp,
+			updated_at TIMESTAMP NOT NULL DEFAULT current_timestamp
+		);
+		CREATE INDEX IF NOT EXISTS part_reservations_work_order_idx ON public.part_reservations (work_order_id, state);
+		CREATE INDEX IF NOT EXISTS part_reservations_part_idx ON public.part_reservations (part_id);
+	`)
+	return err
+}
+
+func DropTablesSpareParts(db *sqlx.DB) error {
+	_, err := db.Exec(`DROP TABLE IF EXISTS public.part_reservations, public.part_stock, public.spare_parts`)
+	return err
+}
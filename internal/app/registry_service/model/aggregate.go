@@ -0,0 +1,144 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+// AggregateChange is the event raised by the aggregate together with the state it has led to and the one preceding it.
+type AggregateChange struct {
+	Event		Event
+	State		Equipment
+	Previous	Equipment
+}
+
+// EquipmentAggregate is the write model of the equipment rebuilt from its events;
+// commands validate against the current state and raise events which are applied immediately.
+type EquipmentAggregate struct {
+	State		Equipment
+	Version		int
+	Registered	bool
+	Removed		bool
+	changes		[]AggregateChange
+}
+
+func NewEquipmentAggregate(id uuid.UUID) *EquipmentAggregate {
+	return &EquipmentAggregate{State: Equipment{Id: id}}
+}
+
+// Exists reports whether the equipment has been registered and has not been removed yet.
+func (aggregate *EquipmentAggregate) Exists() bool {
+	return aggregate.Registered && !aggregate.Removed
+}
+
+// Changes returns the events raised since the aggregate has been loaded.
+func (aggregate *EquipmentAggregate) Changes() []AggregateChange {
+	return aggregate.changes
+}
+
+func (aggregate *EquipmentAggregate) Apply(event *Event) error {
+	if event.Version != aggregate.Version + 1 {
+		return fmt.Errorf("Event #%d of equipment #%v is applied to version %d", event.Version, aggregate.State.Id, aggregate.Version)
+	}
+	switch event.Type {
+		case EquipmentRegistered:
+			var payload EquipmentRegisteredPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return err
+			}
+			aggregate.Registered = true
+			aggregate.State.Kind = payload.Kind
+			aggregate.State.Status = payload.Status
+			aggregate.State.Parameters = payload.Parameters
+			aggregate.State.CreatedAt = event.OccurredAt
+		case StatusChanged:
+			var payload StatusChangedPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return err
+			}
+			aggregate.State.Status = payload.To
+		case ParametersChanged:
+			var payload ParametersChangedPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return err
+			}
+			aggregate.State.Parameters = payload.Parameters
+		case EquipmentRevised:
+			var payload EquipmentRevisedPayload
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return err
+			}
+			aggregate.State.Status = payload.Status
+			aggregate.State.Parameters = payload.Parameters
+		case EquipmentRemoved:
+			aggregate.Removed = true
+		default:
+			return fmt.Errorf("Unknown event type: `%s`", event.Type)
+	}
+	aggregate.State.UpdatedAt = event.OccurredAt
+	aggregate.Version = event.Version
+	return nil
+}
+
+func (aggregate *EquipmentAggregate) raise(eventType EventType, payload interface{}, at time.Time) error {
+	jsonifiedPayload, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	event := Event {
+		AggregateId:	aggregate.State.Id,
+		Version:		aggregate.Version + 1,
+		Type:			eventType,
+		Payload:		jsonifiedPayload,
+		OccurredAt:		at,
+	}
+	previous := aggregate.State
+	if err = aggregate.Apply(&event); err != nil {
+		return err
+	}
+	aggregate.changes = append(aggregate.changes, AggregateChange{Event: event, State: aggregate.State, Previous: previous})
+	return nil
+}
+
+func (aggregate *EquipmentAggregate) Register(kind EquipmentKind, parameters []byte, at time.Time) error {
+	if aggregate.Registered {
+		return fmt.Errorf("%w: equipment #%v is already registered", ErrConflict, aggregate.State.Id)
+	}
+	return aggregate.raise(EquipmentRegistered, EquipmentRegisteredPayload {
+		Kind:		kind,
+		Status:		Operational,	// Default status on creation
+		Parameters:	parameters,
+	}, at)
+}
+
+// ChangeStatus raises no event if the status is the same.
+func (aggregate *EquipmentAggregate) ChangeStatus(status OperationalStatus, reason, actor string, at time.Time) error {
+	if !aggregate.Exists() {
+		return fmt.Errorf("Equipment #%v does not exist", aggregate.State.Id)
+	}
+	if status == aggregate.State.Status {
+		return nil
+	}
+	return aggregate.raise(StatusChanged, StatusChangedPayload {
+		From:	aggregate.State.Status,
+		To:		status,
+		Reason:	reason,
+		Actor:	actor,
+	}, at)
+}
+
+func (aggregate *EquipmentAggregate) ChangeParameters(parameters []byte, at time.Time) error {
+	if !aggregate.Exists() {
+		return fmt.Errorf("Equipment #%v does not exist", aggregate.State.Id)
+	}
+	return aggregate.raise(ParametersChanged, ParametersChangedPayload{Parameters: parameters}, at)
+}
+
+func (aggregate *EquipmentAggregate) Remove(at time.Time) error {
+	if !aggregate.Exists() {
+		return fmt.Errorf("Equipment #%v does not exist", aggregate.State.Id)
+	}
+	return aggregate.raise(EquipmentRemoved, EquipmentRemovedPayload{}, at)
+}
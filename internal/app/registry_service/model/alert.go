@@ -0,0 +1,128 @@
+package model
+
+import (
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+type AlertOperator string
+const (
+	Above AlertOperator = ">"
+	AboveOrEqual AlertOperator = ">="
+	Below AlertOperator = "<"
+	BelowOrEqual AlertOperator = "<="
+)
+
+func (operator AlertOperator) IsValid() bool {
+	switch operator {
+		case Above, AboveOrEqual, Below, BelowOrEqual:
+			return true
+	}
+	return false
+}
+
+// AlertRule is the threshold condition on the metric of the equipment of the kind, e.g. `spindle_temp > 85 for 2m`;
+// the condition is breached while `value operator threshold`, the alert is resolved once the value gets
+// beyond the threshold by Hysteresis in the opposite direction. The rule applies only while the equipment has WhileStatus, if set.
+type AlertRule struct {
+	Id			int64				`db:"id"`
+	Kind		EquipmentKind		`db:"kind"`
+	Name		string				`db:"name"`
+	Metric		string				`db:"metric"`
+	Operator	AlertOperator		`db:"operator"`
+	Threshold	float64				`db:"threshold"`
+	ForSeconds	int64				`db:"for_seconds"`	// How long the condition must hold before firing
+	Hysteresis	float64				`db:"hysteresis"`
+	WhileStatus	*OperationalStatus	`db:"while_status"`
+	Active		bool				`db:"active"`
+	CreatedAt	time.Time			`db:"created_at"`
+	UpdatedAt	time.Time			`db:"updated_at"`
+}
+
+type AlertState string
+const (
+	AlertPending AlertState = "pending"
+	AlertFiring AlertState = "firing"
+	AlertResolved AlertState = "resolved"
+	AlertInactive AlertState = "inactive"	// Pending alert whose condition cleared before firing; it is not stored
+)
+
+func (state AlertState) IsValid() bool {
+	return state == AlertPending || state == AlertFiring || state == AlertResolved
+}
+
+// IsOpen reports whether the alert is still evaluated by new readings.
+func (state AlertState) IsOpen() bool {
+	return state == AlertPending || state == AlertFiring
+}
+
+type Alert struct {
+	Id			int64			`db:"id"`	// 0 until stored
+	RuleId		int64			`db:"rule_id"`
+	EquipmentId	uuid.UUID		`db:"equipment_id"`
+	Kind		EquipmentKind	`db:"kind"`
+	Metric		string			`db:"metric"`
+	State		AlertState		`db:"state"`
+	Value		float64			`db:"value"`	// The last value evaluated
+	StartedAt	time.Time		`db:"started_at"`
+	FiredAt		*time.Time		`db:"fired_at"`
+	ResolvedAt	*time.Time		`db:"resolved_at"`
+	UpdatedAt	time.Time		`db:"updated_at"`
+}
+
+func (rule *AlertRule) breached(value float64) bool {
+	switch rule.Operator {
+		case Above:			return value > rule.Threshold
+		case AboveOrEqual:	return value >= rule.Threshold
+		case Below:			return value < rule.Threshold
+		case BelowOrEqual:	return value <= rule.Threshold
+	}
+	return false
+}
+
+// cleared reports whether the value is beyond the threshold by the hysteresis in the direction opposite to the condition.
+func (rule *AlertRule) cleared(value float64) bool {
+	if rule.Operator == Above || rule.Operator == AboveOrEqual {
+		return value < rule.Threshold - rule.Hysteresis || rule.Hysteresis == 0 && !rule.breached(value)
+	}
+	return value > rule.Threshold + rule.Hysteresis || rule.Hysteresis == 0 && !rule.breached(value)
+}
+
+// Evaluate advances the open alert of the rule for the equipment (nil if there is none) by the reading
+// of the equipment having status and returns the alert after it (nil if there is still none):
+// the breach opens a pending alert which fires once the breach lasts ForSeconds; the pending alert becomes inactive
+// and the firing one resolved when the value clears or the rule stops applying. Readings older than the last evaluated are ignored.
+func (rule *AlertRule) Evaluate(alert *Alert, equipmentId uuid.UUID, reading *Reading, status OperationalStatus) *Alert {
+	applies := rule.WhileStatus == nil || *rule.WhileStatus == status
+	if alert == nil {
+		if !applies || !rule.breached(reading.Value) {
+			return nil
+		}
+		alert = &Alert {
+			RuleId:			rule.Id,
+			EquipmentId:	equipmentId,
+			Kind:			rule.Kind,
+			Metric:			rule.Metric,
+			State:			AlertPending,
+			StartedAt:		reading.RecordedAt,
+		}
+	} else if reading.RecordedAt.Before(alert.UpdatedAt) {
+		return alert
+	} else if !applies || rule.cleared(reading.Value) {
+		alert.Value, alert.UpdatedAt = reading.Value, reading.RecordedAt
+		if alert.State == AlertFiring {
+			resolvedAt := reading.RecordedAt
+			alert.State, alert.ResolvedAt = AlertResolved, &resolvedAt
+		} else {
+			alert.State = AlertInactive
+		}
+		return alert
+	}
+	alert.Value, alert.UpdatedAt = reading.Value, reading.RecordedAt
+	if alert.State == AlertPending && rule.breached(reading.Value) &&
+		reading.RecordedAt.Sub(alert.StartedAt) >= time.Duration(rule.ForSeconds) * time.Second {
+		firedAt := reading.RecordedAt
+		alert.State, alert.FiredAt = AlertFiring, &firedAt
+	}
+	return alert
+}
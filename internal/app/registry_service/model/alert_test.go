@@ -0,0 +1,133 @@
+package model
+
+import (
+	"testing"
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+func TestAlertRuleBreached(t *testing.T) {
+	for _, test := range []struct {
+		operator	AlertOperator
+		value		float64
+		breached	bool
+	}{
+		{Above, 10.5, true},
+		{Above, 10, false},
+		{Above, 9.5, false},
+		{AboveOrEqual, 10, true},
+		{AboveOrEqual, 9.5, false},
+		{Below, 9.5, true},
+		{Below, 10, false},
+		{BelowOrEqual, 10, true},
+		{BelowOrEqual, 10.5, false},
+	} {
+		rule := AlertRule{Operator: test.operator, Threshold: 10}
+		if breached := rule.breached(test.value); breached != test.breached {
+			t.Errorf("%v %s 10: breached %v, expected %v", test.value, test.operator, breached, test.breached)
+		}
+	}
+}
+
+func TestAlertRuleCleared(t *testing.T) {
+	for _, test := range []struct {
+		operator	AlertOperator
+		hysteresis	float64
+		value		float64
+		cleared		bool
+	}{
+		// The value must get beyond the threshold by the hysteresis, the boundary itself is not enough
+		{Above, 2, 7.5, true},
+		{Above, 2, 8, false},
+		{Above, 2, 9, false},
+		{Above, 2, 11, false},
+		{AboveOrEqual, 2, 7.5, true},
+		{AboveOrEqual, 2, 8, false},
+		{Below, 2, 12.5, true},
+		{Below, 2, 12, false},
+		{Below, 2, 11, false},
+		{BelowOrEqual, 2, 12.5, true},
+		{BelowOrEqual, 2, 12, false},
+		// Without the hysteresis any value not breaching the threshold clears
+		{Above, 0, 10, true},
+		{Above, 0, 10.5, false},
+		{AboveOrEqual, 0, 10, false},
+		{AboveOrEqual, 0, 9.5, true},
+		{Below, 0, 10, true},
+		{Below, 0, 9.5, false},
+		{BelowOrEqual, 0, 10, false},
+		{BelowOrEqual, 0, 10.5, true},
+	} {
+		rule := AlertRule{Operator: test.operator, Threshold: 10, Hysteresis: test.hysteresis}
+		if cleared := rule.cleared(test.value); cleared != test.cleared {
+			t.Errorf("%v %s 10 with hysteresis %v: cleared %v, expected %v", test.value, test.operator, test.hysteresis, cleared, test.cleared)
+		}
+	}
+}
+
+func TestAlertRuleEvaluate(t *testing.T) {
+	equipmentId := uuid.Must(uuid.NewV4())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return start.Add(time.Duration(seconds) * time.Second)
+	}
+	operational := Operational
+	rule := AlertRule {
+		Id:				1,
+		Metric:			"spindle_temp",
+		Operator:		Above,
+		Threshold:		85,
+		ForSeconds:		60,
+		Hysteresis:		5,
+		WhileStatus:	&operational,
+	}
+	alert := func(state AlertState, updatedAt int) *Alert {
+		return &Alert{RuleId: rule.Id, EquipmentId: equipmentId, Metric: rule.Metric, State: state, StartedAt: start, UpdatedAt: at(updatedAt)}
+	}
+	for _, test := range []struct {
+		name		string
+		alert		*Alert
+		value		float64
+		at			int
+		status		OperationalStatus
+		expected	AlertState	// Empty for no alert
+	}{
+		{"no breach", nil, 85, 0, Operational, ""},
+		{"breach opens", nil, 86, 0, Operational, AlertPending},
+		{"breach of another status", nil, 86, 0, UnderMaintenance, ""},
+		{"breach shorter than for", alert(AlertPending, 0), 86, 59, Operational, AlertPending},
+		{"breach lasting for", alert(AlertPending, 0), 86, 60, Operational, AlertFiring},
+		{"within hysteresis does not fire", alert(AlertPending, 0), 83, 60, Operational, AlertPending},
+		{"within hysteresis keeps firing", alert(AlertFiring, 60), 80, 90, Operational, AlertFiring},
+		{"clear drops pending", alert(AlertPending, 0), 79, 30, Operational, AlertInactive},
+		{"clear resolves firing", alert(AlertFiring, 60), 79, 90, Operational, AlertResolved},
+		{"status change resolves firing", alert(AlertFiring, 60), 90, 90, UnderMaintenance, AlertResolved},
+		{"older reading is ignored", alert(AlertFiring, 60), 70, 30, Operational, AlertFiring},
+	} {
+		reading := Reading{EquipmentId: equipmentId, Metric: rule.Metric, Value: test.value, RecordedAt: at(test.at)}
+		wasPending := test.alert != nil && test.alert.State == AlertPending
+		evaluated := rule.Evaluate(test.alert, equipmentId, &reading, test.status)
+		var state AlertState
+		if evaluated != nil {
+			state = evaluated.State
+		}
+		if state != test.expected {
+			t.Errorf("%s: state `%s`, expected `%s`", test.name, state, test.expected)
+			continue
+		}
+		switch state {
+			case AlertPending:
+				if test.alert == nil && !evaluated.StartedAt.Equal(reading.RecordedAt) {
+					t.Errorf("%s: started at %v, expected %v", test.name, evaluated.StartedAt, reading.RecordedAt)
+				}
+			case AlertFiring:
+				if wasPending && (evaluated.FiredAt == nil || !evaluated.FiredAt.Equal(reading.RecordedAt)) {
+					t.Errorf("%s: fired at %v, expected %v", test.name, evaluated.FiredAt, reading.RecordedAt)
+				}
+			case AlertResolved:
+				if evaluated.ResolvedAt == nil || !evaluated.ResolvedAt.Equal(reading.RecordedAt) {
+					t.Errorf("%s: resolved at %v, expected %v", test.name, evaluated.ResolvedAt, reading.RecordedAt)
+				}
+		}
+	}
+}
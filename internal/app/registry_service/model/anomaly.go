@@ -0,0 +1,124 @@
+package model
+
+import (
+	"math"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+)
+
+type DetectorMethod string
+const (
+	EWMA DetectorMethod = "ewma"		// Exponentially weighted moving mean and variance
+	ZScore DetectorMethod = "zscore"	// Mean and variance of the rolling window of the last readings
+)
+
+func (method DetectorMethod) IsValid() bool {
+	return method == EWMA || method == ZScore
+}
+
+// AnomalyDetector scores each reading of the metric of the equipment of the kind against the baseline
+// of the previous readings of the same equipment: the score is the deviation from the baseline mean in standard deviations.
+// The reading is anomalous if the absolute score reaches Threshold; nothing is scored until the baseline has Warmup readings.
+type AnomalyDetector struct {
+	Id			int64			`db:"id"`
+	Kind		EquipmentKind	`db:"kind"`
+	Metric		string			`db:"metric"`
+	Method		DetectorMethod	`db:"method"`
+	Alpha		float64			`db:"alpha"`		// Smoothing factor of EWMA
+	Window		int				`db:"window_size"`	// Readings in the window of ZScore
+	Threshold	float64			`db:"threshold"`
+	Warmup		int				`db:"warmup"`
+	Active		bool			`db:"active"`
+	CreatedAt	time.Time		`db:"created_at"`
+	UpdatedAt	time.Time		`db:"updated_at"`
+}
+
+// DetectorState is the baseline of the detector for the equipment, so the readings are never read back.
+type DetectorState struct {
+	DetectorId		int64			`db:"detector_id"`
+	EquipmentId		uuid.UUID		`db:"equipment_id"`
+	Count			int64			`db:"count"`		// Readings taken into the baseline
+	Mean			float64			`db:"mean"`
+	Variance		float64			`db:"variance"`
+	Recent			pq.Float64Array	`db:"recent"`		// The window of ZScore, the oldest reading first
+	LastRecordedAt	time.Time		`db:"last_recorded_at"`
+}
+
+type Anomaly struct {
+	Id			int64			`db:"id"`	// 0 until stored
+	DetectorId	int64			`db:"detector_id"`
+	EquipmentId	uuid.UUID		`db:"equipment_id"`
+	Kind		EquipmentKind	`db:"kind"`
+	Metric		string			`db:"metric"`
+	Method		DetectorMethod	`db:"method"`
+	Value		float64			`db:"value"`
+	Expected	float64			`db:"expected"`	// The baseline mean
+	Score		float64			`db:"score"`
+	RecordedAt	time.Time		`db:"recorded_at"`
+	DetectedAt	time.Time		`db:"detected_at"`
+}
+
+// baseline returns the mean and the variance the reading is scored against and the number of readings behind them.
+func (detector *AnomalyDetector) baseline(state *DetectorState) (float64, float64, int64) {
+	if detector.Method == EWMA {
+		return state.Mean, state.Variance, state.Count
+	}
+	var mean, variance float64
+	for _, value := range state.Recent {
+		mean += value
+	}
+	mean /= float64(max(len(state.Recent), 1))
+	for _, value := range state.Recent {
+		variance += (value - mean) * (value - mean)
+	}
+	variance /= float64(max(len(state.Recent), 1))
+	return mean, variance, int64(len(state.Recent))
+}
+
+// learn takes the value into the baseline.
+func (detector *AnomalyDetector) learn(state *DetectorState, value float64) {
+	state.Count++
+	if detector.Method == ZScore {
+		state.Recent = append(state.Recent, value)
+		if len(state.Recent) > detector.Window {
+			state.Recent = append(pq.Float64Array(nil), state.Recent[len(state.Recent) - detector.Window:]...)
+		}
+		state.Mean, state.Variance, _ = detector.baseline(state)
+	} else if state.Count == 1 {
+		state.Mean, state.Variance = value, 0
+	} else {
+		diff := value - state.Mean
+		increment := detector.Alpha * diff
+		state.Mean += increment
+		state.Variance = (1 - detector.Alpha) * (state.Variance + diff * increment)
+	}
+}
+
+// Detect scores the reading against the state of the detector for the equipment (the zero one for the first reading)
+// and then takes the reading into the state; returns the anomaly if the reading is anomalous.
+// Readings older than the last one taken are ignored.
+func (detector *AnomalyDetector) Detect(state *DetectorState, reading *Reading) *Anomaly {
+	if state.Count > 0 && reading.RecordedAt.Before(state.LastRecordedAt) {
+		return nil
+	}
+	var anomaly *Anomaly
+	if mean, variance, count := detector.baseline(state); count >= int64(detector.Warmup) && variance > 0 {
+		if score := (reading.Value - mean) / math.Sqrt(variance); math.Abs(score) >= detector.Threshold {
+			anomaly = &Anomaly {
+				DetectorId:		detector.Id,
+				EquipmentId:	reading.EquipmentId,
+				Kind:			detector.Kind,
+				Metric:			detector.Metric,
+				Method:			detector.Method,
+				Value:			reading.Value,
+				Expected:		mean,
+				Score:			score,
+				RecordedAt:		reading.RecordedAt,
+			}
+		}
+	}
+	detector.learn(state, reading.Value)
+	state.LastRecordedAt = reading.RecordedAt
+	return anomaly
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+const epsilon = 1e-9
+
+func TestAnomalyDetectorLearn(t *testing.T) {
+	for _, test := range []struct {
+		name		string
+		detector	AnomalyDetector
+		values		[]float64
+		mean		float64
+		variance	float64
+		recent		[]float64
+	}{
+		{"ewma first reading", AnomalyDetector{Method: EWMA, Alpha: 0.5}, []float64{10}, 10, 0, nil},
+		// mean 10 + 0.5*2 = 11, variance 0.5*(0 + 2*1) = 1
+		{"ewma second reading", AnomalyDetector{Method: EWMA, Alpha: 0.5}, []float64{10, 12}, 11, 1, nil},
+		// mean 11 + 0.5*(-3) = 9.5, variance 0.5*(1 + (-3)*(-1.5)) = 2.75
+		{"ewma third reading", AnomalyDetector{Method: EWMA, Alpha: 0.5}, []float64{10, 12, 8}, 9.5, 2.75, nil},
+		{"ewma alpha 1 follows the last reading", AnomalyDetector{Method: EWMA, Alpha: 1}, []float64{10, 12, 8}, 8, 0, nil},
+		{"zscore partial window", AnomalyDetector{Method: ZScore, Window: 3}, []float64{1, 3}, 2, 1, []float64{1, 3}},
+		// The oldest reading leaves the window: mean of 2, 3, 4 is 3, population variance (1 + 0 + 1)/3
+		{"zscore full window", AnomalyDetector{Method: ZScore, Window: 3}, []float64{1, 2, 3, 4}, 3, 2.0 / 3, []float64{2, 3, 4}},
+	} {
+		var state DetectorState
+		for _, value := range test.values {
+			test.detector.learn(&state, value)
+		}
+		if state.Count != int64(len(test.values)) {
+			t.Errorf("%s: count %d, expected %d", test.name, state.Count, len(test.values))
+		}
+		if math.Abs(state.Mean - test.mean) > epsilon || math.Abs(state.Variance - test.variance) > epsilon {
+			t.Errorf("%s: mean %v and variance %v, expected %v and %v", test.name, state.Mean, state.Variance, test.mean, test.variance)
+		}
+		if !slices.Equal([]float64(state.Recent), test.recent) {
+			t.Errorf("%s: window %v, expected %v", test.name, state.Recent, test.recent)
+		}
+	}
+}
+
+func TestAnomalyDetectorDetect(t *testing.T) {
+	equipmentId := uuid.Must(uuid.NewV4())
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// The window of 2, 4, 4, 4, 5, 5, 7, 9 has the mean 5 and the standard deviation 2
+	window := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	for _, test := range []struct {
+		name		string
+		detector	AnomalyDetector
+		values		[]float64	// Taken into the baseline before the reading
+		value		float64
+		score		float64		// 0 for no anomaly
+	}{
+		// The baseline of 10, 12 has the mean 11 and the variance 1
+		{"ewma within threshold", AnomalyDetector{Method: EWMA, Alpha: 0.5, Threshold: 3, Warmup: 2}, []float64{10, 12}, 13.9, 0},
+		{"ewma threshold above", AnomalyDetector{Method: EWMA, Alpha: 0.5, Threshold: 3, Warmup: 2}, []float64{10, 12}, 14, 3},
+		{"ewma threshold below", AnomalyDetector{Method: EWMA, Alpha: 0.5, Threshold: 3, Warmup: 2}, []float64{10, 12}, 8, -3},
+		{"ewma during warmup", AnomalyDetector{Method: EWMA, Alpha: 0.5, Threshold: 3, Warmup: 3}, []float64{10, 12}, 100, 0},
+		{"ewma zero variance", AnomalyDetector{Method: EWMA, Alpha: 0.5, Threshold: 3}, []float64{10, 10, 10}, 100, 0},
+		{"zscore within threshold", AnomalyDetector{Method: ZScore, Window: 8, Threshold: 3, Warmup: 8}, window, 10, 0},
+		{"zscore threshold above", AnomalyDetector{Method: ZScore, Window: 8, Threshold: 3, Warmup: 8}, window, 11, 3},
+		{"zscore threshold below", AnomalyDetector{Method: ZScore, Window: 8, Threshold: 3, Warmup: 8}, window, -1, -3},
+		{"zscore during warmup", AnomalyDetector{Method: ZScore, Window: 8, Threshold: 3, Warmup: 9}, window, 100, 0},
+	} {
+		var state DetectorState
+		for i, value := range test.values {
+			test.detector.Detect(&state, &Reading{EquipmentId: equipmentId, Value: value, RecordedAt: start.Add(time.Duration(i) * time.Second)})
+		}
+		reading := Reading{EquipmentId: equipmentId, Value: test.value, RecordedAt: start.Add(time.Hour)}
+		anomaly := test.detector.Detect(&state, &reading)
+		switch {
+			case test.score == 0 && anomaly != nil:
+				t.Errorf("%s: anomaly with score %v, expected none", test.name, anomaly.Score)
+			case test.score != 0 && anomaly == nil:
+				t.Errorf("%s: no anomaly, expected score %v", test.name, test.score)
+			case anomaly != nil && math.Abs(anomaly.Score - test.score) > epsilon:
+				t.Errorf("%s: score %v, expected %v", test.name, anomaly.Score, test.score)
+		}
+		if state.Count != int64(len(test.values) + 1) || !state.LastRecordedAt.Equal(reading.RecordedAt) {
+			t.Errorf("%s: the reading is not taken into the baseline", test.name)
+		}
+	}
+}
+
+func TestAnomalyDetectorDetectIgnoresOlderReading(t *testing.T) {
+	detector := AnomalyDetector{Method: EWMA, Alpha: 0.5, Threshold: 1}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var state DetectorState
+	detector.Detect(&state, &Reading{Value: 10, RecordedAt: start})
+	detector.Detect(&state, &Reading{Value: 12, RecordedAt: start.Add(time.Minute)})
+	expected := state
+	if anomaly := detector.Detect(&state, &Reading{Value: 100, RecordedAt: start.Add(time.Second)}); anomaly != nil {
+		t.Errorf("older reading: anomaly with score %v, expected none", anomaly.Score)
+	}
+	if state.Count != expected.Count || state.Mean != expected.Mean || state.Variance != expected.Variance {
+		t.Errorf("older reading: baseline %+v, expected %+v", state, expected)
+	}
+}
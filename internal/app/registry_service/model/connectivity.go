@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+// Connectivity is tracked separately from the operational status by heartbeats and readings of the equipment.
+type Connectivity string
+const (
+	Unknown Connectivity = "unknown"	// Never seen
+	Online Connectivity = "online"
+	Unreachable Connectivity = "unreachable"	// Not seen for the expected heartbeat interval of the kind twice
+)
+
+func (connectivity Connectivity) IsValid() bool {
+	return connectivity == Unknown || connectivity == Online || connectivity == Unreachable
+}
+
+type ConnectivityChange struct {
+	EquipmentId	uuid.UUID		`db:"equipment_id"`
+	State		Connectivity	`db:"state"`
+	LastSeenAt	time.Time		`db:"last_seen_at"`
+}
@@ -1,11 +1,14 @@
 package model
 
 import (
+	"strconv"
 	"strings"
 	"time"
 	"github.com/gofrs/uuid"
 )
 
+// EquipmentKind refers to a row of the kind catalog (see Kind);
+// the constants are identifiers of the kinds seeded on the catalog creation.
 type EquipmentKind int16
 const (
 	CNCMachine EquipmentKind = iota
@@ -14,23 +17,40 @@ const (
 	RoboticArm
 )
 
+// IsValid reports whether the kind may refer to the catalog; whether it does is checked against the catalog.
 func (kind EquipmentKind) IsValid() bool {
-	return kind >= CNCMachine && kind <= RoboticArm
+	return kind >= CNCMachine
 }
 
+// String is the name of the kind in the catalog if it is in use (see UseKindCatalog), the seeded name otherwise.
 func (kind EquipmentKind) String() string {
+	if kindCatalog != nil {
+		if name, found := kindCatalog.KindName(kind); found {
+			return name
+		}
+		return strconv.Itoa(int(kind))
+	}
 	switch kind {
 		case CNCMachine:	return "CNCMachine"
 		case ConveyorBelt:	return "ConveyorBelt"
 		case DrillMachine:	return "DrillMachine"
 		case RoboticArm:	return "RoboticArm"
 	}
-	return "";
-} 
+	return strconv.Itoa(int(kind))
+}
 
 
+// ParseEquipmentKind resolves the case-insensitive name of the kind by the catalog if it is in use (see UseKindCatalog),
+// among the seeded kinds otherwise; nil if there is no such kind.
 func ParseEquipmentKind(str string) *EquipmentKind {
 	var kind EquipmentKind
+	if kindCatalog != nil {
+		var found bool
+		if kind, found = kindCatalog.KindByName(str); !found {
+			return nil
+		}
+		return &kind
+	}
 	switch strings.ToLower(str) {
 		case "cncmachine":		kind = CNCMachine
 		case "conveyorbelt":	kind = ConveyorBelt
@@ -75,12 +95,15 @@ func ParseOperationalStatus(str string) *OperationalStatus {
 
 
 type Equipment struct {
-	Id			uuid.UUID			`db:"id,pk"								json:"equipment_id"`
-	Kind		EquipmentKind		`db:"kind,not null,type:smallserial"	json:"kind"`
-	Status		OperationalStatus	`db:"status,not null,type:smallserial"	json:"status"`
-	Parameters	[]byte				`db:"parameters,not null,type:jsonb"	json:"parameters"`
-	CreatedAt	time.Time			`db:"created_at,not null"				json:"created_at"`
-	UpdatedAt	time.Time			`db:"updated_at,not null"				json:"updated_at"`
+	Id				uuid.UUID			`db:"id,pk" json:"equipment_id"`
+	Kind			EquipmentKind		`db:"kind,not null,type:smallserial" json:"kind"`
+	Status			OperationalStatus	`db:"status,not null,type:smallserial" json:"status"`
+	Parameters		[]byte				`db:"parameters,not null,type:jsonb" json:"parameters"`
+	CreatedAt		time.Time			`db:"created_at,not null" json:"created_at"`
+	UpdatedAt		time.Time			`db:"updated_at,not null" json:"updated_at"`
+	// Joined from the connectivity table, not a part of the event-sourced state
+	LastSeenAt		*time.Time			`db:"last_seen_at" json:"last_seen_at"`
+	Connectivity	*Connectivity		`db:"connectivity" json:"connectivity"`
 }
 
 func NewEquipment(kind EquipmentKind, parameters []byte) Equipment {
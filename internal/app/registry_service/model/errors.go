@@ -0,0 +1,8 @@
+package model
+
+import "errors"
+
+var (
+	// ErrConflict is wrapped by errors caused by violating uniqueness or references of stored entities.
+	ErrConflict = errors.New("Conflict")
+)
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+type EventType string
+const (
+	EquipmentRegistered EventType = "EquipmentRegistered"
+	StatusChanged EventType = "StatusChanged"
+	ParametersChanged EventType = "ParametersChanged"
+	EquipmentRemoved EventType = "EquipmentRemoved"
+	EquipmentRevised EventType = "EquipmentRevised"	// The revision recorded before the events were stored
+)
+
+// Event is a stored fact about the equipment aggregate; Version numbers events of the aggregate from 1
+// while Position orders all the events in the store.
+type Event struct {
+	Position	int64		`db:"position"`
+	AggregateId	uuid.UUID	`db:"aggregate_id"`
+	Version		int			`db:"version"`
+	Type		EventType	`db:"type"`
+	Payload		[]byte		`db:"payload"`
+	OccurredAt	time.Time	`db:"occurred_at"`
+}
+
+type EquipmentRegisteredPayload struct {
+	Kind		EquipmentKind		`json:"kind"`
+	Status		OperationalStatus	`json:"status"`
+	Parameters	json.RawMessage		`json:"parameters"`
+}
+
+type StatusChangedPayload struct {
+	From	OperationalStatus	`json:"from"`
+	To		OperationalStatus	`json:"to"`
+	Reason	string				`json:"reason,omitempty"`
+	Actor	string				`json:"actor,omitempty"`
+}
+
+type ParametersChangedPayload struct {
+	Parameters	json.RawMessage	`json:"parameters"`
+}
+
+type EquipmentRemovedPayload struct {}
+
+// EquipmentRevisedPayload is the state of the revision changing the status and the parameters at once.
+type EquipmentRevisedPayload struct {
+	From		OperationalStatus	`json:"from"`
+	Status		OperationalStatus	`json:"status"`
+	Parameters	json.RawMessage		`json:"parameters"`
+	Reason		string				`json:"reason,omitempty"`
+	Actor		string				`json:"actor,omitempty"`
+}
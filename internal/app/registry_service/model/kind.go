@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// Kind is an entry of the equipment kind catalog.
+type Kind struct {
+	Id				EquipmentKind	`db:"id"`
+	Name			string			`db:"name"`
+	Description		string			`db:"description"`
+	ParameterSchema	[]byte			`db:"parameter_schema"`
+	Icon			string			`db:"icon"`
+	Metrics			[]byte			`db:"metrics"`	// JSON array of Metric
+	HeartbeatInterval	int64		`db:"heartbeat_interval_seconds"`	// Expected interval between heartbeats
+	CreatedAt		time.Time		`db:"created_at"`
+	UpdatedAt		time.Time		`db:"updated_at"`
+}
+
+// KindCatalog resolves the names of the kinds of the catalog, including the ones added at runtime.
+type KindCatalog interface {
+	KindName(kind EquipmentKind) (string, bool)
+	KindByName(name string) (EquipmentKind, bool)	// The name is case-insensitive
+}
+
+var kindCatalog KindCatalog
+
+// UseKindCatalog makes ParseEquipmentKind and EquipmentKind.String resolve the names by the catalog instead of the seeded names.
+func UseKindCatalog(catalog KindCatalog) {
+	kindCatalog = catalog
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+type MaintenanceTrigger string
+const (
+	CalendarTrigger MaintenanceTrigger = "calendar"				// Every Interval
+	OperatingHoursTrigger MaintenanceTrigger = "operating_hours"	// Every Threshold hours of being operational
+	CounterTrigger MaintenanceTrigger = "counter"				// Every Threshold of the sum of the readings of Metric
+)
+
+func (trigger MaintenanceTrigger) IsValid() bool {
+	return trigger == CalendarTrigger || trigger == OperatingHoursTrigger || trigger == CounterTrigger
+}
+
+// MaintenancePlan schedules preventive maintenance of the equipment or of all the equipment of the kind;
+// the maintenance is due once the trigger fires since the last maintenance.
+type MaintenancePlan struct {
+	Id				int64				`db:"id"`
+	Name			string				`db:"name"`
+	EquipmentId		*uuid.UUID			`db:"equipment_id"`
+	Kind			*EquipmentKind		`db:"kind"`
+	Trigger			MaintenanceTrigger	`db:"trigger_type"`
+	IntervalSeconds	int64				`db:"interval_seconds"`	// CalendarTrigger only
+	Threshold		float64				`db:"threshold"`			// Except CalendarTrigger
+	Metric			string				`db:"metric"`			// CounterTrigger only
+	Active			bool				`db:"active"`
+	CreatedAt		time.Time			`db:"created_at"`
+	UpdatedAt		time.Time			`db:"updated_at"`
+}
+
+// MaintenanceDue is the schedule of the plan for the equipment.
+type MaintenanceDue struct {
+	PlanId		int64		`db:"plan_id"`
+	EquipmentId	uuid.UUID	`db:"equipment_id"`
+	LastDoneAt	time.Time	`db:"last_done_at"`	// The last maintenance or the registration
+	Usage		*float64	`db:"usage"`			// Hours or the counter since LastDoneAt, nil for CalendarTrigger
+	DueAt		*time.Time	`db:"due_at"`			// Nil until the usage is known
+	ComputedAt	time.Time	`db:"computed_at"`
+}
+
+// Due returns the schedule of the plan for the equipment last maintained at lastDone having the usage since then;
+// the due date of the usage triggers is projected at the average rate of the usage since lastDone.
+func (plan *MaintenancePlan) Due(equipmentId uuid.UUID, lastDone, now time.Time, usage float64) *MaintenanceDue {
+	due := MaintenanceDue{PlanId: plan.Id, EquipmentId: equipmentId, LastDoneAt: lastDone, ComputedAt: now}
+	if plan.Trigger == CalendarTrigger {
+		dueAt := lastDone.Add(time.Duration(plan.IntervalSeconds) * time.Second)
+		due.DueAt = &dueAt
+		return &due
+	}
+	due.Usage = &usage
+	if elapsed := now.Sub(lastDone); usage > 0 && elapsed > 0 {
+		dueAt := lastDone.Add(time.Duration(float64(elapsed) * plan.Threshold / usage))
+		due.DueAt = &dueAt
+	}
+	return &due
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+func TestMaintenancePlanDue(t *testing.T) {
+	equipmentId := uuid.Must(uuid.NewV4())
+	lastDone := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	calendar := MaintenancePlan{Id: 1, Trigger: CalendarTrigger, IntervalSeconds: int64(30 * day / time.Second)}
+	operatingHours := MaintenancePlan{Id: 2, Trigger: OperatingHoursTrigger, Threshold: 500}
+	counter := MaintenancePlan{Id: 3, Trigger: CounterTrigger, Threshold: 1000000, Metric: "belt_cycles"}
+	for _, test := range []struct {
+		name		string
+		plan		*MaintenancePlan
+		elapsed		time.Duration	// Since lastDone
+		usage		float64
+		due			time.Duration	// Since lastDone, 0 if the due date is unknown
+	}{
+		{"calendar", &calendar, 10 * day, 0, 30 * day},
+		{"calendar overdue", &calendar, 45 * day, 0, 30 * day},
+		// Half an hour of operation an hour gets 500 hours in 1000 hours
+		{"operating hours", &operatingHours, 250 * time.Hour, 125, 1000 * time.Hour},
+		{"operating hours at the threshold", &operatingHours, 600 * time.Hour, 500, 600 * time.Hour},
+		{"operating hours overdue", &operatingHours, 300 * time.Hour, 600, 250 * time.Hour},
+		{"operating hours without operation", &operatingHours, 250 * time.Hour, 0, 0},
+		{"operating hours just done", &operatingHours, 0, 10, 0},
+		// 250000 cycles in 10 days get 1M cycles in 40 days
+		{"counter", &counter, 10 * day, 250000, 40 * day},
+		{"counter overdue", &counter, 10 * day, 2000000, 5 * day},
+		{"counter without readings", &counter, 10 * day, 0, 0},
+	} {
+		now := lastDone.Add(test.elapsed)
+		due := test.plan.Due(equipmentId, lastDone, now, test.usage)
+		if due.PlanId != test.plan.Id || due.EquipmentId != equipmentId || !due.LastDoneAt.Equal(lastDone) || !due.ComputedAt.Equal(now) {
+			t.Errorf("%s: schedule %+v of another plan, equipment or time", test.name, due)
+		}
+		switch {
+			case test.due == 0 && due.DueAt != nil:
+				t.Errorf("%s: due at %v, expected unknown", test.name, due.DueAt)
+			case test.due != 0 && (due.DueAt == nil || !due.DueAt.Equal(lastDone.Add(test.due))):
+				t.Errorf("%s: due at %v, expected %v", test.name, due.DueAt, lastDone.Add(test.due))
+		}
+		if test.plan.Trigger == CalendarTrigger && due.Usage != nil {
+			t.Errorf("%s: usage %v, expected none", test.name, *due.Usage)
+		} else if test.plan.Trigger != CalendarTrigger && (due.Usage == nil || *due.Usage != test.usage) {
+			t.Errorf("%s: usage %v, expected %v", test.name, due.Usage, test.usage)
+		}
+	}
+}
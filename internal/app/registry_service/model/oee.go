@@ -0,0 +1,108 @@
+package model
+
+import "time"
+
+const (
+	TotalCountMetric string = "total_count"
+	GoodCountMetric = "good_count"
+	IdealCycleTimeParameter = "ideal_cycle_time_s"
+)
+
+// ProductionCounters are the metrics OEE is computed from: each reading is the number of parts
+// (all of them and the good ones) produced since the previous reading.
+var ProductionCounters = []Metric {
+	{Name: TotalCountMetric, Unit: "pcs", Min: float(0)},
+	{Name: GoodCountMetric, Unit: "pcs", Min: float(0)},
+}
+
+// Effectiveness accumulates the components of OEE of one or more pieces of equipment over the range.
+type Effectiveness struct {
+	PlannedTime		time.Duration	// Since the registration, except while decommissioned
+	RunTime			time.Duration	// While operational
+	CycledRunTime	time.Duration	// RunTime of the equipment having the ideal cycle time
+	IdealTime		time.Duration	// The ideal cycle time by the total count
+	TotalCount		float64
+	GoodCount		float64
+}
+
+// ratio is capped at 1: the counters reported beyond the ideal cycle time or the good parts beyond the total
+// do not make the component exceed the ideal.
+func ratio(numerator, denominator float64) *float64 {
+	if denominator <= 0 {
+		return nil
+	}
+	value := min(numerator / denominator, 1)
+	return &value
+}
+
+// AddStatusHistory accumulates the planned and the run time within [from, to) given the transitions of the equipment
+// in order of time up to `to` including the last one before `from` (the first transition sets the initial status).
+func (effectiveness *Effectiveness) AddStatusHistory(transitions []Transition, from, to time.Time, idealCycleTime *float64) {
+	var runTime time.Duration
+	for i, transition := range transitions {
+		start, end := transition.CreatedAt, to
+		if i + 1 < len(transitions) {
+			end = transitions[i + 1].CreatedAt
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) || transition.ToStatus == Decommissioned {
+			continue
+		}
+		effectiveness.PlannedTime += end.Sub(start)
+		if transition.ToStatus == Operational {
+			runTime += end.Sub(start)
+		}
+	}
+	effectiveness.RunTime += runTime
+	if idealCycleTime != nil {
+		effectiveness.CycledRunTime += runTime
+	}
+}
+
+// AddCounts accumulates the production counters of the equipment having the ideal cycle time (seconds per part) if it is not nil.
+func (effectiveness *Effectiveness) AddCounts(totalCount, goodCount float64, idealCycleTime *float64) {
+	effectiveness.TotalCount += totalCount
+	effectiveness.GoodCount += goodCount
+	if idealCycleTime != nil {
+		effectiveness.IdealTime += time.Duration(*idealCycleTime * totalCount * float64(time.Second))
+	}
+}
+
+func (effectiveness *Effectiveness) Add(other *Effectiveness) {
+	effectiveness.PlannedTime += other.PlannedTime
+	effectiveness.RunTime += other.RunTime
+	effectiveness.CycledRunTime += other.CycledRunTime
+	effectiveness.IdealTime += other.IdealTime
+	effectiveness.TotalCount += other.TotalCount
+	effectiveness.GoodCount += other.GoodCount
+}
+
+// Availability is the share of the planned time the equipment was operational.
+func (effectiveness *Effectiveness) Availability() *float64 {
+	return ratio(effectiveness.RunTime.Seconds(), effectiveness.PlannedTime.Seconds())
+}
+
+// Performance is the ideal time of the produced parts relative to the run time.
+func (effectiveness *Effectiveness) Performance() *float64 {
+	return ratio(effectiveness.IdealTime.Seconds(), effectiveness.CycledRunTime.Seconds())
+}
+
+// Quality is the share of the good parts.
+func (effectiveness *Effectiveness) Quality() *float64 {
+	return ratio(effectiveness.GoodCount, effectiveness.TotalCount)
+}
+
+// OEE is the product of the components; nil unless all of them are known.
+func (effectiveness *Effectiveness) OEE() *float64 {
+	availability, performance, quality := effectiveness.Availability(), effectiveness.Performance(), effectiveness.Quality()
+	if availability == nil || performance == nil || quality == nil {
+		return nil
+	}
+	oee := *availability * *performance * *quality
+	return &oee
+}
@@ -0,0 +1,114 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEffectivenessAddStatusHistory(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+	at := func(hours int) time.Time {
+		return from.Add(time.Duration(hours) * time.Hour)
+	}
+	for _, test := range []struct {
+		name		string
+		transitions	[]Transition
+		planned		time.Duration
+		run			time.Duration
+	}{
+		{"no history", nil, 0, 0},
+		{"operational before the range", []Transition{{ToStatus: Operational, CreatedAt: at(-5)}}, 10 * time.Hour, 10 * time.Hour},
+		{"maintenance within the range", []Transition {
+			{ToStatus: Operational, CreatedAt: at(-5)},
+			{ToStatus: UnderMaintenance, CreatedAt: at(2)},
+			{ToStatus: Operational, CreatedAt: at(5)},
+		}, 10 * time.Hour, 7 * time.Hour},
+		{"under maintenance through the range", []Transition{{ToStatus: UnderMaintenance, CreatedAt: at(-1)}}, 10 * time.Hour, 0},
+		{"registered within the range", []Transition{{ToStatus: Operational, CreatedAt: at(3)}}, 7 * time.Hour, 7 * time.Hour},
+		{"decommissioned within the range", []Transition {
+			{ToStatus: Operational, CreatedAt: at(-5)},
+			{ToStatus: Decommissioned, CreatedAt: at(4)},
+		}, 4 * time.Hour, 4 * time.Hour},
+		{"decommissioned before the range", []Transition{{ToStatus: Decommissioned, CreatedAt: at(-1)}}, 0, 0},
+		{"transition at the end of the range", []Transition {
+			{ToStatus: Operational, CreatedAt: at(-1)},
+			{ToStatus: UnderMaintenance, CreatedAt: at(10)},
+		}, 10 * time.Hour, 10 * time.Hour},
+	} {
+		var cycled, uncycled Effectiveness
+		cycled.AddStatusHistory(test.transitions, from, to, float(2))
+		uncycled.AddStatusHistory(test.transitions, from, to, nil)
+		if cycled.PlannedTime != test.planned || cycled.RunTime != test.run {
+			t.Errorf("%s: planned %v and run %v, expected %v and %v", test.name, cycled.PlannedTime, cycled.RunTime, test.planned, test.run)
+		}
+		if cycled.CycledRunTime != test.run || uncycled.CycledRunTime != 0 {
+			t.Errorf("%s: cycled run %v and %v without the ideal cycle time, expected %v and 0",
+				test.name, cycled.CycledRunTime, uncycled.CycledRunTime, test.run)
+		}
+	}
+}
+
+func TestEffectivenessAddCounts(t *testing.T) {
+	var effectiveness, other Effectiveness
+	effectiveness.AddCounts(100, 90, float(2.5))
+	other.AddCounts(40, 40, nil)	// Counts without the ideal cycle time do not take the ideal time
+	effectiveness.Add(&other)
+	if effectiveness.TotalCount != 140 || effectiveness.GoodCount != 130 || effectiveness.IdealTime != 250 * time.Second {
+		t.Errorf("counts %v and %v with the ideal time %v, expected 140 and 130 with 4m10s",
+			effectiveness.TotalCount, effectiveness.GoodCount, effectiveness.IdealTime)
+	}
+}
+
+func TestEffectivenessComponents(t *testing.T) {
+	for _, test := range []struct {
+		name			string
+		effectiveness	Effectiveness
+		availability	*float64	// nil if it cannot be computed
+		performance		*float64
+		quality			*float64
+		oee				*float64
+	}{
+		{"all components", Effectiveness {
+			PlannedTime: 10 * time.Hour, RunTime: 8 * time.Hour, CycledRunTime: 8 * time.Hour, IdealTime: 6 * time.Hour,
+			TotalCount: 100, GoodCount: 90,
+		}, float(0.8), float(0.75), float(0.9), float(0.54)},
+		{"no planned time", Effectiveness {
+			CycledRunTime: 8 * time.Hour, IdealTime: 6 * time.Hour, TotalCount: 100, GoodCount: 90,
+		}, nil, float(0.75), float(0.9), nil},
+		{"no ideal cycle time", Effectiveness {
+			PlannedTime: 10 * time.Hour, RunTime: 8 * time.Hour, TotalCount: 100, GoodCount: 90,
+		}, float(0.8), nil, float(0.9), nil},
+		{"no counts", Effectiveness {
+			PlannedTime: 10 * time.Hour, RunTime: 8 * time.Hour, CycledRunTime: 8 * time.Hour,
+		}, float(0.8), float(0), nil, nil},
+		{"never operational", Effectiveness {
+			PlannedTime: 10 * time.Hour, TotalCount: 10, GoodCount: 10,
+		}, float(0), nil, float(1), nil},
+		{"counts beyond the ideal are capped", Effectiveness {
+			PlannedTime: 10 * time.Hour, RunTime: 8 * time.Hour, CycledRunTime: 8 * time.Hour, IdealTime: 10 * time.Hour,
+			TotalCount: 100, GoodCount: 110,
+		}, float(0.8), float(1), float(1), float(0.8)},
+	} {
+		for _, component := range []struct {
+			name		string
+			value		*float64
+			expected	*float64
+		}{
+			{"availability", test.effectiveness.Availability(), test.availability},
+			{"performance", test.effectiveness.Performance(), test.performance},
+			{"quality", test.effectiveness.Quality(), test.quality},
+			{"oee", test.effectiveness.OEE(), test.oee},
+		} {
+			switch {
+				case component.value == nil && component.expected != nil:
+					t.Errorf("%s: %s is null, expected %v", test.name, component.name, *component.expected)
+				case component.value != nil && component.expected == nil:
+					t.Errorf("%s: %s %v, expected null", test.name, component.name, *component.value)
+				case component.value != nil && math.Abs(*component.value - *component.expected) > epsilon:
+					t.Errorf("%s: %s %v, expected %v", test.name, component.name, *component.value, *component.expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"fmt"
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+// OutboxMessage is the message stored in the same transaction as the change it reports
+// and relayed to the publisher afterwards.
+type OutboxMessage struct {
+	Id				int64		`db:"id"`
+	AggregateId		uuid.UUID	`db:"aggregate_id"`
+	Type			string		`db:"type"`
+	Payload			[]byte		`db:"payload"`
+	CreatedAt		time.Time	`db:"created_at"`
+	Attempts		int			`db:"attempts"`
+	TransactionId	int64		`db:"transaction_id"`
+}
+
+func (message *OutboxMessage) Position() OutboxPosition {
+	return OutboxPosition{TransactionId: message.TransactionId, Id: message.Id}
+}
+
+// OutboxPosition orders the messages by commit: ids are taken before the commit, so a message with a lower id
+// may commit later, but the messages are read only when all the transactions with lower ids have finished,
+// so no message appears before the position already read.
+type OutboxPosition struct {
+	TransactionId	int64
+	Id				int64
+}
+
+func (position OutboxPosition) Less(other OutboxPosition) bool {
+	return position.TransactionId < other.TransactionId ||
+		position.TransactionId == other.TransactionId && position.Id < other.Id
+}
+
+func (position OutboxPosition) String() string {
+	return fmt.Sprintf("%d-%d", position.TransactionId, position.Id)
+}
+
+// ParseOutboxPosition parses the position formatted by String.
+func ParseOutboxPosition(text string) (OutboxPosition, error) {
+	var position OutboxPosition
+	if _, err := fmt.Sscanf(text, "%d-%d", &position.TransactionId, &position.Id); err != nil || position.TransactionId < 0 || position.Id < 0 || position.String() != text {
+		return position, fmt.Errorf("Invalid position: `%s`", text)
+	}
+	return position, nil
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+	"github.com/lib/pq"
+)
+
+// SparePart fits the equipment of any of Kinds and the equipment listed in EquipmentIds.
+type SparePart struct {
+	Id				int64			`db:"id"`
+	SKU				string			`db:"sku"`
+	Description		string			`db:"description"`
+	Unit			string			`db:"unit"`
+	Kinds			pq.Int64Array	`db:"kinds"`
+	EquipmentIds	pq.StringArray	`db:"equipment_ids"`
+	ReorderLevel	float64			`db:"reorder_level"`	// The stock is low when the available quantity does not exceed it
+	CreatedAt		time.Time		`db:"created_at"`
+	UpdatedAt		time.Time		`db:"updated_at"`
+	// Summed up over the storerooms
+	OnHand			float64			`db:"on_hand"`
+	Reserved		float64			`db:"reserved"`
+}
+
+// PartStock is the quantity of the part in the storeroom; the reserved quantity is not available for new reservations.
+type PartStock struct {
+	PartId		int64	`db:"part_id"`
+	Storeroom	string	`db:"storeroom"`
+	OnHand		float64	`db:"on_hand"`
+	Reserved	float64	`db:"reserved"`
+}
+
+type ReservationState string
+const (
+	PartReserved ReservationState = "reserved"
+	PartConsumed ReservationState = "consumed"	// Taken from the stock by the work order done
+	PartReleased ReservationState = "released"	// Returned to the stock
+)
+
+type PartReservation struct {
+	Id			int64				`db:"id"`
+	WorkOrderId	int64				`db:"work_order_id"`
+	PartId		int64				`db:"part_id"`
+	Storeroom	string				`db:"storeroom"`
+	Quantity	float64				`db:"quantity"`
+	State		ReservationState	`db:"state"`
+	CreatedAt	time.Time			`db:"created_at"`
+	UpdatedAt	time.Time			`db:"updated_at"`
+}
@@ -0,0 +1,71 @@
+package model
+
+import "time"
+
+// Reliability accumulates the failures (transitions from Operational to UnderMaintenance) and the repairs
+// (transitions from UnderMaintenance back to Operational) of one or more pieces of equipment within the range.
+type Reliability struct {
+	UpTime		time.Duration	// While operational
+	RepairTime	time.Duration	// Under maintenance before the repairs
+	Failures	int
+	Repairs		int
+}
+
+// AddStatusHistory accumulates the transitions of the equipment in order of time up to `to` including the last one before `from`
+// (the first transition sets the initial status); the times are clipped to [from, to).
+func (reliability *Reliability) AddStatusHistory(transitions []Transition, from, to time.Time) {
+	for i, transition := range transitions {
+		start, end := transition.CreatedAt, to
+		if i + 1 < len(transitions) {
+			end = transitions[i + 1].CreatedAt
+		}
+		if start.Before(from) {
+			start = from
+		} else if transition.FromStatus != nil {
+			switch {
+			case *transition.FromStatus == Operational && transition.ToStatus == UnderMaintenance:
+				reliability.Failures++
+			case *transition.FromStatus == UnderMaintenance && transition.ToStatus == Operational:
+				reliability.Repairs++
+				if i > 0 {
+					maintenanceStart := transitions[i - 1].CreatedAt
+					if maintenanceStart.Before(from) {
+						maintenanceStart = from
+					}
+					reliability.RepairTime += transition.CreatedAt.Sub(maintenanceStart)
+				}
+			}
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) && transition.ToStatus == Operational {
+			reliability.UpTime += end.Sub(start)
+		}
+	}
+}
+
+func (reliability *Reliability) Add(other *Reliability) {
+	reliability.UpTime += other.UpTime
+	reliability.RepairTime += other.RepairTime
+	reliability.Failures += other.Failures
+	reliability.Repairs += other.Repairs
+}
+
+// MTBF is the mean time between failures: the time operational by the number of failures.
+func (reliability *Reliability) MTBF() *time.Duration {
+	if reliability.Failures == 0 {
+		return nil
+	}
+	mtbf := reliability.UpTime / time.Duration(reliability.Failures)
+	return &mtbf
+}
+
+// MTTR is the mean time to repair: the time under maintenance by the number of repairs.
+func (reliability *Reliability) MTTR() *time.Duration {
+	if reliability.Repairs == 0 {
+		return nil
+	}
+	mttr := reliability.RepairTime / time.Duration(reliability.Repairs)
+	return &mttr
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReliabilityAddStatusHistory(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+	initial := func(status OperationalStatus, hours int) Transition {
+		return Transition{ToStatus: status, CreatedAt: from.Add(time.Duration(hours) * time.Hour)}
+	}
+	change := func(fromStatus, toStatus OperationalStatus, hours int) Transition {
+		transition := initial(toStatus, hours)
+		transition.FromStatus = &fromStatus
+		return transition
+	}
+	hours := func(count float64) *time.Duration {
+		duration := time.Duration(count * float64(time.Hour))
+		return &duration
+	}
+	for _, test := range []struct {
+		name		string
+		transitions	[]Transition
+		expected	Reliability
+		mtbf		*time.Duration	// nil if there are no failures
+		mttr		*time.Duration	// nil if there are no repairs
+	}{
+		{"no history", nil, Reliability{}, nil, nil},
+		{"no failures", []Transition {
+			initial(Operational, -5),
+		}, Reliability{UpTime: 10 * time.Hour}, nil, nil},
+		{"registered within the window", []Transition {
+			initial(Operational, 2),
+		}, Reliability{UpTime: 8 * time.Hour}, nil, nil},
+		{"failure repaired within the window", []Transition {
+			initial(Operational, -5),
+			change(Operational, UnderMaintenance, 2),
+			change(UnderMaintenance, Operational, 5),
+		}, Reliability{UpTime: 7 * time.Hour, RepairTime: 3 * time.Hour, Failures: 1, Repairs: 1}, hours(7), hours(3)},
+		// The failure before the window is not counted, its repair is counted from the start of the window
+		{"window starting mid-failure", []Transition {
+			initial(Operational, -10),
+			change(Operational, UnderMaintenance, -2),
+			change(UnderMaintenance, Operational, 3),
+		}, Reliability{UpTime: 7 * time.Hour, RepairTime: 3 * time.Hour, Repairs: 1}, nil, hours(3)},
+		// The repair open at the end of the window is not counted until it completes
+		{"repair open at the end of the window", []Transition {
+			initial(Operational, -5),
+			change(Operational, UnderMaintenance, 6),
+		}, Reliability{UpTime: 6 * time.Hour, Failures: 1}, hours(6), nil},
+		{"failures and repairs", []Transition {
+			initial(Operational, -1),
+			change(Operational, UnderMaintenance, 1),
+			change(UnderMaintenance, Operational, 2),
+			change(Operational, UnderMaintenance, 4),
+			change(UnderMaintenance, Operational, 8),
+		}, Reliability{UpTime: 5 * time.Hour, RepairTime: 5 * time.Hour, Failures: 2, Repairs: 2}, hours(2.5), hours(2.5)},
+		{"decommissioned under maintenance", []Transition {
+			initial(Operational, -1),
+			change(Operational, UnderMaintenance, 3),
+			change(UnderMaintenance, Decommissioned, 5),
+		}, Reliability{UpTime: 3 * time.Hour, Failures: 1}, hours(3), nil},
+	} {
+		var reliability Reliability
+		reliability.AddStatusHistory(test.transitions, from, to)
+		if reliability != test.expected {
+			t.Errorf("%s: %+v, expected %+v", test.name, reliability, test.expected)
+		}
+		for _, mean := range []struct {
+			name		string
+			value		*time.Duration
+			expected	*time.Duration
+		}{
+			{"MTBF", reliability.MTBF(), test.mtbf},
+			{"MTTR", reliability.MTTR(), test.mttr},
+		} {
+			if (mean.value == nil) != (mean.expected == nil) || mean.value != nil && *mean.value != *mean.expected {
+				t.Errorf("%s: %s %v, expected %v", test.name, mean.name, mean.value, mean.expected)
+			}
+		}
+	}
+}
+
+func TestReliabilityAdd(t *testing.T) {
+	reliability := Reliability{UpTime: 7 * time.Hour, RepairTime: 3 * time.Hour, Failures: 1, Repairs: 1}
+	reliability.Add(&Reliability{UpTime: 7 * time.Hour, RepairTime: 3 * time.Hour, Repairs: 1})
+	if mtbf, mttr := reliability.MTBF(), reliability.MTTR(); mtbf == nil || *mtbf != 14 * time.Hour || mttr == nil || *mttr != 3 * time.Hour {
+		t.Errorf("MTBF %v and MTTR %v, expected 14h and 3h", mtbf, mttr)
+	}
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+type RevisionOperation string
+const (
+	Created RevisionOperation = "created"
+	Updated RevisionOperation = "updated"
+	Deleted RevisionOperation = "deleted"
+)
+
+// Revision is a snapshot of the equipment taken by each change; revisions of the equipment are numbered from 1.
+type Revision struct {
+	EquipmentId	uuid.UUID			`db:"equipment_id"`
+	Revision	int					`db:"revision"`
+	Operation	RevisionOperation	`db:"operation"`
+	Kind		EquipmentKind		`db:"kind"`
+	Status		OperationalStatus	`db:"status"`
+	Parameters	[]byte				`db:"parameters"`
+	RecordedAt	time.Time			`db:"recorded_at"`
+}
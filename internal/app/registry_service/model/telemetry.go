@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+// Metric is the reading the equipment of some kind may report; Min and Max bound its values if set.
+type Metric struct {
+	Name	string		`json:"name"`
+	Unit	string		`json:"unit"`
+	Min		*float64	`json:"min,omitempty"`
+	Max		*float64	`json:"max,omitempty"`
+}
+
+// Reading is the value of the metric reported by the equipment at RecordedAt.
+type Reading struct {
+	EquipmentId	uuid.UUID	`db:"equipment_id" json:"equipment_id"`
+	Metric		string		`db:"metric" json:"metric"`
+	Value		float64		`db:"value" json:"value"`
+	Unit		string		`db:"unit" json:"unit"`
+	RecordedAt	time.Time	`db:"recorded_at" json:"recorded_at"`
+}
+
+// ReadingBatch is the stored batch of readings of the equipment received at ReceivedAt awaiting the listener.
+type ReadingBatch struct {
+	Id			int64		`db:"id"`
+	Listener	string		`db:"listener"`
+	EquipmentId	uuid.UUID	`db:"equipment_id"`
+	Readings	[]Reading	`db:"-"`
+	ReceivedAt	time.Time	`db:"received_at"`
+	Attempts	int			`db:"attempts"`
+}
+
+func float(value float64) *float64 {
+	return &value
+}
+
+// BuiltinMetrics are the metrics of the predefined kinds seeded into the catalog; all of them have the production counters.
+var BuiltinMetrics = map[EquipmentKind][]Metric {
+	CNCMachine: append([]Metric {
+		{Name: "spindle_temp", Unit: "celsius", Min: float(-50), Max: float(300)},
+		{Name: "spindle_rpm", Unit: "rpm", Min: float(0)},
+		{Name: "vibration", Unit: "mm/s", Min: float(0)},
+	}, ProductionCounters...),
+	ConveyorBelt: append([]Metric {
+		{Name: "speed", Unit: "m/s", Min: float(0)},
+		{Name: "motor_temp", Unit: "celsius", Min: float(-50), Max: float(300)},
+		{Name: "vibration", Unit: "mm/s", Min: float(0)},
+	}, ProductionCounters...),
+	DrillMachine: append([]Metric {
+		{Name: "spindle_temp", Unit: "celsius", Min: float(-50), Max: float(300)},
+		{Name: "spindle_rpm", Unit: "rpm", Min: float(0)},
+		{Name: "vibration", Unit: "mm/s", Min: float(0)},
+	}, ProductionCounters...),
+	RoboticArm: append([]Metric {
+		{Name: "joint_torque", Unit: "N*m"},
+		{Name: "motor_temp", Unit: "celsius", Min: float(-50), Max: float(300)},
+		{Name: "vibration", Unit: "mm/s", Min: float(0)},
+	}, ProductionCounters...),
+}
+
+// MetricTotal is the sum of the readings of the metric of the equipment over some range.
+type MetricTotal struct {
+	EquipmentId	uuid.UUID	`db:"equipment_id"`
+	Metric		string		`db:"metric"`
+	Value		float64		`db:"value"`
+}
+
+// SeriesPoint is the aggregated value of readings within the step starting at Time.
+type SeriesPoint struct {
+	Time	time.Time	`db:"time"`
+	Value	float64		`db:"value"`
+}
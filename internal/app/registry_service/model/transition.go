@@ -0,0 +1,102 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+// Transition is a recorded change of the operational status; FromStatus is nil for the initial status.
+type Transition struct {
+	Id			int64				`db:"id"`
+	EquipmentId	uuid.UUID			`db:"equipment_id"`
+	FromStatus	*OperationalStatus	`db:"from_status"`
+	ToStatus	OperationalStatus	`db:"to_status"`
+	Reason		string				`db:"reason"`
+	Actor		string				`db:"actor"`
+	CreatedAt	time.Time			`db:"created_at"`
+}
+
+
+type TransitionRule struct {
+	RequiresReason	bool	`json:"requires_reason"`
+}
+
+// TransitionGraph lists allowed changes of the operational status: graph[from][to];
+// the status without outgoing transitions is terminal.
+type TransitionGraph map[OperationalStatus]map[OperationalStatus]TransitionRule
+
+func DefaultTransitionGraph() TransitionGraph {
+	return TransitionGraph {
+		Operational: {
+			UnderMaintenance:	{},
+			Decommissioned:		{RequiresReason: true},
+		},
+		UnderMaintenance: {
+			Operational:		{RequiresReason: true}, // Completion note
+			Decommissioned:		{RequiresReason: true},
+		},
+		Decommissioned: {},
+	}
+}
+
+// LoadTransitionGraph reads the graph overriding the default one from the JSON file keyed by status names (see UnmarshalJSON);
+// the default graph is returned if the path is empty.
+func LoadTransitionGraph(path string) (TransitionGraph, error) {
+	graph := DefaultTransitionGraph()
+	if path == "" {
+		return graph, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &graph); err != nil {
+		return nil, fmt.Errorf("Transition graph `%s`: %w", path, err)
+	}
+	return graph, nil
+}
+
+// Check returns the error wrapping ErrConflict if the graph does not allow the transition with given reason.
+func (graph TransitionGraph) Check(from, to OperationalStatus, reason string) error {
+	if from == to {
+		return fmt.Errorf("%w: equipment is already %s", ErrConflict, to)
+	}
+	rule, ok := graph[from][to]
+	if !ok {
+		if len(graph[from]) == 0 {
+			return fmt.Errorf("%w: %s is terminal status", ErrConflict, from)
+		}
+		return fmt.Errorf("%w: transition from %s to %s is not allowed", ErrConflict, from, to)
+	}
+	if rule.RequiresReason && reason == "" {
+		return fmt.Errorf("%w: transition from %s to %s requires reason", ErrConflict, from, to)
+	}
+	return nil
+}
+
+// UnmarshalJSON reads the graph keyed by status names, e.g. `{"Operational": {"UnderMaintenance": {"requires_reason": false}}}`.
+func (graph *TransitionGraph) UnmarshalJSON(data []byte) error {
+	var named map[string]map[string]TransitionRule
+	if err := json.Unmarshal(data, &named); err != nil {
+		return err
+	}
+	*graph = make(TransitionGraph, len(named))
+	for fromName, rules := range named {
+		from := ParseOperationalStatus(fromName)
+		if from == nil {
+			return fmt.Errorf("Unknown operational status: `%s`", fromName)
+		}
+		(*graph)[*from] = make(map[OperationalStatus]TransitionRule, len(rules))
+		for toName, rule := range rules {
+			to := ParseOperationalStatus(toName)
+			if to == nil {
+				return fmt.Errorf("Unknown operational status: `%s`", toName)
+			}
+			(*graph)[*from][*to] = rule
+		}
+	}
+	return nil
+}
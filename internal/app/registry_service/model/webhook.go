@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+	"github.com/lib/pq"
+)
+
+// ChangeEvent names the change of the equipment the webhooks may be subscribed to.
+type ChangeEvent string
+const (
+	EquipmentCreated ChangeEvent = "equipment.created"
+	EquipmentUpdated ChangeEvent = "equipment.updated"	// Parameters are changed
+	EquipmentStatusChanged ChangeEvent = "equipment.status_changed"
+	EquipmentDeleted ChangeEvent = "equipment.deleted"
+)
+
+func (event ChangeEvent) IsValid() bool {
+	switch event {
+		case EquipmentCreated, EquipmentUpdated, EquipmentStatusChanged, EquipmentDeleted:
+			return true
+	}
+	return false
+}
+
+// ChangeEventOf returns the change the event of the equipment makes for the webhooks.
+func ChangeEventOf(eventType EventType) (ChangeEvent, bool) {
+	switch eventType {
+		case EquipmentRegistered:
+			return EquipmentCreated, true
+		case StatusChanged:
+			return EquipmentStatusChanged, true
+		case ParametersChanged:
+			return EquipmentUpdated, true
+		case EquipmentRemoved:
+			return EquipmentDeleted, true
+	}
+	return "", false
+}
+
+// Webhook is the subscription to the changes of equipment; empty EventTypes, Kinds or Statuses match any value.
+type Webhook struct {
+	Id			int64			`db:"id"`
+	URL			string			`db:"url"`
+	EventTypes	pq.StringArray	`db:"event_types"`
+	Kinds		pq.Int64Array	`db:"kinds"`
+	Statuses	pq.Int64Array	`db:"statuses"`
+	Secret		string			`db:"secret"`
+	Active		bool			`db:"active"`
+	CreatedAt	time.Time		`db:"created_at"`
+	UpdatedAt	time.Time		`db:"updated_at"`
+}
+
+
+type DeliveryStatus string
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead DeliveryStatus = "dead"	// Attempts are exhausted
+)
+
+type WebhookDelivery struct {
+	Id				int64			`db:"id"`
+	WebhookId		int64			`db:"webhook_id"`
+	Event			ChangeEvent		`db:"event"`
+	Payload			[]byte			`db:"payload"`
+	Status			DeliveryStatus	`db:"status"`
+	Attempts		int				`db:"attempts"`
+	ResponseStatus	*int			`db:"response_status"`	// Of the last attempt
+	LastError		*string			`db:"last_error"`
+	CreatedAt		time.Time		`db:"created_at"`
+	NextAttemptAt	time.Time		`db:"next_attempt_at"`
+	DeliveredAt		*time.Time		`db:"delivered_at"`
+}
+
+// OutgoingDelivery is the delivery due for the attempt along with the endpoint of its webhook.
+type OutgoingDelivery struct {
+	WebhookDelivery
+	URL		string	`db:"url"`
+	Secret	string	`db:"secret"`
+}
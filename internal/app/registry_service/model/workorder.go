@@ -0,0 +1,73 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"github.com/gofrs/uuid"
+)
+
+type WorkOrderState string
+const (
+	WorkOrderOpen WorkOrderState = "open"
+	WorkOrderAssigned WorkOrderState = "assigned"
+	WorkOrderInProgress WorkOrderState = "in_progress"
+	WorkOrderDone WorkOrderState = "done"
+	WorkOrderCancelled WorkOrderState = "cancelled"
+)
+
+// workOrderTransitions lists the states each open state may change to; assigned work order may be reassigned.
+var workOrderTransitions = map[WorkOrderState][]WorkOrderState {
+	WorkOrderOpen:			{WorkOrderAssigned, WorkOrderCancelled},
+	WorkOrderAssigned:		{WorkOrderAssigned, WorkOrderInProgress, WorkOrderCancelled},
+	WorkOrderInProgress:	{WorkOrderDone, WorkOrderCancelled},
+}
+
+func (state WorkOrderState) IsValid() bool {
+	switch state {
+		case WorkOrderOpen, WorkOrderAssigned, WorkOrderInProgress, WorkOrderDone, WorkOrderCancelled:
+			return true
+	}
+	return false
+}
+
+// IsClosed reports whether the work order is done or cancelled.
+func (state WorkOrderState) IsClosed() bool {
+	return state == WorkOrderDone || state == WorkOrderCancelled
+}
+
+// CheckTransition returns the error wrapping ErrConflict unless the work order may change its state from the state to `to`.
+func (state WorkOrderState) CheckTransition(to WorkOrderState) error {
+	if !slices.Contains(workOrderTransitions[state], to) {
+		return fmt.Errorf("%w: work order cannot change from %s to %s", ErrConflict, state, to)
+	}
+	return nil
+}
+
+type WorkOrderPart struct {
+	Name		string	`json:"name"`
+	Quantity	float64	`json:"quantity"`
+}
+
+// WorkOrder records the maintenance of the equipment; if ManagesStatus, opening it moves the equipment UnderMaintenance
+// and closing it returns the equipment Operational.
+type WorkOrder struct {
+	Id					int64			`db:"id"`
+	EquipmentId			uuid.UUID		`db:"equipment_id"`
+	PlanId				*int64			`db:"plan_id"`	// The maintenance plan the work order is done for
+	Title				string			`db:"title"`
+	Description			string			`db:"description"`
+	State				WorkOrderState	`db:"state"`
+	OpenedBy			string			`db:"opened_by"`
+	Assignee			string			`db:"assignee"`
+	ClosedBy			string			`db:"closed_by"`
+	Resolution			string			`db:"resolution"`
+	Parts				[]byte			`db:"parts"`	// JSON array of WorkOrderPart
+	TimeSpentSeconds	*int64			`db:"time_spent_seconds"`
+	ManagesStatus		bool			`db:"manages_status"`
+	CreatedAt			time.Time		`db:"created_at"`
+	AssignedAt			*time.Time		`db:"assigned_at"`
+	StartedAt			*time.Time		`db:"started_at"`
+	ClosedAt			*time.Time		`db:"closed_at"`
+	UpdatedAt			time.Time		`db:"updated_at"`
+}
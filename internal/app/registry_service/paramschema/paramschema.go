@@ -0,0 +1,158 @@
+package paramschema
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	schemaURL string = "equipment://kinds/%d/parameters.schema.json"
+	unknownKind = "No parameter schema is registered for equipment kind %d"
+	invalidParameters = "Equipment parameters do not match the %s schema"
+)
+
+//go:embed schemas/*.json
+var builtinSchemas embed.FS
+
+// BuiltinSchemas maps each of the predefined equipment kinds to its embedded parameter schema file.
+var BuiltinSchemas = map[model.EquipmentKind]string {
+	model.CNCMachine:	"schemas/cnc_machine.json",
+	model.ConveyorBelt:	"schemas/conveyor_belt.json",
+	model.DrillMachine:	"schemas/drill_machine.json",
+	model.RoboticArm:	"schemas/robotic_arm.json",
+}
+
+// Builtin returns the source of the embedded parameter schema for given predefined kind.
+func Builtin(kind model.EquipmentKind) ([]byte, bool) {
+	if path, ok := BuiltinSchemas[kind]; ok {
+		if source, err := builtinSchemas.ReadFile(path); err == nil {
+			return source, true
+		}
+	}
+	return nil, false
+}
+
+
+type FieldError struct {
+	Field	string	`json:"field"`
+	Message	string	`json:"message"`
+}
+
+// ValidationError lists every field of equipment parameters violating the schema of its kind.
+type ValidationError struct {
+	Kind	model.EquipmentKind
+	Fields	[]FieldError
+}
+
+func (validationError *ValidationError) Error() string {
+	messages := make([]string, 0, len(validationError.Fields))
+	for _, field := range validationError.Fields {
+		messages = append(messages, field.Field + ": " + field.Message)
+	}
+	return fmt.Sprintf(invalidParameters, validationError.Kind) + ": " + strings.Join(messages, "; ")
+}
+
+
+type compiledSchema struct {
+	source	[]byte
+	schema	*jsonschema.Schema
+}
+
+// Registry keeps a compiled JSON Schema (draft 2020-12 by default) of parameters per equipment kind.
+type Registry struct {
+	mutex	sync.RWMutex
+	schemas	map[model.EquipmentKind]compiledSchema
+}
+
+func NewRegistry() *Registry {
+	return &Registry{schemas: make(map[model.EquipmentKind]compiledSchema)}
+}
+
+func compile(kind model.EquipmentKind, source []byte) (*jsonschema.Schema, error) {
+	url := fmt.Sprintf(schemaURL, kind)
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	if err := compiler.AddResource(url, bytes.NewReader(source)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(url)
+}
+
+// Check reports whether the source is a valid schema.
+func Check(source []byte) error {
+	if _, err := compile(-1, source); err != nil {
+		return fmt.Errorf("Invalid parameter schema: %v", err)
+	}
+	return nil
+}
+
+// Register compiles the schema source and replaces the one previously registered for the kind;
+// the source equal to the registered one is not recompiled.
+func (registry *Registry) Register(kind model.EquipmentKind, source []byte) error {
+	registry.mutex.RLock()
+	compiled, ok := registry.schemas[kind]
+	registry.mutex.RUnlock()
+	if ok && bytes.Equal(compiled.source, source) {
+		return nil
+	}
+	schema, err := compile(kind, source)
+	if err != nil {
+		return fmt.Errorf("Invalid parameter schema for equipment kind %d: %v", kind, err)
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.schemas[kind] = compiledSchema{source: source, schema: schema}
+	return nil
+}
+
+// Validate checks parameters against the schema of the kind;
+// violations are reported as *ValidationError.
+func (registry *Registry) Validate(kind model.EquipmentKind, parameters map[string]interface{}) error {
+	registry.mutex.RLock()
+	compiled, ok := registry.schemas[kind]
+	registry.mutex.RUnlock()
+	if !ok {
+		return fmt.Errorf(unknownKind, kind)
+	}
+	err := compiled.schema.Validate(parameters)
+	var schemaError *jsonschema.ValidationError
+	if !errors.As(err, &schemaError) {
+		return err
+	}
+	validationError := ValidationError{Kind: kind}
+	for _, cause := range leafCauses(schemaError, nil) {
+		validationError.Fields = append(validationError.Fields, FieldError{
+			Field:		fieldName(cause.InstanceLocation),
+			Message:	cause.Message,
+		})
+	}
+	return &validationError
+}
+
+// leafCauses flattens the tree of schema errors keeping only the most specific ones.
+func leafCauses(schemaError *jsonschema.ValidationError, leaves []*jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(schemaError.Causes) == 0 {
+		return append(leaves, schemaError)
+	}
+	for _, cause := range schemaError.Causes {
+		leaves = leafCauses(cause, leaves)
+	}
+	return leaves
+}
+
+// fieldName converts JSON pointer to the instance location into dotted form, e.g. `/axes/0` -> `parameters.axes.0`.
+func fieldName(instanceLocation string) string {
+	name := "parameters"
+	for _, token := range strings.Split(strings.TrimPrefix(instanceLocation, "/"), "/") {
+		if token != "" {
+			name += "." + strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		}
+	}
+	return name
+}
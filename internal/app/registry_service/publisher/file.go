@@ -0,0 +1,56 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+type fileRecord struct {
+	Id			int64			`json:"id"`
+	AggregateId	uuid.UUID		`json:"aggregate_id"`
+	Type		string			`json:"type"`
+	CreatedAt	time.Time		`json:"created_at"`
+	Payload		json.RawMessage	`json:"payload"`
+}
+
+// File appends messages to the file as JSON lines; stands in for the broker in development.
+type File struct {
+	mutex	sync.Mutex
+	file	*os.File
+}
+
+func NewFile(path string) (*File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE | os.O_WRONLY | os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &File{file: file}, nil
+}
+
+func (publisher *File) Publish(ctx context.Context, message *model.OutboxMessage) error {
+	line, err := json.Marshal(fileRecord {
+		Id:				message.Id,
+		AggregateId:	message.AggregateId,
+		Type:			message.Type,
+		CreatedAt:		message.CreatedAt,
+		Payload:		message.Payload,
+	})
+	if err != nil {
+		return err
+	}
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	if _, err = publisher.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return publisher.file.Sync()
+}
+
+func (publisher *File) Close() error {
+	return publisher.file.Close()
+}
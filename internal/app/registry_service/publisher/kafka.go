@@ -0,0 +1,39 @@
+package publisher
+
+import (
+	"context"
+	"strconv"
+	"github.com/segmentio/kafka-go"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+// Kafka publishes messages to the topic keyed by the aggregate id, so that the changes of the same equipment
+// land in the same partition in order.
+type Kafka struct {
+	writer *kafka.Writer
+}
+
+func NewKafka(brokers []string, topic string) *Kafka {
+	return &Kafka{writer: &kafka.Writer {
+		Addr:			kafka.TCP(brokers...),
+		Topic:			topic,
+		Balancer:		&kafka.Hash{},
+		RequiredAcks:	kafka.RequireAll,
+	}}
+}
+
+func (publisher *Kafka) Publish(ctx context.Context, message *model.OutboxMessage) error {
+	return publisher.writer.WriteMessages(ctx, kafka.Message {
+		Key:	message.AggregateId.Bytes(),
+		Value:	message.Payload,
+		Time:	message.CreatedAt,
+		Headers: []kafka.Header {
+			{Key: "message-id", Value: []byte(strconv.FormatInt(message.Id, 10))},
+			{Key: "type", Value: []byte(message.Type)},
+		},
+	})
+}
+
+func (publisher *Kafka) Close() error {
+	return publisher.writer.Close()
+}
@@ -0,0 +1,47 @@
+package publisher
+
+import (
+	"context"
+	"sync"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+// Memory keeps published messages in process; Fail makes the next Publish calls return the error,
+// which allows to check retries in tests.
+type Memory struct {
+	mutex		sync.Mutex
+	messages	[]model.OutboxMessage
+	failure		error
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (publisher *Memory) Publish(ctx context.Context, message *model.OutboxMessage) error {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	if publisher.failure != nil {
+		return publisher.failure
+	}
+	publisher.messages = append(publisher.messages, *message)
+	return nil
+}
+
+// Fail sets the error returned by Publish; nil restores successful publishing.
+func (publisher *Memory) Fail(err error) {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	publisher.failure = err
+}
+
+// Messages returns copy of the messages published so far.
+func (publisher *Memory) Messages() []model.OutboxMessage {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	return append([]model.OutboxMessage(nil), publisher.messages...)
+}
+
+func (publisher *Memory) Close() error {
+	return nil
+}
@@ -0,0 +1,13 @@
+package publisher
+
+import (
+	"context"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+// Publisher delivers outbox messages downstream; a message is retried until Publish returns nil,
+// so the delivery is at-least-once and consumers should deduplicate by the message id.
+type Publisher interface {
+	Publish(ctx context.Context, message *model.OutboxMessage) error
+	Close() error
+}
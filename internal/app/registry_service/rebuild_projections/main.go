@@ -0,0 +1,25 @@
+package main
+
+import (
+	"log"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/database"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/repository"
+)
+
+// Recreates the equipment table, its revisions and status transitions from the event store.
+func main() {
+	db, err := database.Connect(
+		"postgres", "localhost", 54327, "equipment_api", "postgres", "postgres", false,
+	)
+	if err != nil {
+		log.Fatalln(err)
+		panic(err)
+	}
+	defer db.Close()
+	equipmentRepository := repository.NewEquipment(db)
+	if err := equipmentRepository.RebuildProjections(); err != nil {
+		log.Fatalln(err)
+		panic(err)
+	}
+	log.Println("Projections are rebuilt.")
+}
@@ -15,11 +15,11 @@ func main() {
 		panic(err)
 	}
 	defer db.Close()
-	if err := migrations.DropTableEquipment(db); err != nil {
+	if err := migrations.DropTables(db); err != nil {
 		log.Fatalln(err)
 		panic(err)
 	}
-	if err := migrations.CreateTableEquipment(db); err != nil {
+	if err := migrations.CreateTables(db); err != nil {
 		log.Fatalln(err)
 		panic(err)
 	}
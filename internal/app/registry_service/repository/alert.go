@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"strings"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	alertRuleColumns string = `id, kind, name, metric, operator, threshold, for_seconds, hysteresis, while_status, active, created_at, updated_at`
+	alertColumns = `id, rule_id, equipment_id, kind, metric, state, value, started_at, fired_at, resolved_at, updated_at`
+)
+
+type Alert struct {
+	db *sqlx.DB
+}
+
+func NewAlert(db *sqlx.DB) Alert {
+	return Alert{db: db}
+}
+
+func (repository *Alert) ListRules() ([]*dtos.AlertRuleGet, error) {
+	var ruleModels []model.AlertRule
+	if err := repository.db.Select(&ruleModels, `SELECT ` + alertRuleColumns + ` FROM alert_rules ORDER BY kind, id`); err != nil {
+		return nil, err
+	}
+	ruleGets := make([]*dtos.AlertRuleGet, 0, len(ruleModels))
+	for _, ruleModel := range ruleModels {
+		ruleGets = append(ruleGets, dtos.AlertRuleGetFromModel(ruleModel))
+	}
+	return ruleGets, nil
+}
+
+// ListActiveRules returns the rules evaluated for the equipment of the kind.
+func (repository *Alert) ListActiveRules(kind model.EquipmentKind) ([]model.AlertRule, error) {
+	var ruleModels []model.AlertRule
+	err := repository.db.Select(&ruleModels, `SELECT ` + alertRuleColumns + ` FROM alert_rules WHERE kind=$1 AND active ORDER BY id`, kind)
+	return ruleModels, err
+}
+
+func (repository *Alert) CreateRule(ruleCreate *dtos.AlertRuleCreate) (int64, error) {
+	active := ruleCreate.Active == nil || *ruleCreate.Active
+	var id int64
+	err := repository.db.QueryRow(
+		`INSERT INTO alert_rules (kind, name, metric, operator, threshold, for_seconds, hysteresis, while_status, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		ruleCreate.Kind, ruleCreate.Name, ruleCreate.Metric, ruleCreate.Operator, *ruleCreate.Threshold,
+		ruleCreate.ForSeconds(), ruleCreate.Hysteresis, ruleCreate.WhileStatus, active,
+	).Scan(&id)
+	return id, wrapConflict(err)
+}
+
+// UpdateRule changes the rule; the deactivated rule resolves its firing alerts and drops the pending ones.
+func (repository *Alert) UpdateRule(ruleUpdate *dtos.AlertRuleUpdate) (bool, error) {
+	now := time.Now()
+	set := make([]string, 0, 8)
+	arguments := map[string]interface{}{
+		"id":			ruleUpdate.Id,
+		"updated_at":	now,
+	}
+	if ruleUpdate.Name != nil {
+		set = append(set, "name=:name")
+		arguments["name"] = *ruleUpdate.Name
+	}
+	if ruleUpdate.Metric != nil {
+		set = append(set, "metric=:metric")
+		arguments["metric"] = *ruleUpdate.Metric
+	}
+	if ruleUpdate.Operator != nil {
+		set = append(set, "operator=:operator")
+		arguments["operator"] = *ruleUpdate.Operator
+	}
+	if ruleUpdate.Threshold != nil {
+		set = append(set, "threshold=:threshold")
+		arguments["threshold"] = *ruleUpdate.Threshold
+	}
+	if forSeconds := ruleUpdate.ForSeconds(); forSeconds != nil {
+		set = append(set, "for_seconds=:for_seconds")
+		arguments["for_seconds"] = *forSeconds
+	}
+	if ruleUpdate.Hysteresis != nil {
+		set = append(set, "hysteresis=:hysteresis")
+		arguments["hysteresis"] = *ruleUpdate.Hysteresis
+	}
+	if ruleUpdate.WhileStatus != nil {
+		set = append(set, "while_status=:while_status")
+		if *ruleUpdate.WhileStatus < 0 {
+			arguments["while_status"] = nil
+		} else {
+			arguments["while_status"] = *ruleUpdate.WhileStatus
+		}
+	}
+	if ruleUpdate.Active != nil {
+		set = append(set, "active=:active")
+		arguments["active"] = *ruleUpdate.Active
+	}
+	if len(set) == 0 {
+		return false, nil // Nothing to update
+	}
+	var updated bool
+	err := inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		var err error
+		updated, err = checkAffect(tx.NamedExec(
+			`UPDATE alert_rules SET ` + strings.Join(set, ", ") + `, updated_at=:updated_at WHERE id=:id`,
+			arguments,
+		))
+		deactivated := ruleUpdate.Active != nil && !*ruleUpdate.Active
+		if err != nil || !updated || !deactivated && !ruleUpdate.ChangesCondition() {
+			return err
+		}
+		// The open alerts have been evaluated against the former condition, so they are closed; the new one opens new alerts
+		if _, err = tx.Exec(
+			`UPDATE alerts SET state='resolved', resolved_at=$2, updated_at=$2 WHERE rule_id=$1 AND state='firing'`,
+			ruleUpdate.Id, now,
+		); err == nil {
+			_, err = tx.Exec(`DELETE FROM alerts WHERE rule_id=$1 AND state='pending'`, ruleUpdate.Id)
+		}
+		return err
+	})
+	return updated, err
+}
+
+func (repository *Alert) FindRuleById(id int64) (*dtos.AlertRuleGet, error) {
+	var ruleModel model.AlertRule
+	if err := repository.db.Get(&ruleModel, `SELECT ` + alertRuleColumns + ` FROM alert_rules WHERE id=$1`, id); err != nil {
+		return nil, err
+	}
+	return dtos.AlertRuleGetFromModel(ruleModel), nil
+}
+
+// RemoveRuleById removes the rule along with its alerts.
+func (repository *Alert) RemoveRuleById(id int64) (bool, error) {
+	return checkAffect(repository.db.Exec(`DELETE FROM alert_rules WHERE id=$1`, id))
+}
+
+// ListAlerts returns the total number of alerts matching the filter and the page of them from the newest.
+func (repository *Alert) ListAlerts(alertFilter *dtos.AlertFilter) (int, []*dtos.AlertGet, error) {
+	conditions := make([]string, 0, 4)
+	arguments := map[string]interface{}{
+		"limit":	alertFilter.PerPage,
+		"offset":	(alertFilter.Page - 1) * alertFilter.PerPage,
+	}
+	if len(alertFilter.EquipmentIds) > 0 {
+		conditions = append(conditions, "equipment_id=ANY(CAST(:equipment_ids AS uuid[]))")
+		arguments["equipment_ids"] = pq.Array(alertFilter.EquipmentIds)
+	}
+	if len(alertFilter.Kinds) > 0 {
+		conditions = append(conditions, "kind=ANY(:kinds)")
+		arguments["kinds"] = integralArray(alertFilter.Kinds)
+	}
+	if len(alertFilter.States) > 0 {
+		states := make(pq.StringArray, 0, len(alertFilter.States))
+		for _, state := range alertFilter.States {
+			states = append(states, string(state))
+		}
+		conditions = append(conditions, "state=ANY(:states)")
+		arguments["states"] = states
+	}
+	if len(alertFilter.RuleIds) > 0 {
+		conditions = append(conditions, "rule_id=ANY(:rule_ids)")
+		arguments["rule_ids"] = pq.Int64Array(alertFilter.RuleIds)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	var total int
+	countQuery, err := repository.db.PrepareNamed(`SELECT COUNT(*) FROM alerts` + where)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer countQuery.Close()
+	if err = countQuery.Get(&total, arguments); err != nil {
+		return 0, nil, err
+	}
+	pageQuery, err := repository.db.PrepareNamed(`SELECT ` + alertColumns + ` FROM alerts` + where + ` ORDER BY id DESC LIMIT :limit OFFSET :offset`)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer pageQuery.Close()
+	var alertModels []model.Alert
+	if err = pageQuery.Select(&alertModels, arguments); err != nil {
+		return 0, nil, err
+	}
+	alertGets := make([]*dtos.AlertGet, 0, len(alertModels))
+	for _, alertModel := range alertModels {
+		alertGets = append(alertGets, dtos.AlertGetFromModel(alertModel))
+	}
+	return total, alertGets, nil
+}
+
+// EvaluateAlerts passes the open alerts of the equipment to evaluate and stores the alerts it returns:
+// new ones (with zero id) are inserted, inactive ones are deleted, others are updated.
+// Evaluations of the same equipment are serialized by the advisory lock held until the end of the transaction.
+func (repository *Alert) EvaluateAlerts(equipmentId uuid.UUID, evaluate func(open []model.Alert) []*model.Alert) error {
+	return inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, equipmentId.String()); err != nil {
+			return err
+		}
+		var open []model.Alert
+		if err := tx.Select(
+			&open,
+			`SELECT ` + alertColumns + ` FROM alerts WHERE equipment_id=$1 AND state IN ('pending', 'firing')`,
+			equipmentId,
+		); err != nil {
+			return err
+		}
+		for _, alert := range evaluate(open) {
+			var err error
+			switch {
+				case alert.Id == 0 && alert.State == model.AlertInactive:
+					continue
+				case alert.Id == 0:
+					_, err = tx.NamedExec(
+						`INSERT INTO alerts (rule_id, equipment_id, kind, metric, state, value, started_at, fired_at, resolved_at, updated_at)
+						VALUES (:rule_id, :equipment_id, :kind, :metric, :state, :value, :started_at, :fired_at, :resolved_at, :updated_at)`,
+						alert,
+					)
+				case alert.State == model.AlertInactive:
+					_, err = tx.Exec(`DELETE FROM alerts WHERE id=$1`, alert.Id)
+				default:
+					_, err = tx.NamedExec(
+						`UPDATE alerts SET state=:state, value=:value, fired_at=:fired_at, resolved_at=:resolved_at, updated_at=:updated_at
+						WHERE id=:id`,
+						alert,
+					)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
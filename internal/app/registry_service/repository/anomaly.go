@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"strings"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	anomalyDetectorColumns string = `id, kind, metric, method, alpha, window_size, threshold, warmup, active, created_at, updated_at`
+	detectorStateColumns = `detector_id, equipment_id, count, mean, variance, recent, last_recorded_at`
+	anomalyColumns = `id, detector_id, equipment_id, kind, metric, method, value, expected, score, recorded_at, detected_at`
+)
+
+type Anomaly struct {
+	db *sqlx.DB
+}
+
+func NewAnomaly(db *sqlx.DB) Anomaly {
+	return Anomaly{db: db}
+}
+
+func (repository *Anomaly) ListDetectors() ([]*dtos.AnomalyDetectorGet, error) {
+	var detectorModels []model.AnomalyDetector
+	if err := repository.db.Select(&detectorModels, `SELECT ` + anomalyDetectorColumns + ` FROM anomaly_detectors ORDER BY kind, id`); err != nil {
+		return nil, err
+	}
+	detectorGets := make([]*dtos.AnomalyDetectorGet, 0, len(detectorModels))
+	for _, detectorModel := range detectorModels {
+		detectorGets = append(detectorGets, dtos.AnomalyDetectorGetFromModel(detectorModel))
+	}
+	return detectorGets, nil
+}
+
+// ListActiveDetectors returns the detectors applied to the readings of the equipment of the kind.
+func (repository *Anomaly) ListActiveDetectors(kind model.EquipmentKind) ([]model.AnomalyDetector, error) {
+	var detectorModels []model.AnomalyDetector
+	err := repository.db.Select(
+		&detectorModels,
+		`SELECT ` + anomalyDetectorColumns + ` FROM anomaly_detectors WHERE kind=$1 AND active ORDER BY id`,
+		kind,
+	)
+	return detectorModels, err
+}
+
+func (repository *Anomaly) CreateDetector(detector *model.AnomalyDetector) (int64, error) {
+	var id int64
+	err := repository.db.QueryRow(
+		`INSERT INTO anomaly_detectors (kind, metric, method, alpha, window_size, threshold, warmup, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		detector.Kind, detector.Metric, detector.Method, detector.Alpha, detector.Window, detector.Threshold, detector.Warmup, detector.Active,
+	).Scan(&id)
+	return id, wrapConflict(err)
+}
+
+// UpdateDetector stores the changed detector; its baselines are dropped if reset.
+func (repository *Anomaly) UpdateDetector(detector *model.AnomalyDetector, reset bool) (bool, error) {
+	var updated bool
+	err := inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		var err error
+		updated, err = checkAffect(tx.Exec(
+			`UPDATE anomaly_detectors SET metric=$2, method=$3, alpha=$4, window_size=$5, threshold=$6, warmup=$7, active=$8, updated_at=$9
+			WHERE id=$1`,
+			detector.Id, detector.Metric, detector.Method, detector.Alpha, detector.Window, detector.Threshold, detector.Warmup, detector.Active,
+			time.Now(),
+		))
+		if err != nil || !updated || !reset {
+			return err
+		}
+		_, err = tx.Exec(`DELETE FROM anomaly_detector_states WHERE detector_id=$1`, detector.Id)
+		return err
+	})
+	return updated, err
+}
+
+func (repository *Anomaly) FindDetectorById(id int64) (*model.AnomalyDetector, error) {
+	var detectorModel model.AnomalyDetector
+	if err := repository.db.Get(&detectorModel, `SELECT ` + anomalyDetectorColumns + ` FROM anomaly_detectors WHERE id=$1`, id); err != nil {
+		return nil, err
+	}
+	return &detectorModel, nil
+}
+
+// RemoveDetectorById removes the detector along with its baselines and anomalies.
+func (repository *Anomaly) RemoveDetectorById(id int64) (bool, error) {
+	return checkAffect(repository.db.Exec(`DELETE FROM anomaly_detectors WHERE id=$1`, id))
+}
+
+// ListAnomalies returns the total number of anomalies of the equipment matching the filter and the page of them from the latest reading.
+func (repository *Anomaly) ListAnomalies(equipmentId uuid.UUID, anomalyFilter *dtos.AnomalyFilter) (int, []*dtos.AnomalyGet, error) {
+	conditions := []string{"equipment_id=:equipment_id"}
+	arguments := map[string]interface{}{
+		"equipment_id":	equipmentId,
+		"limit":		anomalyFilter.PerPage,
+		"offset":		(anomalyFilter.Page - 1) * anomalyFilter.PerPage,
+	}
+	if len(anomalyFilter.Metrics) > 0 {
+		conditions = append(conditions, "metric=ANY(:metrics)")
+		arguments["metrics"] = pq.StringArray(anomalyFilter.Metrics)
+	}
+	if anomalyFilter.From != nil {
+		conditions = append(conditions, "recorded_at>=:from")
+		arguments["from"] = anomalyFilter.From.UTC()
+	}
+	if anomalyFilter.To != nil {
+		conditions = append(conditions, "recorded_at<:to")
+		arguments["to"] = anomalyFilter.To.UTC()
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+	var total int
+	countQuery, err := repository.db.PrepareNamed(`SELECT COUNT(*) FROM anomalies` + where)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer countQuery.Close()
+	if err = countQuery.Get(&total, arguments); err != nil {
+		return 0, nil, err
+	}
+	pageQuery, err := repository.db.PrepareNamed(
+		`SELECT ` + anomalyColumns + ` FROM anomalies` + where + ` ORDER BY recorded_at DESC, id DESC LIMIT :limit OFFSET :offset`,
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer pageQuery.Close()
+	var anomalyModels []model.Anomaly
+	if err = pageQuery.Select(&anomalyModels, arguments); err != nil {
+		return 0, nil, err
+	}
+	anomalyGets := make([]*dtos.AnomalyGet, 0, len(anomalyModels))
+	for _, anomalyModel := range anomalyModels {
+		anomalyGets = append(anomalyGets, dtos.AnomalyGetFromModel(anomalyModel))
+	}
+	return total, anomalyGets, nil
+}
+
+// Detect passes the stored baselines of the detectors for the equipment to detect and stores the baselines
+// and the anomalies it returns. Detections for the same equipment are serialized by the advisory lock held until the end of the transaction.
+func (repository *Anomaly) Detect(
+	equipmentId uuid.UUID,
+	detectorIds []int64,
+	detect func(states []model.DetectorState) ([]*model.DetectorState, []*model.Anomaly),
+) error {
+	return inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, equipmentId.String()); err != nil {
+			return err
+		}
+		var states []model.DetectorState
+		if err := tx.Select(
+			&states,
+			`SELECT ` + detectorStateColumns + ` FROM anomaly_detector_states WHERE equipment_id=$1 AND detector_id=ANY($2)`,
+			equipmentId, pq.Int64Array(detectorIds),
+		); err != nil {
+			return err
+		}
+		changed, anomalies := detect(states)
+		for _, state := range changed {
+			if _, err := tx.NamedExec(
+				`INSERT INTO anomaly_detector_states (` + detectorStateColumns + `)
+				VALUES (:detector_id, :equipment_id, :count, :mean, :variance, COALESCE(CAST(:recent AS double precision[]), '{}'), :last_recorded_at)
+				ON CONFLICT (detector_id, equipment_id) DO UPDATE SET
+					count=EXCLUDED.count, mean=EXCLUDED.mean, variance=EXCLUDED.variance,
+					recent=EXCLUDED.recent, last_recorded_at=EXCLUDED.last_recorded_at`,
+				state,
+			); err != nil {
+				return err
+			}
+		}
+		detectedAt := time.Now()
+		for _, anomaly := range anomalies {
+			anomaly.DetectedAt = detectedAt
+			if _, err := tx.NamedExec(
+				`INSERT INTO anomalies (detector_id, equipment_id, kind, metric, method, value, expected, score, recorded_at, detected_at)
+				VALUES (:detector_id, :equipment_id, :kind, :metric, :method, :value, :expected, :score, :recorded_at, :detected_at)`,
+				anomaly,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
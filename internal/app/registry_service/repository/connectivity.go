@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+type Connectivity struct {
+	db *sqlx.DB
+}
+
+func NewConnectivity(db *sqlx.DB) Connectivity {
+	return Connectivity{db: db}
+}
+
+// Seen marks the existing equipment online as of at; returns false if there is no such equipment.
+func (repository *Connectivity) Seen(id uuid.UUID, at time.Time) (bool, error) {
+	return checkAffect(repository.db.Exec(
+		`INSERT INTO equipment_connectivity (equipment_id, state, last_seen_at, changed_at)
+		SELECT id, $3, $2, $2 FROM equipment WHERE id=$1
+		ON CONFLICT (equipment_id) DO UPDATE SET
+			state=EXCLUDED.state,
+			last_seen_at=GREATEST(equipment_connectivity.last_seen_at, EXCLUDED.last_seen_at),
+			changed_at=CASE WHEN equipment_connectivity.state=EXCLUDED.state THEN equipment_connectivity.changed_at ELSE EXCLUDED.changed_at END`,
+		id, at, model.Online,
+	))
+}
+
+// Sweep marks unreachable the online equipment not seen for twice the heartbeat interval of its kind before now;
+// decommissioned equipment is expected to be silent and is left alone.
+func (repository *Connectivity) Sweep(now time.Time) ([]model.ConnectivityChange, error) {
+	var changes []model.ConnectivityChange
+	err := repository.db.Select(
+		&changes,
+		`UPDATE equipment_connectivity connectivity SET state=$2, changed_at=$1
+		FROM equipment JOIN equipment_kinds kind ON kind.id=equipment.kind
+		WHERE equipment.id=connectivity.equipment_id AND equipment.status<>$4 AND connectivity.state=$3
+		AND connectivity.last_seen_at < $1 - 2 * kind.heartbeat_interval_seconds * interval '1 second'
+		RETURNING connectivity.equipment_id, connectivity.state, connectivity.last_seen_at`,
+		now, model.Unreachable, model.Online, model.Decommissioned,
+	)
+	return changes, err
+}
@@ -1,12 +1,14 @@
 package repository
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 	"github.com/gofrs/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
 )
@@ -19,81 +21,53 @@ func NewEquipment(db *sqlx.DB) Equipment {
 	return Equipment{db: db}
 }
 
-func (repository *Equipment) List(equipmentFilter *dtos.EquipmentFilter) ([]*dtos.EquipmentGet, error) {
-	var equipmentModels []model.Equipment
-	query := `SELECT id, kind, status, parameters, created_at, updated_at FROM equipment`
-	conditions := make([]string, 0, 6)
-
-	if equipmentFilter.Kinds != nil {
-		switch len(equipmentFilter.Kinds) {
-			case 0:
-				break
-			case 1:
-				conditions = append(conditions, "kind=" + string(figure(equipmentFilter.Kinds[0])))
-			default:
-				conditions = append(conditions, fmt.Sprintf("kind IN (%s)", joinIntegralArray(equipmentFilter.Kinds)))
-		}
-	} else if equipmentFilter.NoKinds != nil {
-		switch len(equipmentFilter.NoKinds) {
-			case 0:
-				break
-			case 1:
-				conditions = append(conditions, "kind<>" + string(figure(equipmentFilter.NoKinds[0])))
-			default:
-				conditions = append(conditions, fmt.Sprintf("kind NOT IN (%s)", joinIntegralArray(equipmentFilter.NoKinds)))
-		}
-	}
+// historicalEquipment is the state of the equipment table at :as_of restored from the revisions:
+// created_at is taken from the first revision and updated_at from the last one not later than :as_of.
+const historicalEquipment string = `(
+	SELECT DISTINCT ON (revision.equipment_id)
+		revision.equipment_id AS id, revision.kind, revision.status, revision.parameters, revision.operation,
+		(SELECT first.recorded_at FROM equipment_revisions first WHERE first.equipment_id=revision.equipment_id AND first.revision=1) AS created_at,
+		revision.recorded_at AS updated_at
+	FROM equipment_revisions revision
+	WHERE revision.recorded_at<=:as_of
+	ORDER BY revision.equipment_id, revision.revision DESC
+) AS equipment`
 
-	if equipmentFilter.Statuses != nil {
-		switch len(equipmentFilter.Statuses) {
-			case 0:
-				break
-			case 1:
-				conditions = append(conditions, "status=" + string(figure(equipmentFilter.Statuses[0])))
-			default:
-				conditions = append(conditions, fmt.Sprintf("status IN (%s)", joinIntegralArray(equipmentFilter.Statuses)))
-		}
-	} else if equipmentFilter.NoStatuses != nil {
-		switch len(equipmentFilter.NoStatuses) {
-			case 0:
-				break
-			case 1:
-				conditions = append(conditions, "status<>" + string(figure(equipmentFilter.NoStatuses[0])))
-			default:
-				conditions = append(conditions, fmt.Sprintf("status NOT IN (%s)", joinIntegralArray(equipmentFilter.NoStatuses)))
-		}
-	}
+// equipmentWithConnectivity is the equipment table joined with the connectivity of the equipment which has ever been seen.
+const equipmentWithConnectivity string = `equipment LEFT JOIN equipment_connectivity connectivity ON connectivity.equipment_id=equipment.id`
 
-	if equipmentFilter.CreatedSince != nil {
-		conditions = append(conditions, "created_at>=:created_since")
-	}
-	if equipmentFilter.CreatedUntil != nil {
-		conditions = append(conditions, "created_at<=:created_until")
-	}
-	if equipmentFilter.UpdatedSince != nil {
-		conditions = append(conditions, "updated_at>=:updated_since")
-	}
-	if equipmentFilter.UpdatedUntil != nil {
-		conditions = append(conditions, "updated_at<=:updated_until")
+// equipmentSelection returns the selection of the equipment matching the filter (AsOf included), its conditions and their arguments.
+func equipmentSelection(equipmentFilter *dtos.EquipmentFilter) (string, []string, map[string]interface{}) {
+	query := `SELECT id, kind, status, parameters, created_at, updated_at, `
+	conditions := make([]string, 0, 10)
+	if equipmentFilter.AsOf == nil {
+		query += "connectivity.last_seen_at, connectivity.state AS connectivity FROM " + equipmentWithConnectivity
+	} else {
+		// The connectivity is not a part of the history
+		query += "NULL AS last_seen_at, NULL AS connectivity FROM " + historicalEquipment
+		conditions = append(conditions, "operation<>'" + string(model.Deleted) + "'")
 	}
-	var err error
+	filterConditions, arguments := equipmentConditions(equipmentFilter)
+	return query, append(conditions, filterConditions...), arguments
+}
+
+func whereClause(conditions []string) string {
 	if len(conditions) == 0 {
-		err = repository.db.Select(&equipmentModels, query)
-	} else {
-		preparedQuery, _ := repository.db.PrepareNamed(query + " WHERE " + strings.Join(conditions, " AND "))
-		err = preparedQuery.Select(
-			&equipmentModels,
-			map[string]interface{}{
-				"created_since":	equipmentFilter.CreatedSince,
-				"created_until":	equipmentFilter.CreatedUntil,
-				"updated_since":	equipmentFilter.UpdatedSince,
-				"updated_until":	equipmentFilter.UpdatedUntil,
-			},
-		)
+		return ""
 	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+func (repository *Equipment) selectEquipment(query string, arguments map[string]interface{}) ([]*dtos.EquipmentGet, error) {
+	preparedQuery, err := repository.db.PrepareNamed(query)
 	if err != nil {
 		return nil, err
 	}
+	defer preparedQuery.Close()
+	var equipmentModels []model.Equipment
+	if err = preparedQuery.Select(&equipmentModels, arguments); err != nil {
+		return nil, err
+	}
 	equipmentGets := make([]*dtos.EquipmentGet, 0, len(equipmentModels))
 	for _, equipmentModel := range equipmentModels {
 		equipmentGets = append(equipmentGets, dtos.EquipmentGetFromModel(equipmentModel))
@@ -101,61 +75,108 @@ func (repository *Equipment) List(equipmentFilter *dtos.EquipmentFilter) ([]*dto
 	return equipmentGets, nil
 }
 
-func (repository *Equipment) Create(equipmentCreate *dtos.EquipmentCreate) (uuid.UUID, error) {
-	for jsonifiedParameters, _ := json.Marshal(equipmentCreate.Parameters); ; {
-		// Generate a UUID version 6 (using a library):
-		id, err := uuid.NewV6()
-		if err == nil {
-			_, err = repository.db.NamedExec(
-				`INSERT INTO equipment (id, kind, status, parameters) VALUES (:id, :kind, :status, :parameters)`,
-				map[string]interface{}{
-					"id":         id,
-					"kind":       equipmentCreate.Kind,
-					"status":     model.Operational,
-					"parameters": jsonifiedParameters,
-				},
-			)
-			if err == nil {
-				return id, nil
-			}
-			if false {// TODO: Check id collision error
-				continue
-			}
+func (repository *Equipment) List(equipmentFilter *dtos.EquipmentFilter) ([]*dtos.EquipmentGet, error) {
+	query, conditions, arguments := equipmentSelection(equipmentFilter)
+	return repository.selectEquipment(query + whereClause(conditions), arguments)
+}
+
+// ListPage returns the page of the equipment matching the filter following the cursor of the page,
+// one more than the limit to tell whether the page is the last one, and the number of the matching equipment if requested (-1 otherwise).
+func (repository *Equipment) ListPage(equipmentQuery *dtos.EquipmentQuery) (int, []*dtos.EquipmentGet, error) {
+	query, conditions, arguments := equipmentSelection(&equipmentQuery.EquipmentFilter)
+	total := -1
+	if equipmentQuery.Total {
+		countQuery, err := repository.db.PrepareNamed(`SELECT COUNT(*) FROM (` + query + whereClause(conditions) + `) AS matching`)
+		if err != nil {
+			return 0, nil, err
+		}
+		defer countQuery.Close()
+		if err = countQuery.Get(&total, arguments); err != nil {
+			return 0, nil, err
+		}
+	}
+	comparison, direction := ">", "ASC"
+	if equipmentQuery.Descending() {
+		comparison, direction = "<", "DESC"
+	}
+	// The sort is validated against the columns
+	order := "id " + direction
+	if equipmentQuery.Sort != dtos.SortById {
+		order = string(equipmentQuery.Sort) + " " + direction + ", " + order
+	}
+	if cursor := equipmentQuery.After; cursor != nil {
+		arguments["cursor_id"] = cursor.Id
+		if equipmentQuery.Sort == dtos.SortById {
+			conditions = append(conditions, "id" + comparison + ":cursor_id")
+		} else {
+			arguments["cursor_key"], _ = cursor.KeyValue()
+			conditions = append(conditions, "(" + string(equipmentQuery.Sort) + ", id)" + comparison + "(:cursor_key, :cursor_id)")
 		}
+	}
+	arguments["limit"] = equipmentQuery.Limit + 1
+	equipmentGets, err := repository.selectEquipment(query + whereClause(conditions) + " ORDER BY " + order + " LIMIT :limit", arguments)
+	return total, equipmentGets, err
+}
+
+func (repository *Equipment) Create(equipmentCreate *dtos.EquipmentCreate) (uuid.UUID, error) {
+	jsonifiedParameters, _ := json.Marshal(equipmentCreate.Parameters)
+	// Generate a UUID version 6 (using a library):
+	id, err := uuid.NewV6()
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	aggregate := model.NewEquipmentAggregate(id)
+	if err = aggregate.Register(equipmentCreate.Kind, jsonifiedParameters, time.Now()); err != nil {
+		return uuid.UUID{}, err
+	}
+	if err = inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		return saveAggregate(tx, aggregate)
+	}); err != nil {
 		return uuid.UUID{}, err
 	}
+	return id, nil
 }
 
-func (repository *Equipment) Update(equipmentUpdate *dtos.EquipmentUpdate) (bool, error) {
-	var set string
-	var jsonifiedParameters []byte
-	if equipmentUpdate.Parameters == nil {
-		if equipmentUpdate.Status == nil {
-			return false, nil // Nothing to update
+// Update applies equipmentUpdate provided the equipment still has fromStatus;
+// the changes are stored as events of the equipment aggregate and projected within the same transaction.
+func (repository *Equipment) Update(equipmentUpdate *dtos.EquipmentUpdate, fromStatus model.OperationalStatus) (bool, error) {
+	if equipmentUpdate.Parameters == nil && equipmentUpdate.Status == nil {
+		return false, nil // Nothing to update
+	}
+	var updated bool
+	err := inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		aggregate, err := loadAggregate(tx, equipmentUpdate.Id)
+		if err != nil || !aggregate.Exists() {
+			return err
 		}
-		set = "status=:status"
-	} else {
-		if equipmentUpdate.Status == nil {
-			set = "parameters=:parameters"
-		} else {
-			set = "status=:status, parameters=:parameters"
+		if aggregate.State.Status != fromStatus {
+			return fmt.Errorf("%w: status of equipment #%v has been changed concurrently", model.ErrConflict, equipmentUpdate.Id)
 		}
-		jsonifiedParameters, _ = json.Marshal(*equipmentUpdate.Parameters)
-	}
-	return checkAffect(repository.db.NamedExec(
-		fmt.Sprintf("UPDATE equipment SET %s, updated_at=:updated_at WHERE id=:id", set),
-		map[string]interface{}{
-			"id":			equipmentUpdate.Id,
-			"status": 		equipmentUpdate.Status,
-			"parameters":	jsonifiedParameters,
-			"updated_at":	time.Now(),
-		},
-	))
+		now := time.Now()
+		if equipmentUpdate.Status != nil {
+			err = aggregate.ChangeStatus(*equipmentUpdate.Status, equipmentUpdate.Reason, equipmentUpdate.Actor, now)
+		}
+		if err == nil && equipmentUpdate.Parameters != nil {
+			jsonifiedParameters, _ := json.Marshal(*equipmentUpdate.Parameters)
+			err = aggregate.ChangeParameters(jsonifiedParameters, now)
+		}
+		if err == nil {
+			err = saveAggregate(tx, aggregate)
+		}
+		updated = err == nil
+		return err
+	})
+	return updated, err
 }
 
 func (repository *Equipment) FindById(id uuid.UUID) (*dtos.EquipmentGet, error) {
 	var equipmentModel model.Equipment
-	err := repository.db.Get(&equipmentModel, `SELECT id, kind, status, parameters, created_at, updated_at FROM equipment WHERE id=$1`, id)
+	err := repository.db.Get(
+		&equipmentModel,
+		`SELECT id, kind, status, parameters, created_at, updated_at, connectivity.last_seen_at, connectivity.state AS connectivity
+		FROM ` + equipmentWithConnectivity + ` WHERE id=$1`,
+		id,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -166,6 +187,138 @@ func (repository *Equipment) FindById(id uuid.UUID) (*dtos.EquipmentGet, error)
 	return dtos.EquipmentGetFromModel(equipmentModel), nil
 }
 
+// FindByIdAsOf restores the state of the equipment at given instant from its revisions.
+func (repository *Equipment) FindByIdAsOf(id uuid.UUID, asOf time.Time) (*dtos.EquipmentGet, error) {
+	var revisionModel model.Revision
+	err := repository.db.Get(
+		&revisionModel,
+		`SELECT equipment_id, revision, operation, kind, status, parameters, recorded_at FROM equipment_revisions
+		WHERE equipment_id=$1 AND recorded_at<=$2 ORDER BY revision DESC LIMIT 1`,
+		id, asOf,
+	)
+	if err != nil {
+		return nil, err
+	} else if revisionModel.Operation == model.Deleted {
+		return nil, sql.ErrNoRows
+	}
+	var createdAt time.Time
+	if err = repository.db.Get(&createdAt, `SELECT recorded_at FROM equipment_revisions WHERE equipment_id=$1 AND revision=1`, id); err != nil {
+		return nil, err
+	}
+	return dtos.EquipmentGetFromModel(model.Equipment {
+		Id:			revisionModel.EquipmentId,
+		Kind:		revisionModel.Kind,
+		Status:		revisionModel.Status,
+		Parameters:	revisionModel.Parameters,
+		CreatedAt:	createdAt,
+		UpdatedAt:	revisionModel.RecordedAt,
+	}), nil
+}
+
+// RemoveById deletes the equipment keeping its last state as the revision.
 func (repository *Equipment) RemoveById(id uuid.UUID) (bool, error) {
-	return checkAffect(repository.db.Exec(`DELETE FROM equipment WHERE id=$1`, id))
+	var removed bool
+	err := inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		aggregate, err := loadAggregate(tx, id)
+		if err != nil || !aggregate.Exists() {
+			return err
+		}
+		if err = aggregate.Remove(time.Now()); err == nil {
+			err = saveAggregate(tx, aggregate)
+		}
+		removed = err == nil
+		return err
+	})
+	return removed, err
+}
+
+func insertTransition(tx *sqlx.Tx, transition *model.Transition) error {
+	_, err := tx.NamedExec(
+		`INSERT INTO equipment_transitions (equipment_id, from_status, to_status, reason, actor, created_at)
+		VALUES (:equipment_id, :from_status, :to_status, :reason, :actor, :created_at)`,
+		transition,
+	)
+	return err
+}
+
+// ListTransitions returns the status history of the equipment, the oldest transition first.
+func (repository *Equipment) ListTransitions(id uuid.UUID) ([]*dtos.TransitionGet, error) {
+	var transitionModels []model.Transition
+	err := repository.db.Select(
+		&transitionModels,
+		`SELECT id, equipment_id, from_status, to_status, reason, actor, created_at FROM equipment_transitions
+		WHERE equipment_id=$1 ORDER BY created_at, id`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	transitionGets := make([]*dtos.TransitionGet, 0, len(transitionModels))
+	for _, transitionModel := range transitionModels {
+		transitionGets = append(transitionGets, dtos.TransitionGetFromModel(transitionModel))
+	}
+	return transitionGets, nil
+}
+
+// ListTransitionsWithin returns the transitions of the equipment setting the status within [from, to),
+// that is the transitions within the range and the last one before it, grouped by equipment in order of time.
+func (repository *Equipment) ListTransitionsWithin(ids []uuid.UUID, from, to time.Time) (map[uuid.UUID][]model.Transition, error) {
+	var transitionModels []model.Transition
+	err := repository.db.Select(
+		&transitionModels,
+		`SELECT id, equipment_id, from_status, to_status, reason, actor, created_at FROM (
+			SELECT DISTINCT ON (equipment_id) * FROM equipment_transitions
+			WHERE equipment_id=ANY($1) AND created_at<$2 ORDER BY equipment_id, created_at DESC, id DESC
+		) AS initial
+		UNION ALL
+		SELECT id, equipment_id, from_status, to_status, reason, actor, created_at FROM equipment_transitions
+		WHERE equipment_id=ANY($1) AND created_at>=$2 AND created_at<$3
+		ORDER BY equipment_id, created_at, id`,
+		pq.Array(ids), from, to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	transitions := make(map[uuid.UUID][]model.Transition, len(ids))
+	for _, transitionModel := range transitionModels {
+		transitions[transitionModel.EquipmentId] = append(transitions[transitionModel.EquipmentId], transitionModel)
+	}
+	return transitions, nil
+}
+
+// ListLastMaintained returns the time of the last completion of the maintenance (the transition from UnderMaintenance to Operational)
+// of the equipment which has ever been maintained.
+func (repository *Equipment) ListLastMaintained(ids []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	var transitionModels []model.Transition
+	err := repository.db.Select(
+		&transitionModels,
+		`SELECT DISTINCT ON (equipment_id) id, equipment_id, from_status, to_status, reason, actor, created_at FROM equipment_transitions
+		WHERE equipment_id=ANY($1) AND from_status=$2 AND to_status=$3 ORDER BY equipment_id, created_at DESC, id DESC`,
+		pq.Array(ids), model.UnderMaintenance, model.Operational,
+	)
+	if err != nil {
+		return nil, err
+	}
+	lastMaintained := make(map[uuid.UUID]time.Time, len(transitionModels))
+	for _, transitionModel := range transitionModels {
+		lastMaintained[transitionModel.EquipmentId] = transitionModel.CreatedAt
+	}
+	return lastMaintained, nil
+}
+
+// ListRevisions returns the total number of revisions and the page of them from the newest
+// plus one more revision preceding the page, if any.
+func (repository *Equipment) ListRevisions(id uuid.UUID, historyPage *dtos.HistoryPage) (int, []model.Revision, error) {
+	var total int
+	if err := repository.db.Get(&total, `SELECT COUNT(*) FROM equipment_revisions WHERE equipment_id=$1`, id); err != nil {
+		return 0, nil, err
+	}
+	var revisionModels []model.Revision
+	err := repository.db.Select(
+		&revisionModels,
+		`SELECT equipment_id, revision, operation, kind, status, parameters, recorded_at FROM equipment_revisions
+		WHERE equipment_id=$1 ORDER BY revision DESC LIMIT $2 OFFSET $3`,
+		id, historyPage.PerPage + 1, (historyPage.Page - 1) * historyPage.PerPage,
+	)
+	return total, revisionModels, err
 }
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	eventColumns string = `position, aggregate_id, version, type, payload, occurred_at`
+	rebuildBatchSize = 1000
+)
+
+// loadAggregate replays the events of the equipment; the aggregate of unknown equipment is not registered.
+func loadAggregate(queryer sqlx.Queryer, id uuid.UUID) (*model.EquipmentAggregate, error) {
+	var events []model.Event
+	err := sqlx.Select(
+		queryer, &events,
+		`SELECT ` + eventColumns + ` FROM equipment_events WHERE aggregate_id=$1 ORDER BY version`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	aggregate := model.NewEquipmentAggregate(id)
+	for i := range events {
+		if err = aggregate.Apply(&events[i]); err != nil {
+			return nil, err
+		}
+	}
+	return aggregate, nil
+}
+
+// saveAggregate appends the events raised by the aggregate, updates the projections,
+// enqueues the changes to the outbox and the deliveries to the webhooks within the transaction;
+// the append is optimistic: if another transaction has already stored an event with the same version,
+// the error wraps model.ErrConflict.
+func saveAggregate(tx *sqlx.Tx, aggregate *model.EquipmentAggregate) error {
+	changes := aggregate.Changes()
+	for i := range changes {
+		event := &changes[i].Event
+		err := tx.QueryRow(
+			`INSERT INTO equipment_events (aggregate_id, version, type, payload, occurred_at)
+			VALUES ($1, $2, $3, $4, $5) RETURNING position`,
+			event.AggregateId, event.Version, event.Type, event.Payload, event.OccurredAt,
+		).Scan(&event.Position)
+		var pqError *pq.Error
+		if errors.As(err, &pqError) && pqError.Code == uniqueViolation {
+			return fmt.Errorf("%w: equipment #%v has been modified concurrently", model.ErrConflict, event.AggregateId)
+		} else if err != nil {
+			return err
+		}
+		if err = project(tx, &changes[i]); err != nil {
+			return err
+		}
+		if err = insertOutboxMessage(tx, &changes[i]); err != nil {
+			return err
+		}
+		if err = enqueueDeliveries(tx, &changes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// project applies the event to the read models: the equipment table, its revisions and status transitions.
+func project(tx *sqlx.Tx, change *model.AggregateChange) error {
+	event, state := &change.Event, &change.State
+	var err error
+	var operation model.RevisionOperation
+	switch event.Type {
+		case model.EquipmentRegistered:
+			operation = model.Created
+			_, err = tx.Exec(
+				`INSERT INTO equipment (id, kind, status, parameters, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $5)`,
+				state.Id, state.Kind, state.Status, state.Parameters, event.OccurredAt,
+			)
+			if err == nil {
+				err = insertTransition(tx, &model.Transition {
+					EquipmentId:	state.Id,
+					ToStatus:		state.Status,
+					CreatedAt:		event.OccurredAt,
+				})
+			}
+		case model.StatusChanged:
+			operation = model.Updated
+			var payload model.StatusChangedPayload
+			if err = json.Unmarshal(event.Payload, &payload); err != nil {
+				return err
+			}
+			_, err = tx.Exec(`UPDATE equipment SET status=$2, updated_at=$3 WHERE id=$1`, state.Id, state.Status, event.OccurredAt)
+			if err == nil {
+				err = insertTransition(tx, &model.Transition {
+					EquipmentId:	state.Id,
+					FromStatus:		&payload.From,
+					ToStatus:		payload.To,
+					Reason:			payload.Reason,
+					Actor:			payload.Actor,
+					CreatedAt:		event.OccurredAt,
+				})
+			}
+		case model.ParametersChanged:
+			operation = model.Updated
+			_, err = tx.Exec(`UPDATE equipment SET parameters=$2, updated_at=$3 WHERE id=$1`, state.Id, state.Parameters, event.OccurredAt)
+		case model.EquipmentRevised:
+			operation = model.Updated
+			var payload model.EquipmentRevisedPayload
+			if err = json.Unmarshal(event.Payload, &payload); err != nil {
+				return err
+			}
+			_, err = tx.Exec(
+				`UPDATE equipment SET status=$2, parameters=$3, updated_at=$4 WHERE id=$1`,
+				state.Id, state.Status, state.Parameters, event.OccurredAt,
+			)
+			if err == nil && payload.From != payload.Status {
+				err = insertTransition(tx, &model.Transition {
+					EquipmentId:	state.Id,
+					FromStatus:		&payload.From,
+					ToStatus:		payload.Status,
+					Reason:			payload.Reason,
+					Actor:			payload.Actor,
+					CreatedAt:		event.OccurredAt,
+				})
+			}
+		case model.EquipmentRemoved:
+			operation = model.Deleted
+			_, err = tx.Exec(`DELETE FROM equipment WHERE id=$1`, state.Id)
+		default:
+			return fmt.Errorf("Unknown event type: `%s`", event.Type)
+	}
+	if err != nil {
+		return wrapConflict(err)
+	}
+	_, err = tx.NamedExec(
+		`INSERT INTO equipment_revisions (equipment_id, revision, operation, kind, status, parameters, recorded_at)
+		VALUES (:equipment_id, :revision, :operation, :kind, :status, :parameters, :recorded_at)`,
+		&model.Revision {
+			EquipmentId:	state.Id,
+			Revision:		event.Version,
+			Operation:		operation,
+			Kind:			state.Kind,
+			Status:			state.Status,
+			Parameters:		state.Parameters,
+			RecordedAt:		event.OccurredAt,
+		},
+	)
+	return err
+}
+
+// RebuildProjections recreates the contents of the equipment table, its revisions and status transitions
+// replaying all the stored events in order.
+func (repository *Equipment) RebuildProjections() error {
+	return inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		_, err := tx.Exec(`TRUNCATE equipment, equipment_revisions, equipment_transitions RESTART IDENTITY`)
+		if err != nil {
+			return err
+		}
+		aggregates := make(map[uuid.UUID]*model.EquipmentAggregate)
+		for position := int64(0); ; {
+			var events []model.Event
+			err = tx.Select(
+				&events,
+				`SELECT ` + eventColumns + ` FROM equipment_events WHERE position>$1 ORDER BY position LIMIT $2`,
+				position, rebuildBatchSize,
+			)
+			if err != nil || len(events) == 0 {
+				return err
+			}
+			for i := range events {
+				event := &events[i]
+				aggregate, ok := aggregates[event.AggregateId]
+				if !ok {
+					aggregate = model.NewEquipmentAggregate(event.AggregateId)
+					aggregates[event.AggregateId] = aggregate
+				}
+				if err = aggregate.Apply(event); err != nil {
+					return err
+				}
+				if err = project(tx, &model.AggregateChange{Event: *event, State: aggregate.State}); err != nil {
+					return err
+				}
+			}
+			position = events[len(events) - 1].Position
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/rsql"
+)
+
+// filterColumns are the columns of equipmentWithConnectivity (or historicalEquipment except the connectivity) compared by the fields of the filter.
+var filterColumns = map[string]string {
+	"id":			"id",
+	"kind":			"kind",
+	"status":		"status",
+	"created_at":	"created_at",
+	"updated_at":	"updated_at",
+	"last_seen_at":	"connectivity.last_seen_at",
+	"connectivity":	"COALESCE(connectivity.state, '" + string(model.Unknown) + "')",
+}
+
+var comparisonOperators = map[rsql.Operator]string {
+	rsql.Eq:	"=",
+	rsql.Ne:	"<>",
+	rsql.Lt:	"<",
+	rsql.Le:	"<=",
+	rsql.Gt:	">",
+	rsql.Ge:	">=",
+}
+
+// filterCompiler compiles the expression tree validated by dtos into the SQL condition
+// binding the values to the named parameters `filter_{n}` of the arguments.
+type filterCompiler struct {
+	arguments	map[string]interface{}
+	count		int
+}
+
+func (compiler *filterCompiler) bind(value interface{}) string {
+	name := fmt.Sprintf("filter_%d", compiler.count)
+	compiler.count++
+	compiler.arguments[name] = value
+	return ":" + name
+}
+
+func (compiler *filterCompiler) compile(node rsql.Node) string {
+	switch node := node.(type) {
+		case *rsql.And:
+			return compiler.join(node.Operands, " AND ")
+		case *rsql.Or:
+			return compiler.join(node.Operands, " OR ")
+		case *rsql.Not:
+			// The comparisons of NULL (missing parameters, never seen equipment) are false, not unknown
+			return "NOT COALESCE(" + compiler.compile(node.Operand) + ", false)"
+		case *rsql.Comparison:
+			if name, isParameter := strings.CutPrefix(node.Selector, "param."); isParameter {
+				return compiler.parameter(name, node)
+			}
+			return compiler.field(filterColumns[node.Selector], node)
+	}
+	return "true"
+}
+
+func (compiler *filterCompiler) join(operands []rsql.Node, operator string) string {
+	conditions := make([]string, 0, len(operands))
+	for _, operand := range operands {
+		conditions = append(conditions, compiler.compile(operand))
+	}
+	return "(" + strings.Join(conditions, operator) + ")"
+}
+
+func (compiler *filterCompiler) field(column string, comparison *rsql.Comparison) string {
+	switch comparison.Operator {
+		case rsql.In:
+			return column + "=ANY(" + compiler.bindList(comparison.Values) + ")"
+		case rsql.Out:
+			return column + "<>ALL(" + compiler.bindList(comparison.Values) + ")"
+	}
+	return column + comparisonOperators[comparison.Operator] + compiler.bind(comparison.Values[0])
+}
+
+// bindList binds the values of the same field as the typed array.
+func (compiler *filterCompiler) bindList(values []interface{}) string {
+	integers := make(pq.Int64Array, 0, len(values))
+	texts := make(pq.StringArray, 0, len(values))
+	cast := "text[]"
+	for _, value := range values {
+		switch value := value.(type) {
+			case model.EquipmentKind:
+				integers = append(integers, int64(value))
+			case model.OperationalStatus:
+				integers = append(integers, int64(value))
+			case model.Connectivity:
+				texts = append(texts, string(value))
+			default:	// uuid.UUID
+				texts, cast = append(texts, fmt.Sprint(value)), "uuid[]"
+		}
+	}
+	if len(integers) > 0 {
+		return compiler.bind(integers)
+	}
+	return "CAST(" + compiler.bind(texts) + " AS " + cast + ")"
+}
+
+// parameter compiles the comparison of the parameter. The name of the parameter is validated by dtos, so it is embedded
+// as the key matching the expression indexes; the equality and the presence use the GIN index on the parameters.
+// jsonb orders the values of different types by the type, so the type is checked for the ordering comparisons;
+// the strings are ordered by their bytes (COLLATE "C"), the way dtos.Evaluate orders them, not by the collation of the database.
+func (compiler *filterCompiler) parameter(name string, comparison *rsql.Comparison) string {
+	field := "parameters->" + pq.QuoteLiteral(name)
+	switch comparison.Operator {
+		case rsql.Exists:
+			if comparison.Values[0].(bool) {
+				return "parameters ? " + compiler.bind(name)
+			}
+			return "NOT parameters ? " + compiler.bind(name)
+		case rsql.Eq:
+			return "parameters @> CAST(" + compiler.bind(jsonified(map[string]interface{}{name: comparison.Values[0]})) + " AS jsonb)"
+		case rsql.In:
+			conditions := make([]string, 0, len(comparison.Values))
+			for _, value := range comparison.Values {
+				conditions = append(conditions, "parameters @> CAST(" + compiler.bind(jsonified(map[string]interface{}{name: value})) + " AS jsonb)")
+			}
+			return "(" + strings.Join(conditions, " OR ") + ")"
+		case rsql.Ne, rsql.Out:
+			conditions := make([]string, 0, len(comparison.Values) + 1)
+			conditions = append(conditions, "jsonb_typeof(" + field + ")<>'null'")
+			for _, value := range comparison.Values {
+				conditions = append(conditions, field + "<>CAST(" + compiler.bind(jsonified(value)) + " AS jsonb)")
+			}
+			return "(" + strings.Join(conditions, " AND ") + ")"
+	}
+	if text, isString := comparison.Values[0].(string); isString {
+		return "(jsonb_typeof(" + field + ")='string' AND (parameters->>" + pq.QuoteLiteral(name) + ") COLLATE \"C\"" +
+			comparisonOperators[comparison.Operator] + "CAST(" + compiler.bind(text) + " AS text))"
+	}
+	return "(jsonb_typeof(" + field + ")='number' AND " +
+		field + comparisonOperators[comparison.Operator] + "CAST(" + compiler.bind(jsonified(comparison.Values[0])) + " AS jsonb))"
+}
+
+func jsonified(value interface{}) string {
+	jsonified, _ := json.Marshal(value)
+	return string(jsonified)
+}
+
+// equipmentConditions returns the SQL conditions of the filter (except AsOf) on the columns of equipmentWithConnectivity
+// compiled from its expression tree along with the named parameters they bind and :as_of.
+func equipmentConditions(equipmentFilter *dtos.EquipmentFilter) ([]string, map[string]interface{}) {
+	compiler := filterCompiler{arguments: map[string]interface{}{"as_of": equipmentFilter.AsOf}}
+	condition := equipmentFilter.Condition()
+	if condition == nil {
+		return nil, compiler.arguments
+	}
+	return []string{compiler.compile(condition)}, compiler.arguments
+}
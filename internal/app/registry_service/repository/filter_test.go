@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+// TestFilterCompilerMissingValues checks that the condition compiled for the repository and dtos.Evaluate
+// agree on the comparisons of the missing values: the SQL comparisons of NULL are unknown, which NOT keeps unknown,
+// so the compiled NOT takes the unknown operand as false, the way Evaluate takes those comparisons.
+func TestFilterCompilerMissingValues(t *testing.T) {
+	seenAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	unseen := dtos.EquipmentGet {
+		Status:		model.Operational,
+		Parameters:	map[string]interface{}{"speed": 5.0, "name": "lathe", "serial": "0042"},
+	}
+	seen := unseen
+	seen.LastSeenAt, seen.Connectivity = &seenAt, model.Online
+	for _, test := range []struct {
+		filter		string
+		equipment	*dtos.EquipmentGet
+		condition	string
+		matches		bool
+	}{
+		// last_seen_at is NULL for the equipment never seen
+		{"last_seen_at>2024-01-01", &unseen, "connectivity.last_seen_at>:filter_0", false},
+		{"!(last_seen_at>2024-01-01)", &unseen, "NOT COALESCE(connectivity.last_seen_at>:filter_0, false)", true},
+		{"!(last_seen_at>2024-01-01)", &seen, "NOT COALESCE(connectivity.last_seen_at>:filter_0, false)", false},
+		{"!(last_seen_at<=2024-01-01)", &seen, "NOT COALESCE(connectivity.last_seen_at<=:filter_0, false)", true},
+		{"!!(last_seen_at>2024-01-01)", &unseen, "NOT COALESCE(NOT COALESCE(connectivity.last_seen_at>:filter_0, false), false)", false},
+		// NULL AND true is NULL
+		{"!(last_seen_at>2024-01-01;status==operational)", &unseen,
+			"NOT COALESCE((connectivity.last_seen_at>:filter_0 AND status=:filter_1), false)", true},
+		// NULL OR true is true
+		{"!(last_seen_at>2024-01-01,status==operational)", &unseen,
+			"NOT COALESCE((connectivity.last_seen_at>:filter_0 OR status=:filter_1), false)", false},
+		{"!(last_seen_at>2024-01-01);status==operational", &unseen,
+			"(NOT COALESCE(connectivity.last_seen_at>:filter_0, false) AND status=:filter_1)", true},
+		{"!(connectivity==online)", &unseen, "NOT COALESCE(COALESCE(connectivity.state, 'unknown')=:filter_0, false)", true},
+		// The missing parameter is NULL for the ordering and the inequality comparisons, the containment is false
+		{"param.torque==10", &unseen, "parameters @> CAST(:filter_0 AS jsonb)", false},
+		{"!(param.torque==10)", &unseen, "NOT COALESCE(parameters @> CAST(:filter_0 AS jsonb), false)", true},
+		{"param.torque!=10", &unseen,
+			"(jsonb_typeof(parameters->'torque')<>'null' AND parameters->'torque'<>CAST(:filter_0 AS jsonb))", false},
+		{"!(param.torque!=10)", &unseen,
+			"NOT COALESCE((jsonb_typeof(parameters->'torque')<>'null' AND parameters->'torque'<>CAST(:filter_0 AS jsonb)), false)", true},
+		{"!(param.torque=out=(1,2))", &unseen,
+			"NOT COALESCE((jsonb_typeof(parameters->'torque')<>'null' AND parameters->'torque'<>CAST(:filter_0 AS jsonb) AND " +
+			"parameters->'torque'<>CAST(:filter_1 AS jsonb)), false)", true},
+		{"!(param.torque<10)", &unseen,
+			"NOT COALESCE((jsonb_typeof(parameters->'torque')='number' AND parameters->'torque'<CAST(:filter_0 AS jsonb)), false)", true},
+		{"!(param.torque>1,last_seen_at>2024-01-01)", &unseen,
+			"NOT COALESCE(((jsonb_typeof(parameters->'torque')='number' AND parameters->'torque'>CAST(:filter_0 AS jsonb)) OR " +
+			"connectivity.last_seen_at>:filter_1), false)", true},
+		{"param.torque=exists=false", &unseen, "NOT parameters ? :filter_0", true},
+		{"!(param.torque=exists=true)", &unseen, "NOT COALESCE(parameters ? :filter_0, false)", true},
+		// The parameters of other types are not comparable
+		{"!(param.name<m)", &unseen,
+			"NOT COALESCE((jsonb_typeof(parameters->'name')='string' AND (parameters->>'name') COLLATE \"C\"<CAST(:filter_0 AS text)), false)", false},
+		{"!(param.name<10)", &unseen,
+			"NOT COALESCE((jsonb_typeof(parameters->'name')='number' AND parameters->'name'<CAST(:filter_0 AS jsonb)), false)", true},
+		// The strings are ordered by their bytes, the upper case before the lower case
+		{"param.name>Zebra", &unseen,
+			"(jsonb_typeof(parameters->'name')='string' AND (parameters->>'name') COLLATE \"C\">CAST(:filter_0 AS text))", true},
+		{"param.name<=Lathe", &unseen,
+			"(jsonb_typeof(parameters->'name')='string' AND (parameters->>'name') COLLATE \"C\"<=CAST(:filter_0 AS text))", false},
+		// The quoted value is a string
+		{`param.serial=="0042"`, &unseen, "parameters @> CAST(:filter_0 AS jsonb)", true},
+		{"param.serial==0042", &unseen, "parameters @> CAST(:filter_0 AS jsonb)", false},
+		{`param.speed=in=("5",6)`, &unseen,
+			"(parameters @> CAST(:filter_0 AS jsonb) OR parameters @> CAST(:filter_1 AS jsonb))", false},
+		{"!(param.speed=in=(5,6))", &unseen,
+			"NOT COALESCE((parameters @> CAST(:filter_0 AS jsonb) OR parameters @> CAST(:filter_1 AS jsonb)), false)", false},
+	} {
+		node, err := dtos.ParseFilter(test.filter)
+		if err != nil {
+			t.Errorf("%s: %v", test.filter, err)
+			continue
+		}
+		compiler := filterCompiler{arguments: make(map[string]interface{})}
+		if condition := compiler.compile(node); condition != test.condition {
+			t.Errorf("%s: condition %s, expected %s", test.filter, condition, test.condition)
+		}
+		if matches := dtos.Evaluate(node, test.equipment); matches != test.matches {
+			t.Errorf("%s: evaluated %v, expected %v as the condition", test.filter, matches, test.matches)
+		}
+	}
+}
@@ -2,27 +2,17 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
 )
 
-func figure[T model.EquipmentKind|model.OperationalStatus](f T) byte {
-	return byte(f + '0')
-} 
-
-func joinIntegralArray[T model.EquipmentKind|model.OperationalStatus](intArray []T) string {
-	const (
-		zero byte	= '0'
-		separator	= ','
-	)
-	l := len(intArray)
-	buffer := make([]byte, l + l - 1)
-	buffer[0] = figure(intArray[0])
-	for i := 1; i < l; i++ {
-		buffer[i + i - 1] = separator
-		buffer[i + i] = figure(intArray[i])
-	}
-	return string(buffer)
-}
+const (
+	uniqueViolation pq.ErrorCode = "23505"
+	foreignKeyViolation pq.ErrorCode = "23503"
+)
 
 func checkAffect(result sql.Result, err error) (bool, error) {
 	if err == nil {
@@ -33,3 +23,25 @@ func checkAffect(result sql.Result, err error) (bool, error) {
 	}
 	return false, err
 }
+
+// wrapConflict marks violations of unique and foreign key constraints with model.ErrConflict.
+func wrapConflict(err error) error {
+	var pqError *pq.Error
+	if errors.As(err, &pqError) && (pqError.Code == uniqueViolation || pqError.Code == foreignKeyViolation) {
+		return fmt.Errorf("%w: %s", model.ErrConflict, pqError.Message)
+	}
+	return err
+}
+
+// inTransaction runs action within the transaction which is committed if action succeeds and rolled back otherwise.
+func inTransaction(db *sqlx.DB, action func(tx *sqlx.Tx) error) error {
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	if err = action(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
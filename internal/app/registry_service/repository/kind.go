@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"github.com/jmoiron/sqlx"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const kindColumns string = `id, name, description, parameter_schema, icon, metrics, heartbeat_interval_seconds, created_at, updated_at`
+
+type Kind struct {
+	db *sqlx.DB
+}
+
+func NewKind(db *sqlx.DB) Kind {
+	return Kind{db: db}
+}
+
+func (repository *Kind) List() ([]*dtos.KindGet, error) {
+	var kindModels []model.Kind
+	if err := repository.db.Select(&kindModels, `SELECT ` + kindColumns + ` FROM equipment_kinds ORDER BY id`); err != nil {
+		return nil, err
+	}
+	kindGets := make([]*dtos.KindGet, 0, len(kindModels))
+	for _, kindModel := range kindModels {
+		kindGets = append(kindGets, dtos.KindGetFromModel(kindModel))
+	}
+	return kindGets, nil
+}
+
+func (repository *Kind) Create(kindCreate *dtos.KindCreate) (model.EquipmentKind, error) {
+	var id model.EquipmentKind
+	metrics, _ := json.Marshal(kindCreate.Metrics)
+	if kindCreate.Metrics == nil {
+		metrics = []byte("[]")
+	}
+	err := repository.db.QueryRow(
+		`INSERT INTO equipment_kinds (name, description, parameter_schema, icon, metrics, heartbeat_interval_seconds)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, 60)) RETURNING id`,
+		kindCreate.Name, kindCreate.Description, []byte(kindCreate.ParameterSchema), kindCreate.Icon, metrics,
+		kindCreate.HeartbeatIntervalSeconds(),
+	).Scan(&id)
+	return id, wrapConflict(err)
+}
+
+func (repository *Kind) Update(kindUpdate *dtos.KindUpdate) (bool, error) {
+	set := make([]string, 0, 5)
+	arguments := map[string]interface{}{
+		"id":			kindUpdate.Id,
+		"updated_at":	time.Now(),
+	}
+	if kindUpdate.Name != nil {
+		set = append(set, "name=:name")
+		arguments["name"] = *kindUpdate.Name
+	}
+	if kindUpdate.Description != nil {
+		set = append(set, "description=:description")
+		arguments["description"] = *kindUpdate.Description
+	}
+	if kindUpdate.ParameterSchema != nil {
+		set = append(set, "parameter_schema=:parameter_schema")
+		arguments["parameter_schema"] = []byte(*kindUpdate.ParameterSchema)
+	}
+	if kindUpdate.Icon != nil {
+		set = append(set, "icon=:icon")
+		arguments["icon"] = *kindUpdate.Icon
+	}
+	if kindUpdate.Metrics != nil {
+		set = append(set, "metrics=:metrics")
+		metrics, _ := json.Marshal(*kindUpdate.Metrics)
+		if *kindUpdate.Metrics == nil {
+			metrics = []byte("[]")
+		}
+		arguments["metrics"] = metrics
+	}
+	if heartbeatInterval := kindUpdate.HeartbeatIntervalSeconds(); heartbeatInterval != nil {
+		set = append(set, "heartbeat_interval_seconds=:heartbeat_interval_seconds")
+		arguments["heartbeat_interval_seconds"] = *heartbeatInterval
+	}
+	if len(set) == 0 {
+		return false, nil // Nothing to update
+	}
+	updated, err := checkAffect(repository.db.NamedExec(
+		`UPDATE equipment_kinds SET ` + strings.Join(set, ", ") + `, updated_at=:updated_at WHERE id=:id`,
+		arguments,
+	))
+	return updated, wrapConflict(err)
+}
+
+func (repository *Kind) FindById(id model.EquipmentKind) (*dtos.KindGet, error) {
+	var kindModel model.Kind
+	if err := repository.db.Get(&kindModel, `SELECT ` + kindColumns + ` FROM equipment_kinds WHERE id=$1`, id); err != nil {
+		return nil, err
+	}
+	return dtos.KindGetFromModel(kindModel), nil
+}
+
+func (repository *Kind) FindByName(name string) (*dtos.KindGet, error) {
+	var kindModel model.Kind
+	if err := repository.db.Get(&kindModel, `SELECT ` + kindColumns + ` FROM equipment_kinds WHERE lower(name)=lower($1)`, name); err != nil {
+		return nil, err
+	}
+	return dtos.KindGetFromModel(kindModel), nil
+}
+
+// RemoveById fails with model.ErrConflict while any equipment of the kind exists or is kept in the event store
+// (the events and the revisions of the removed equipment keep the kind the projections are rebuilt with)
+// and while any spare part fits the kind.
+func (repository *Kind) RemoveById(id model.EquipmentKind) (bool, error) {
+	removed := false
+	err := inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		// The kind is locked so no equipment of it can be registered before it is removed
+		var locked model.EquipmentKind
+		if err := tx.Get(&locked, `SELECT id FROM equipment_kinds WHERE id=$1 FOR UPDATE`, id); errors.Is(err, sql.ErrNoRows) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var references struct {
+			History	bool	`db:"history"`
+			Parts	bool	`db:"parts"`
+		}
+		if err := tx.Get(
+			&references,
+			`SELECT
+				EXISTS (SELECT 1 FROM equipment_revisions WHERE kind=$1)
+				OR EXISTS (SELECT 1 FROM equipment_events WHERE type=$2 AND payload @> jsonb_build_object('kind', CAST($1 AS integer))) AS history,
+				EXISTS (SELECT 1 FROM spare_parts WHERE kinds @> ARRAY[CAST($1 AS smallint)]) AS parts`,
+			id, model.EquipmentRegistered,
+		); err != nil {
+			return err
+		}
+		if references.History {
+			return fmt.Errorf("%w: equipment kind #%d is referenced by the history of equipment", model.ErrConflict, id)
+		}
+		if references.Parts {
+			return fmt.Errorf("%w: equipment kind #%d is referenced by spare parts", model.ErrConflict, id)
+		}
+		var err error
+		removed, err = checkAffect(tx.Exec(`DELETE FROM equipment_kinds WHERE id=$1`, id))
+		return wrapConflict(err)
+	})
+	return removed, err
+}
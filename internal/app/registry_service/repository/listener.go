@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"log"
+	"strconv"
+	"time"
+	"github.com/lib/pq"
+)
+
+// changesChannel is notified with the id of the outbox message by each transaction storing a change.
+const changesChannel string = "equipment_changes"
+
+// ChangeListener receives ids of the outbox messages committed by any replica via LISTEN/NOTIFY.
+type ChangeListener struct {
+	listener	*pq.Listener
+	ids			chan int64
+}
+
+func NewChangeListener(dsn string) (*ChangeListener, error) {
+	changeListener := ChangeListener{ids: make(chan int64, 256)}
+	changeListener.listener = pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Change listener error: %v", err)
+		}
+	})
+	if err := changeListener.listener.Listen(changesChannel); err != nil {
+		changeListener.listener.Close()
+		return nil, err
+	}
+	go changeListener.forward()
+	return &changeListener, nil
+}
+
+// forward translates notifications into ids; nil notification (sent after the connection is re-established)
+// becomes 0 meaning that some notifications might have been lost.
+func (changeListener *ChangeListener) forward() {
+	defer close(changeListener.ids)
+	for notification := range changeListener.listener.Notify {
+		if notification == nil {
+			changeListener.ids <- 0
+		} else if id, err := strconv.ParseInt(notification.Extra, 10, 64); err == nil {
+			changeListener.ids <- id
+		}
+	}
+}
+
+// Ids is closed by Close.
+func (changeListener *ChangeListener) Ids() <-chan int64 {
+	return changeListener.ids
+}
+
+func (changeListener *ChangeListener) Close() error {
+	return changeListener.listener.Close()
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+	"github.com/jmoiron/sqlx"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	maintenancePlanColumns string = `id, name, equipment_id, kind, trigger_type, interval_seconds, threshold, metric, active, created_at, updated_at`
+	maintenanceDueColumns = `plan_id, equipment_id, last_done_at, usage, due_at, computed_at`
+)
+
+type Maintenance struct {
+	db *sqlx.DB
+}
+
+func NewMaintenance(db *sqlx.DB) Maintenance {
+	return Maintenance{db: db}
+}
+
+func (repository *Maintenance) ListPlans() ([]*dtos.MaintenancePlanGet, error) {
+	var planModels []model.MaintenancePlan
+	if err := repository.db.Select(&planModels, `SELECT ` + maintenancePlanColumns + ` FROM maintenance_plans ORDER BY id`); err != nil {
+		return nil, err
+	}
+	planGets := make([]*dtos.MaintenancePlanGet, 0, len(planModels))
+	for _, planModel := range planModels {
+		planGets = append(planGets, dtos.MaintenancePlanGetFromModel(planModel))
+	}
+	return planGets, nil
+}
+
+func (repository *Maintenance) ListActivePlans() ([]model.MaintenancePlan, error) {
+	var planModels []model.MaintenancePlan
+	err := repository.db.Select(&planModels, `SELECT ` + maintenancePlanColumns + ` FROM maintenance_plans WHERE active ORDER BY id`)
+	return planModels, err
+}
+
+func (repository *Maintenance) CreatePlan(plan *model.MaintenancePlan) (int64, error) {
+	var id int64
+	err := repository.db.QueryRow(
+		`INSERT INTO maintenance_plans (name, equipment_id, kind, trigger_type, interval_seconds, threshold, metric, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		plan.Name, plan.EquipmentId, plan.Kind, plan.Trigger, plan.IntervalSeconds, plan.Threshold, plan.Metric, plan.Active,
+	).Scan(&id)
+	return id, wrapConflict(err)
+}
+
+func (repository *Maintenance) UpdatePlan(plan *model.MaintenancePlan) (bool, error) {
+	return checkAffect(repository.db.Exec(
+		`UPDATE maintenance_plans SET name=$2, interval_seconds=$3, threshold=$4, metric=$5, active=$6, updated_at=$7 WHERE id=$1`,
+		plan.Id, plan.Name, plan.IntervalSeconds, plan.Threshold, plan.Metric, plan.Active, time.Now(),
+	))
+}
+
+func (repository *Maintenance) FindPlanById(id int64) (*model.MaintenancePlan, error) {
+	var planModel model.MaintenancePlan
+	if err := repository.db.Get(&planModel, `SELECT ` + maintenancePlanColumns + ` FROM maintenance_plans WHERE id=$1`, id); err != nil {
+		return nil, err
+	}
+	return &planModel, nil
+}
+
+// RemovePlanById removes the plan along with its schedule.
+func (repository *Maintenance) RemovePlanById(id int64) (bool, error) {
+	return checkAffect(repository.db.Exec(`DELETE FROM maintenance_plans WHERE id=$1`, id))
+}
+
+// StoreSchedule replaces the schedule of the plan.
+func (repository *Maintenance) StoreSchedule(planId int64, dues []*model.MaintenanceDue) error {
+	return inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.Exec(`DELETE FROM maintenance_schedule WHERE plan_id=$1`, planId); err != nil {
+			return err
+		}
+		for _, due := range dues {
+			if _, err := tx.NamedExec(
+				`INSERT INTO maintenance_schedule (` + maintenanceDueColumns + `)
+				VALUES (:plan_id, :equipment_id, :last_done_at, :usage, :due_at, :computed_at)`,
+				due,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+type scheduledMaintenance struct {
+	model.MaintenanceDue
+	Name		string						`db:"name"`
+	Trigger		model.MaintenanceTrigger	`db:"trigger_type"`
+	Threshold	float64						`db:"threshold"`
+}
+
+// ListDue returns the maintenance of the active plans due before until, the earliest first.
+func (repository *Maintenance) ListDue(now, until time.Time) ([]*dtos.MaintenanceDueGet, error) {
+	var rows []scheduledMaintenance
+	if err := repository.db.Select(
+		&rows,
+		`SELECT schedule.plan_id, schedule.equipment_id, schedule.last_done_at, schedule.usage, schedule.due_at, schedule.computed_at,
+			plan.name, plan.trigger_type, plan.threshold
+		FROM maintenance_schedule schedule JOIN maintenance_plans plan ON plan.id=schedule.plan_id
+		WHERE plan.active AND schedule.due_at<$1 ORDER BY schedule.due_at, schedule.plan_id, schedule.equipment_id`,
+		until,
+	); err != nil {
+		return nil, err
+	}
+	dueGets := make([]*dtos.MaintenanceDueGet, 0, len(rows))
+	for _, row := range rows {
+		dueGets = append(dueGets, dtos.MaintenanceDueGetFromModel(
+			row.MaintenanceDue,
+			model.MaintenancePlan{Id: row.PlanId, Name: row.Name, Trigger: row.Trigger, Threshold: row.Threshold},
+			now,
+		))
+	}
+	return dueGets, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+	"github.com/jmoiron/sqlx"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+type Outbox struct {
+	db *sqlx.DB
+}
+
+func NewOutbox(db *sqlx.DB) Outbox {
+	return Outbox{db: db}
+}
+
+// insertOutboxMessage enqueues the change within the transaction storing it.
+func insertOutboxMessage(tx *sqlx.Tx, change *model.AggregateChange) error {
+	payload, err := json.Marshal(dtos.EquipmentChangeFromModel(change))
+	if err != nil {
+		return err
+	}
+	// Listeners are notified on commit
+	_, err = tx.Exec(
+		`WITH message AS (
+			INSERT INTO outbox (aggregate_id, type, payload, created_at, next_attempt_at) VALUES ($1, $2, $3, $4, $4) RETURNING id
+		)
+		SELECT pg_notify('` + changesChannel + `', id::text) FROM message`,
+		change.Event.AggregateId, change.Event.Type, payload, change.Event.OccurredAt,
+	)
+	return err
+}
+
+const (
+	outboxMessageColumns string = `id, aggregate_id, type, payload, created_at, attempts, transaction_id`
+	// finishedTransactions restricts the messages to the ones of the transactions which have finished along with all the earlier ones
+	finishedTransactions = `transaction_id<pg_snapshot_xmin(pg_current_snapshot())`
+)
+
+// ListAfter returns up to limit messages following the position in order of the positions.
+func (repository *Outbox) ListAfter(position model.OutboxPosition, limit int) ([]model.OutboxMessage, error) {
+	var messages []model.OutboxMessage
+	err := repository.db.Select(
+		&messages,
+		`SELECT ` + outboxMessageColumns + ` FROM outbox
+		WHERE (transaction_id, id)>(CAST(CAST($1 AS text) AS xid8), $2) AND ` + finishedTransactions + `
+		ORDER BY transaction_id, id LIMIT $3`,
+		position.TransactionId, position.Id, limit,
+	)
+	return messages, err
+}
+
+// LastPosition returns the position of the latest message which can be read or the zero position if there are none.
+func (repository *Outbox) LastPosition() (model.OutboxPosition, error) {
+	var position model.OutboxPosition
+	err := repository.db.QueryRow(
+		`SELECT transaction_id, id FROM outbox WHERE ` + finishedTransactions + ` ORDER BY transaction_id DESC, id DESC LIMIT 1`,
+	).Scan(&position.TransactionId, &position.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return position, nil
+	}
+	return position, err
+}
+
+// Relay leases up to limit messages due for publishing for the lease duration, passes each of them to publish
+// and stores the outcome: a failed message is retried after the delay returned by backoff for the number of attempts made.
+// Messages of the same aggregate are relayed in order: a message waits while any earlier one is unpublished.
+// The lease is taken and every outcome is stored in short transactions of their own, so no lock is held while publishing;
+// relays of several replicas share the queue and the messages of a crashed relay are taken again once the lease expires.
+func (repository *Outbox) Relay(limit int, lease time.Duration, publish func(*model.OutboxMessage) error, backoff func(attempts int) time.Duration) (int, error) {
+	var messages []model.OutboxMessage
+	now := time.Now()
+	err := repository.db.Select(
+		&messages,
+		`WITH leased AS (
+			UPDATE outbox SET locked_until=$3
+			WHERE id IN (
+				SELECT id FROM outbox message
+				WHERE published_at IS NULL AND next_attempt_at<=$1 AND (locked_until IS NULL OR locked_until<=$1)
+				AND NOT EXISTS (
+					SELECT 1 FROM outbox earlier
+					WHERE earlier.aggregate_id=message.aggregate_id AND earlier.published_at IS NULL AND earlier.id<message.id
+				)
+				ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + outboxMessageColumns + `
+		)
+		SELECT ` + outboxMessageColumns + ` FROM leased ORDER BY id`,
+		now, limit, now.Add(lease),
+	)
+	if err != nil {
+		return 0, err
+	}
+	for i := range messages {
+		message := &messages[i]
+		if publishError := publish(message); publishError != nil {
+			message.Attempts++
+			_, err = repository.db.Exec(
+				`UPDATE outbox SET attempts=$2, next_attempt_at=$3, last_error=$4, locked_until=NULL WHERE id=$1`,
+				message.Id, message.Attempts, time.Now().Add(backoff(message.Attempts)), publishError.Error(),
+			)
+		} else {
+			_, err = repository.db.Exec(
+				`UPDATE outbox SET attempts=attempts + 1, published_at=$2, locked_until=NULL WHERE id=$1`,
+				message.Id, time.Now(),
+			)
+		}
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(messages), nil
+}
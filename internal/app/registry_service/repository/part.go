@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	sparePartColumns string = `id, sku, description, unit, kinds, equipment_ids, reorder_level, created_at, updated_at`
+	partReservationColumns = `id, work_order_id, part_id, storeroom, quantity, state, created_at, updated_at`
+	// The parts along with their stock summed up over the storerooms
+	sparePartsWithStock = `(SELECT ` + sparePartColumns + `, COALESCE(totals.on_hand, 0) AS on_hand, COALESCE(totals.reserved, 0) AS reserved
+		FROM spare_parts LEFT JOIN (
+			SELECT part_id, sum(on_hand) AS on_hand, sum(reserved) AS reserved FROM part_stock GROUP BY part_id
+		) AS totals ON totals.part_id=spare_parts.id) AS parts`
+)
+
+type Part struct {
+	db *sqlx.DB
+}
+
+func NewPart(db *sqlx.DB) Part {
+	return Part{db: db}
+}
+
+func uuidArray(ids []uuid.UUID) pq.StringArray {
+	array := make(pq.StringArray, 0, len(ids))
+	for _, id := range ids {
+		array = append(array, id.String())
+	}
+	return array
+}
+
+// withStock returns the parts along with their stock per storeroom.
+func (repository *Part) withStock(partModels []model.SparePart) ([]*dtos.SparePartGet, error) {
+	ids := make(pq.Int64Array, 0, len(partModels))
+	for _, partModel := range partModels {
+		ids = append(ids, partModel.Id)
+	}
+	var stockModels []model.PartStock
+	if err := repository.db.Select(
+		&stockModels,
+		`SELECT part_id, storeroom, on_hand, reserved FROM part_stock WHERE part_id=ANY($1) ORDER BY part_id, storeroom`,
+		ids,
+	); err != nil {
+		return nil, err
+	}
+	stock := make(map[int64][]model.PartStock, len(partModels))
+	for _, stockModel := range stockModels {
+		stock[stockModel.PartId] = append(stock[stockModel.PartId], stockModel)
+	}
+	partGets := make([]*dtos.SparePartGet, 0, len(partModels))
+	for _, partModel := range partModels {
+		partGets = append(partGets, dtos.SparePartGetFromModel(partModel, stock[partModel.Id]))
+	}
+	return partGets, nil
+}
+
+func (repository *Part) List(partFilter *dtos.SparePartFilter) ([]*dtos.SparePartGet, error) {
+	conditions := make([]string, 0, 4)
+	arguments := make(map[string]interface{}, 4)
+	if len(partFilter.Kinds) > 0 {
+		conditions = append(conditions, "kinds && CAST(:kinds AS smallint[])")
+		arguments["kinds"] = integralArray(partFilter.Kinds)
+	}
+	if len(partFilter.SKUs) > 0 {
+		conditions = append(conditions, "sku=ANY(:skus)")
+		arguments["skus"] = pq.StringArray(partFilter.SKUs)
+	}
+	if partFilter.Fits != nil {
+		conditions = append(conditions, "(:fits_kind=ANY(kinds) OR CAST(:fits_id AS uuid)=ANY(equipment_ids))")
+		arguments["fits_kind"] = partFilter.Fits.Kind
+		arguments["fits_id"] = partFilter.Fits.Id
+	}
+	if partFilter.LowStock {
+		conditions = append(conditions, "on_hand - reserved <= reorder_level")
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query, err := repository.db.PrepareNamed(`SELECT * FROM ` + sparePartsWithStock + where + ` ORDER BY sku`)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Close()
+	var partModels []model.SparePart
+	if err = query.Select(&partModels, arguments); err != nil {
+		return nil, err
+	}
+	return repository.withStock(partModels)
+}
+
+func (repository *Part) Create(partCreate *dtos.SparePartCreate) (int64, error) {
+	unit := partCreate.Unit
+	if unit == "" {
+		unit = dtos.DefaultPartUnit
+	}
+	var id int64
+	err := repository.db.QueryRow(
+		`INSERT INTO spare_parts (sku, description, unit, kinds, equipment_ids, reorder_level)
+		VALUES ($1, $2, $3, $4, CAST($5 AS uuid[]), $6) RETURNING id`,
+		partCreate.SKU, partCreate.Description, unit, integralArray(partCreate.Kinds), uuidArray(partCreate.EquipmentIds), partCreate.ReorderLevel,
+	).Scan(&id)
+	return id, wrapConflict(err)
+}
+
+func (repository *Part) Update(partUpdate *dtos.SparePartUpdate) (bool, error) {
+	set := make([]string, 0, 6)
+	arguments := map[string]interface{}{
+		"id":			partUpdate.Id,
+		"updated_at":	time.Now(),
+	}
+	if partUpdate.SKU != nil {
+		set = append(set, "sku=:sku")
+		arguments["sku"] = *partUpdate.SKU
+	}
+	if partUpdate.Description != nil {
+		set = append(set, "description=:description")
+		arguments["description"] = *partUpdate.Description
+	}
+	if partUpdate.Unit != nil {
+		set = append(set, "unit=:unit")
+		arguments["unit"] = *partUpdate.Unit
+	}
+	if partUpdate.Kinds != nil {
+		set = append(set, "kinds=:kinds")
+		arguments["kinds"] = integralArray(*partUpdate.Kinds)
+	}
+	if partUpdate.EquipmentIds != nil {
+		set = append(set, "equipment_ids=CAST(:equipment_ids AS uuid[])")
+		arguments["equipment_ids"] = uuidArray(*partUpdate.EquipmentIds)
+	}
+	if partUpdate.ReorderLevel != nil {
+		set = append(set, "reorder_level=:reorder_level")
+		arguments["reorder_level"] = *partUpdate.ReorderLevel
+	}
+	if len(set) == 0 {
+		return false, nil // Nothing to update
+	}
+	updated, err := checkAffect(repository.db.NamedExec(
+		`UPDATE spare_parts SET ` + strings.Join(set, ", ") + `, updated_at=:updated_at WHERE id=:id`,
+		arguments,
+	))
+	return updated, wrapConflict(err)
+}
+
+func (repository *Part) FindById(id int64) (*dtos.SparePartGet, error) {
+	var partModel model.SparePart
+	if err := repository.db.Get(&partModel, `SELECT * FROM ` + sparePartsWithStock + ` WHERE id=$1`, id); err != nil {
+		return nil, err
+	}
+	partGets, err := repository.withStock([]model.SparePart{partModel})
+	if err != nil {
+		return nil, err
+	}
+	return partGets[0], nil
+}
+
+// RemoveById removes the part along with its stock; fails with model.ErrConflict while the part has reservations.
+func (repository *Part) RemoveById(id int64) (bool, error) {
+	removed, err := checkAffect(repository.db.Exec(`DELETE FROM spare_parts WHERE id=$1`, id))
+	return removed, wrapConflict(err)
+}
+
+// AdjustStock adds the quantity to the stock on hand of the part in the storeroom; fails with sql.ErrNoRows for unknown part
+// and with model.ErrConflict if the stock on hand would get below the reserved one.
+func (repository *Part) AdjustStock(partId int64, stockAdjustment *dtos.StockAdjustment) error {
+	if stockAdjustment.Quantity > 0 {
+		_, err := repository.db.Exec(
+			`INSERT INTO part_stock (part_id, storeroom, on_hand) VALUES ($1, $2, $3)
+			ON CONFLICT (part_id, storeroom) DO UPDATE SET on_hand=part_stock.on_hand + EXCLUDED.on_hand`,
+			partId, stockAdjustment.Storeroom, stockAdjustment.Quantity,
+		)
+		if err = wrapConflict(err); errors.Is(err, model.ErrConflict) {
+			return sql.ErrNoRows
+		}
+		return err
+	}
+	adjusted, err := checkAffect(repository.db.Exec(
+		`UPDATE part_stock SET on_hand=on_hand + $3 WHERE part_id=$1 AND storeroom=$2 AND on_hand + $3 >= reserved`,
+		partId, stockAdjustment.Storeroom, stockAdjustment.Quantity,
+	))
+	if err != nil || adjusted {
+		return err
+	}
+	if _, err = repository.FindById(partId); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: insufficient unreserved stock of part #%d in `%s`", model.ErrConflict, partId, stockAdjustment.Storeroom)
+}
+
+// Reserve reserves the available stock of the part in the storeroom for the open work order;
+// fails with sql.ErrNoRows for unknown work order or part and with model.ErrConflict if the work order is closed,
+// the part does not fit its equipment or the stock is insufficient.
+func (repository *Part) Reserve(workOrderId int64, reservationCreate *dtos.PartReservationCreate) (int64, error) {
+	var id int64
+	err := inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		var check struct {
+			State	model.WorkOrderState	`db:"state"`
+			Fits	bool					`db:"fits"`
+		}
+		// The work order is locked so it cannot be closed before the reservation is stored
+		if err := tx.Get(
+			&check,
+			`SELECT work_orders.state, COALESCE(equipment.kind=ANY(spare_parts.kinds) OR equipment.id=ANY(spare_parts.equipment_ids), FALSE) AS fits
+			FROM work_orders JOIN spare_parts ON spare_parts.id=$2 LEFT JOIN equipment ON equipment.id=work_orders.equipment_id
+			WHERE work_orders.id=$1 FOR UPDATE OF work_orders`,
+			workOrderId, reservationCreate.PartId,
+		); err != nil {
+			return err
+		}
+		if check.State.IsClosed() {
+			return fmt.Errorf("%w: work order #%d is %s", model.ErrConflict, workOrderId, check.State)
+		}
+		if !check.Fits {
+			return fmt.Errorf("%w: part #%d does not fit the equipment of work order #%d", model.ErrConflict, reservationCreate.PartId, workOrderId)
+		}
+		if reserved, err := checkAffect(tx.Exec(
+			`UPDATE part_stock SET reserved=reserved + $3 WHERE part_id=$1 AND storeroom=$2 AND on_hand - reserved >= $3`,
+			reservationCreate.PartId, reservationCreate.Storeroom, reservationCreate.Quantity,
+		)); err != nil {
+			return err
+		} else if !reserved {
+			return fmt.Errorf(
+				"%w: insufficient available stock of part #%d in `%s`", model.ErrConflict, reservationCreate.PartId, reservationCreate.Storeroom,
+			)
+		}
+		return tx.QueryRow(
+			`INSERT INTO part_reservations (work_order_id, part_id, storeroom, quantity, state) VALUES ($1, $2, $3, $4, $5) RETURNING id`,
+			workOrderId, reservationCreate.PartId, reservationCreate.Storeroom, reservationCreate.Quantity, model.PartReserved,
+		).Scan(&id)
+	})
+	return id, err
+}
+
+func (repository *Part) ListReservations(workOrderId int64) ([]*dtos.PartReservationGet, error) {
+	var reservationModels []model.PartReservation
+	if err := repository.db.Select(
+		&reservationModels,
+		`SELECT ` + partReservationColumns + ` FROM part_reservations WHERE work_order_id=$1 ORDER BY id`,
+		workOrderId,
+	); err != nil {
+		return nil, err
+	}
+	reservationGets := make([]*dtos.PartReservationGet, 0, len(reservationModels))
+	for _, reservationModel := range reservationModels {
+		reservationGets = append(reservationGets, dtos.PartReservationGetFromModel(reservationModel))
+	}
+	return reservationGets, nil
+}
+
+// settleReservations consumes (takes from the stock) or releases (returns to the stock) the reservations locked by the transaction.
+func settleReservations(tx *sqlx.Tx, reservationModels []model.PartReservation, state model.ReservationState) error {
+	consumed := 0.0
+	if state == model.PartConsumed {
+		consumed = 1
+	}
+	ids := make(pq.Int64Array, 0, len(reservationModels))
+	for _, reservationModel := range reservationModels {
+		if _, err := tx.Exec(
+			`UPDATE part_stock SET reserved=reserved - $3, on_hand=on_hand - $3 * $4 WHERE part_id=$1 AND storeroom=$2`,
+			reservationModel.PartId, reservationModel.Storeroom, reservationModel.Quantity, consumed,
+		); err != nil {
+			return err
+		}
+		ids = append(ids, reservationModel.Id)
+	}
+	_, err := tx.Exec(`UPDATE part_reservations SET state=$2, updated_at=$3 WHERE id=ANY($1)`, ids, state, time.Now())
+	return err
+}
+
+// settleWorkOrder consumes the reservations of the work order done and releases the ones of the work order cancelled
+// within the transaction closing it.
+func settleWorkOrder(tx *sqlx.Tx, workOrder *model.WorkOrder) error {
+	var reservationModels []model.PartReservation
+	if err := tx.Select(
+		&reservationModels,
+		`SELECT ` + partReservationColumns + ` FROM part_reservations WHERE work_order_id=$1 AND state=$2 ORDER BY id FOR UPDATE`,
+		workOrder.Id, model.PartReserved,
+	); err != nil {
+		return err
+	}
+	state := model.PartReleased
+	if workOrder.State == model.WorkOrderDone {
+		state = model.PartConsumed
+	}
+	return settleReservations(tx, reservationModels, state)
+}
+
+// Release returns the parts of the reservation of the work order to the stock provided it is still reserved
+// and the work order is open: the work order is locked, so it cannot be closed meanwhile.
+func (repository *Part) Release(workOrderId, reservationId int64) (bool, error) {
+	var released bool
+	err := inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		var reservationModels []model.PartReservation
+		if err := tx.Select(
+			&reservationModels,
+			`SELECT ` + partReservationColumns + ` FROM part_reservations
+			WHERE work_order_id=(SELECT id FROM work_orders WHERE id=$1 AND state NOT IN ('done', 'cancelled') FOR UPDATE)
+			AND id=$2 AND state=$3 FOR UPDATE`,
+			workOrderId, reservationId, model.PartReserved,
+		); err != nil || len(reservationModels) == 0 {
+			return err
+		}
+		released = true
+		return settleReservations(tx, reservationModels, model.PartReleased)
+	})
+	return released, err
+}
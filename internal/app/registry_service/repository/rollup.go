@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	maxRollupSpan time.Duration = 24 * time.Hour	// Rolled up by one transaction at most
+	bucketLayout string = "2006-01-02T15:04:05.999999"
+)
+
+// rollup is the table of the readings aggregated by buckets of the interval from the finer data;
+// the rollup is complete until the watermark stored in telemetry_rollups under the resolution
+// except the buckets stored in telemetry_dirty_buckets, which got finer data after they were rolled up.
+type rollup struct {
+	resolution	string
+	table		string
+	interval	time.Duration
+	unit		string	// Field of date_trunc truncating to the interval
+	finer		string	// Resolution of the source rollup, the readings if empty
+	source		string	// Table of the finer data
+	time		string	// Column of the time of the finer data
+	aggregates	string	// Partial aggregates of the finer data
+}
+
+// rollups are ordered from the finest one.
+var rollups = []rollup {
+	{
+		resolution:	"1m",
+		table:		"telemetry_1m",
+		interval:	time.Minute,
+		unit:		"minute",
+		source:		"telemetry",
+		time:		"recorded_at",
+		aggregates:	"count(*), sum(value), min(value), max(value)",
+	},
+	{
+		resolution:	"1h",
+		table:		"telemetry_1h",
+		interval:	time.Hour,
+		unit:		"hour",
+		finer:		"1m",
+		source:		"telemetry_1m",
+		time:		"bucket",
+		aggregates:	"sum(value_count), sum(value_sum), min(value_min), max(value_max)",
+	},
+}
+
+// upsert returns the statement replacing the buckets by the aggregates of the finer data of source
+// (aliased as `source`) joined and filtered by from.
+func (rollup *rollup) upsert(from string) string {
+	return `INSERT INTO ` + rollup.table + ` (equipment_id, metric, bucket, value_count, value_sum, value_min, value_max)
+		SELECT source.equipment_id, source.metric, date_trunc('` + rollup.unit + `', source.` + rollup.time + `), ` + rollup.aggregates + `
+		FROM ` + from + ` GROUP BY 1, 2, 3
+		ON CONFLICT (equipment_id, metric, bucket) DO UPDATE SET value_count=EXCLUDED.value_count, value_sum=EXCLUDED.value_sum,
+			value_min=EXCLUDED.value_min, value_max=EXCLUDED.value_max`
+}
+
+func earlier(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+	return a
+}
+
+type watermark struct {
+	Resolution	string		`db:"resolution"`
+	RolledUntil	time.Time	`db:"rolled_until"`
+}
+
+func (repository *Telemetry) watermarks() (map[string]time.Time, error) {
+	var rows []watermark
+	if err := repository.db.Select(&rows, `SELECT resolution, rolled_until FROM telemetry_rollups`); err != nil {
+		return nil, err
+	}
+	watermarks := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		watermarks[row.Resolution] = row.RolledUntil
+	}
+	return watermarks, nil
+}
+
+type dirtyBucket struct {
+	EquipmentId	uuid.UUID	`db:"equipment_id"`
+	Bucket		time.Time	`db:"bucket"`
+}
+
+// bucketArrays returns the equipment ids and the times of the buckets to be cast to uuid[] and timestamp[].
+func bucketArrays(buckets []dirtyBucket) (interface{}, pq.StringArray) {
+	equipmentIds := make([]uuid.UUID, 0, len(buckets))
+	times := make(pq.StringArray, 0, len(buckets))
+	for _, bucket := range buckets {
+		equipmentIds, times = append(equipmentIds, bucket.EquipmentId), append(times, bucket.Bucket.UTC().Format(bucketLayout))
+	}
+	return pq.Array(equipmentIds), times
+}
+
+// markDirty stores the buckets of the rollup of the resolution as the ones to roll up again
+// unless they are stored already; the time of each bucket is truncated to the unit.
+func markDirty(tx *sqlx.Tx, resolution, unit string, buckets []dirtyBucket) error {
+	equipmentIds, times := bucketArrays(buckets)
+	_, err := tx.Exec(
+		`INSERT INTO telemetry_dirty_buckets (resolution, equipment_id, bucket)
+		SELECT CAST($1 AS text), equipment_id, date_trunc(CAST($2 AS text), bucket) FROM unnest(CAST($3 AS uuid[]), CAST($4 AS timestamp[])) AS dirty (equipment_id, bucket)
+		ON CONFLICT DO NOTHING`,
+		resolution, unit, equipmentIds, times,
+	)
+	return err
+}
+
+// markReadings marks the buckets of the finest rollup the readings are stored to, so the readings committed
+// after their buckets are rolled up are rolled up again whenever they are committed.
+func markReadings(tx *sqlx.Tx, readings []model.Reading) error {
+	buckets := make([]dirtyBucket, 0, len(readings))
+	for _, reading := range readings {
+		bucket := dirtyBucket{EquipmentId: reading.EquipmentId, Bucket: reading.RecordedAt.Truncate(rollups[0].interval)}
+		if !slices.Contains(buckets, bucket) {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return markDirty(tx, rollups[0].resolution, rollups[0].unit, buckets)
+}
+
+type rollupRange struct {
+	rollup		*rollup
+	from, to	time.Time
+}
+
+// cover splits [from, to) into the ranges read from the rollups (see covered) by the current watermarks.
+func (repository *Telemetry) cover(from, to time.Time, fits func(*rollup) bool) ([]rollupRange, time.Time, error) {
+	watermarks, err := repository.watermarks()
+	if err != nil {
+		return nil, from, err
+	}
+	ranges, from := covered(watermarks, from, to, fits)
+	return ranges, from, nil
+}
+
+// covered splits [from, to) into the ranges read from the rollups: starting from the coarsest one whose buckets fit,
+// each rollup covers whole buckets up to its watermark and the finer ones continue; returns the ranges
+// and the start of the rest read from the readings.
+func covered(watermarks map[string]time.Time, from, to time.Time, fits func(*rollup) bool) ([]rollupRange, time.Time) {
+	ranges := make([]rollupRange, 0, len(rollups))
+	for i := len(rollups) - 1; i >= 0; i-- {
+		rollup := &rollups[i]
+		if !fits(rollup) || !from.Truncate(rollup.interval).Equal(from) {
+			continue
+		}
+		if until := earlier(watermarks[rollup.resolution], to.Truncate(rollup.interval)); until.After(from) {
+			ranges = append(ranges, rollupRange{rollup: rollup, from: from, to: until})
+			from = until
+		}
+	}
+	return ranges, from
+}
+
+// RollUp aggregates the data following the watermark of each rollup up to the bucket of until
+// (and up to the watermark of the finer rollup) replacing the buckets rolled up before,
+// and aggregates again the dirty buckets preceding the watermark marking the buckets of the coarser rollup containing them.
+func (repository *Telemetry) RollUp(until time.Time) error {
+	for i := range rollups {
+		var coarser *rollup
+		if i + 1 < len(rollups) {
+			coarser = &rollups[i + 1]
+		}
+		if err := repository.rollUp(&rollups[i], coarser, until.UTC()); err != nil {
+			return fmt.Errorf("Rollup %s: %w", rollups[i].resolution, err)
+		}
+	}
+	return nil
+}
+
+func (repository *Telemetry) rollUp(rollup, coarser *rollup, until time.Time) error {
+	return inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		var from time.Time
+		// The lock serializes the rollups of the replicas
+		if err := tx.Get(&from, `SELECT rolled_until FROM telemetry_rollups WHERE resolution=$1 FOR UPDATE`, rollup.resolution); err != nil {
+			return err
+		}
+		to := until
+		if rollup.finer != "" {
+			var finerUntil time.Time
+			if err := tx.Get(&finerUntil, `SELECT rolled_until FROM telemetry_rollups WHERE resolution=$1`, rollup.finer); err != nil {
+				return err
+			}
+			to = earlier(to, finerUntil)
+		}
+		to = earlier(to.Truncate(rollup.interval), from.Add(maxRollupSpan))
+		if !to.After(from) {
+			to = from
+		}
+		// The dirty buckets are taken before the data is read: the finer data of those committed later is read or they stay
+		var dirty []dirtyBucket
+		if err := tx.Select(
+			&dirty,
+			`DELETE FROM telemetry_dirty_buckets WHERE resolution=$1 AND bucket<$2 RETURNING equipment_id, bucket`,
+			rollup.resolution, to,
+		); err != nil {
+			return err
+		}
+		if to.After(from) {
+			if _, err := tx.Exec(
+				rollup.upsert(rollup.source + ` source WHERE source.` + rollup.time + `>=$1 AND source.` + rollup.time + `<$2`),
+				from, to,
+			); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(`UPDATE telemetry_rollups SET rolled_until=$2 WHERE resolution=$1`, rollup.resolution, to); err != nil {
+				return err
+			}
+		}
+		// The dirty buckets following from are rolled up by the range
+		dirty = slices.DeleteFunc(dirty, func(bucket dirtyBucket) bool { return !bucket.Bucket.Before(from) })
+		if len(dirty) == 0 {
+			return nil
+		}
+		equipmentIds, buckets := bucketArrays(dirty)
+		if _, err := tx.Exec(
+			rollup.upsert(
+				`unnest(CAST($1 AS uuid[]), CAST($2 AS timestamp[])) AS dirty (equipment_id, bucket)
+				JOIN ` + rollup.source + ` source ON source.equipment_id=dirty.equipment_id
+				AND source.` + rollup.time + `>=dirty.bucket AND source.` + rollup.time + `<dirty.bucket + interval '1 ` + rollup.unit + `'`,
+			),
+			equipmentIds, buckets,
+		); err != nil {
+			return err
+		}
+		if coarser == nil {
+			return nil
+		}
+		return markDirty(tx, coarser.resolution, coarser.unit, dirty)
+	})
+}
+
+// DropRollups deletes the buckets of the rollups by minutes starting before minutesBefore
+// and by hours starting before hoursBefore; returns the number of the deleted buckets.
+func (repository *Telemetry) DropRollups(minutesBefore, hoursBefore time.Time) (int64, error) {
+	var dropped int64
+	for _, drop := range []struct {
+		rollup	*rollup
+		before	time.Time
+	}{
+		{&rollups[0], minutesBefore},
+		{&rollups[1], hoursBefore},
+	} {
+		result, err := repository.db.Exec(`DELETE FROM ` + drop.rollup.table + ` WHERE bucket<$1`, drop.before.UTC())
+		if err != nil {
+			return dropped, err
+		}
+		count, err := result.RowsAffected()
+		if err != nil {
+			return dropped, err
+		}
+		dropped += count
+	}
+	return dropped, nil
+}
+
+// DropPartitions drops the daily partitions of the readings ending not later than before
+// provided they are rolled up; returns the names of the dropped ones.
+func (repository *Telemetry) DropPartitions(before time.Time) ([]string, error) {
+	var names []string
+	if err := repository.db.Select(
+		&names,
+		`SELECT child.relname FROM pg_inherits
+		JOIN pg_class child ON child.oid=pg_inherits.inhrelid
+		JOIN pg_class parent ON parent.oid=pg_inherits.inhparent
+		WHERE parent.relname='telemetry' ORDER BY 1`,
+	); err != nil {
+		return nil, err
+	}
+	watermarks, err := repository.watermarks()
+	if err != nil {
+		return nil, err
+	}
+	rolledUntil := watermarks[rollups[0].resolution]
+	dropped := make([]string, 0, len(names))
+	for _, name := range names {
+		day, err := time.Parse(partitionLayout, strings.TrimPrefix(name, "telemetry_"))
+		if err != nil {
+			continue // Not created by ensurePartition
+		}
+		if end := day.AddDate(0, 0, 1); end.After(before) || end.After(rolledUntil) {
+			continue
+		}
+		if _, err = repository.db.Exec(`DROP TABLE IF EXISTS ` + pq.QuoteIdentifier(name)); err != nil {
+			return dropped, err
+		}
+		repository.partitions.Delete(name)
+		dropped = append(dropped, name)
+	}
+	return dropped, nil
+}
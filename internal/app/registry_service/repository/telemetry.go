@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	duplicateTable pq.ErrorCode = "42P07"
+	partitionLayout string = "20060102"
+	readingsPerInsert = 1000	// 5 parameters per reading stay far below the limit of 65535
+	// stepStart is the start of the step aligned to :from the time of the column falls within
+	stepStart = `CAST(:from AS timestamp) + floor(extract(epoch FROM %[1]s - CAST(:from AS timestamp)) / :step) * :step * interval '1 second'`
+	// percentileQuery is formatted with the equipment condition; the percentile is computed from the readings only
+	percentileQuery = `SELECT ` + stepStart + ` AS time, percentile_cont(0.95) WITHIN GROUP (ORDER BY value) AS value
+	FROM telemetry
+	WHERE %[2]s AND metric=:metric AND recorded_at>=:from AND recorded_at<:to
+	GROUP BY 1 ORDER BY 1`
+	// readingsPartials are the partial aggregates of the readings by steps in [:readings_from, :to); is formatted with the equipment condition
+	readingsPartials = `SELECT ` + stepStart + ` AS time, count(*) AS value_count, sum(value) AS value_sum, min(value) AS value_min, max(value) AS value_max
+	FROM telemetry
+	WHERE %[2]s AND metric=:metric AND recorded_at>=:readings_from AND recorded_at<:to
+	GROUP BY 1`
+	// rollupPartials are the partial aggregates of the rollup by steps; is formatted with the bucket column, the equipment condition,
+	// the table and the resolution naming the range parameters
+	rollupPartials = `SELECT ` + stepStart + ` AS time, sum(value_count) AS value_count, sum(value_sum) AS value_sum, min(value_min) AS value_min, max(value_max) AS value_max
+	FROM %[3]s
+	WHERE %[2]s AND metric=:metric AND bucket>=:from_%[4]s AND bucket<:to_%[4]s
+	GROUP BY 1`
+)
+
+// aggregates combine the partial aggregates of the step
+var aggregates = map[dtos.Aggregation]string {
+	dtos.AggAvg:	"sum(value_sum) / sum(value_count)",
+	dtos.AggMin:	"min(value_min)",
+	dtos.AggMax:	"max(value_max)",
+	dtos.AggCount:	"sum(value_count)",
+}
+
+type Telemetry struct {
+	db			*sqlx.DB
+	partitions	sync.Map	// Names of the partitions known to exist
+}
+
+func NewTelemetry(db *sqlx.DB) *Telemetry {
+	return &Telemetry{db: db}
+}
+
+func partitionName(day time.Time) string {
+	return "telemetry_" + day.Format(partitionLayout)
+}
+
+// ensurePartition creates the daily partition holding the instant unless it exists.
+func (repository *Telemetry) ensurePartition(instant time.Time) error {
+	day := instant.UTC().Truncate(24 * time.Hour)
+	name := partitionName(day)
+	if _, ok := repository.partitions.Load(name); ok {
+		return nil
+	}
+	_, err := repository.db.Exec(fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s PARTITION OF telemetry FOR VALUES FROM ('%s') TO ('%s')`,
+		pq.QuoteIdentifier(name), day.Format(time.DateTime), day.AddDate(0, 0, 1).Format(time.DateTime),
+	))
+	var pqError *pq.Error
+	if errors.As(err, &pqError) && (pqError.Code == duplicateTable || pqError.Code == uniqueViolation) {
+		err = nil // Created concurrently
+	}
+	if err == nil {
+		repository.partitions.Store(name, struct{}{})
+	}
+	return err
+}
+
+// Insert stores the readings of the equipment by multi-row inserts within one transaction creating the missing partitions beforehand
+// and enqueues them as the batch for each of the listeners.
+func (repository *Telemetry) Insert(readings []model.Reading, listeners []string) error {
+	for i := range readings {
+		if err := repository.ensurePartition(readings[i].RecordedAt); err != nil {
+			return err
+		}
+	}
+	return inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		for start := 0; start < len(readings); start += readingsPerInsert {
+			chunk := readings[start:min(start + readingsPerInsert, len(readings))]
+			rows := make([]string, 0, len(chunk))
+			arguments := make([]interface{}, 0, 5 * len(chunk))
+			for i, reading := range chunk {
+				rows = append(rows, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", 5 * i + 1, 5 * i + 2, 5 * i + 3, 5 * i + 4, 5 * i + 5))
+				arguments = append(arguments, reading.EquipmentId, reading.Metric, reading.Value, reading.Unit, reading.RecordedAt)
+			}
+			if _, err := tx.Exec(
+				`INSERT INTO telemetry (equipment_id, metric, value, unit, recorded_at) VALUES ` + strings.Join(rows, ", "),
+				arguments...,
+			); err != nil {
+				return err
+			}
+		}
+		if err := markReadings(tx, readings); err != nil || len(listeners) == 0 {
+			return err
+		}
+		jsonifiedReadings, err := json.Marshal(readings)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(
+			`INSERT INTO telemetry_batches (listener, equipment_id, readings, received_at, next_attempt_at)
+			SELECT listener, $2, $3, $4, $4 FROM unnest(CAST($1 AS text[])) AS listener`,
+			pq.StringArray(listeners), readings[0].EquipmentId, jsonifiedReadings, time.Now(),
+		)
+		return err
+	})
+}
+
+// DeliverBatches leases up to limit batches due for the listeners for the lease duration, passes each of them to deliver
+// and removes it if deliver succeeds; a failed batch is retried after the delay returned by backoff for the number of attempts made.
+// The batches of the same equipment are delivered to the same listener in order: a batch waits while any earlier one is pending.
+// No transaction is held while delivering, so the deliverers of several replicas share the queue.
+func (repository *Telemetry) DeliverBatches(
+	limit int, lease time.Duration,
+	deliver func(*model.ReadingBatch) error,
+	backoff func(attempts int) time.Duration,
+) (int, error) {
+	var rows []struct {
+		model.ReadingBatch
+		Payload	[]byte	`db:"readings"`
+	}
+	now := time.Now()
+	err := repository.db.Select(
+		&rows,
+		`WITH leased AS (
+			UPDATE telemetry_batches SET locked_until=$3
+			WHERE id IN (
+				SELECT id FROM telemetry_batches batch
+				WHERE next_attempt_at<=$1 AND (locked_until IS NULL OR locked_until<=$1)
+				AND NOT EXISTS (
+					SELECT 1 FROM telemetry_batches earlier
+					WHERE earlier.listener=batch.listener AND earlier.equipment_id=batch.equipment_id AND earlier.id<batch.id
+				)
+				ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, listener, equipment_id, readings, received_at, attempts
+		)
+		SELECT id, listener, equipment_id, readings, received_at, attempts FROM leased ORDER BY id`,
+		now, limit, now.Add(lease),
+	)
+	if err != nil {
+		return 0, err
+	}
+	for i := range rows {
+		batch := &rows[i].ReadingBatch
+		deliveryError := json.Unmarshal(rows[i].Payload, &batch.Readings)
+		if deliveryError == nil {
+			deliveryError = deliver(batch)
+		}
+		if deliveryError != nil {
+			batch.Attempts++
+			_, err = repository.db.Exec(
+				`UPDATE telemetry_batches SET attempts=$2, next_attempt_at=$3, last_error=$4, locked_until=NULL WHERE id=$1`,
+				batch.Id, batch.Attempts, time.Now().Add(backoff(batch.Attempts)), deliveryError.Error(),
+			)
+		} else {
+			_, err = repository.db.Exec(`DELETE FROM telemetry_batches WHERE id=$1`, batch.Id)
+		}
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
+func (repository *Telemetry) series(equipmentCondition string, telemetryQuery *dtos.TelemetryQuery, arguments map[string]interface{}) ([]dtos.TelemetryPoint, error) {
+	arguments["metric"] = telemetryQuery.Metric
+	arguments["from"] = *telemetryQuery.From
+	arguments["to"] = *telemetryQuery.To
+	arguments["step"] = telemetryQuery.Interval.Seconds()
+	var query string
+	if telemetryQuery.Agg == dtos.AggP95 {
+		query = fmt.Sprintf(percentileQuery, "recorded_at", equipmentCondition)
+	} else {
+		partials, err := repository.partials(equipmentCondition, telemetryQuery, arguments)
+		if err != nil {
+			return nil, err
+		}
+		query = `SELECT time, ` + aggregates[telemetryQuery.Agg] + ` AS value FROM (` + strings.Join(partials, " UNION ALL ") + `) AS partial
+		GROUP BY time ORDER BY time`
+	}
+	preparedQuery, err := repository.db.PrepareNamed(query)
+	if err != nil {
+		return nil, err
+	}
+	defer preparedQuery.Close()
+	var points []model.SeriesPoint
+	if err = preparedQuery.Select(&points, arguments); err != nil {
+		return nil, err
+	}
+	telemetryPoints := make([]dtos.TelemetryPoint, 0, len(points))
+	for _, point := range points {
+		telemetryPoints = append(telemetryPoints, dtos.TelemetryPoint{Time: point.Time, Value: point.Value})
+	}
+	return telemetryPoints, nil
+}
+
+// partials returns the queries of the partial aggregates covering the range of the query by rollups (see cover) and the readings.
+func (repository *Telemetry) partials(equipmentCondition string, telemetryQuery *dtos.TelemetryQuery, arguments map[string]interface{}) ([]string, error) {
+	ranges, readingsFrom, err := repository.cover(*telemetryQuery.From, *telemetryQuery.To, func(rollup *rollup) bool {
+		return telemetryQuery.Interval % rollup.interval == 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	partials := make([]string, 0, len(ranges) + 1)
+	for _, rollupRange := range ranges {
+		partials = append(partials, fmt.Sprintf(rollupPartials, "bucket", equipmentCondition, rollupRange.rollup.table, rollupRange.rollup.resolution))
+		arguments["from_" + rollupRange.rollup.resolution], arguments["to_" + rollupRange.rollup.resolution] = rollupRange.from, rollupRange.to
+	}
+	arguments["readings_from"] = readingsFrom
+	return append(partials, fmt.Sprintf(readingsPartials, "recorded_at", equipmentCondition)), nil
+}
+
+// Series aggregates the readings of the metric of the equipment by steps reading the rollups where possible.
+func (repository *Telemetry) Series(id uuid.UUID, telemetryQuery *dtos.TelemetryQuery) ([]dtos.TelemetryPoint, error) {
+	return repository.series("equipment_id=:equipment_id", telemetryQuery, map[string]interface{}{"equipment_id": id})
+}
+
+// FleetSeries aggregates the readings of the metric of all the existing equipment matching the filter by steps.
+func (repository *Telemetry) FleetSeries(fleetTelemetryQuery *dtos.FleetTelemetryQuery) ([]dtos.TelemetryPoint, error) {
+	conditions, arguments := equipmentConditions(&fleetTelemetryQuery.EquipmentFilter)
+	return repository.series(
+		"equipment_id IN (SELECT id FROM " + equipmentWithConnectivity + whereClause(conditions) + ")",
+		&fleetTelemetryQuery.TelemetryQuery,
+		arguments,
+	)
+}
+
+// Totals sums the readings of the metrics of each equipment within [its start in from, to) reading the rollups where possible;
+// the ranges of all the equipment are summed by one query.
+func (repository *Telemetry) Totals(from map[uuid.UUID]time.Time, metrics []string, to time.Time) ([]model.MetricTotal, error) {
+	watermarks, err := repository.watermarks()
+	if err != nil {
+		return nil, err
+	}
+	to = to.UTC()
+	ids := make([]uuid.UUID, 0, len(from))
+	readingsFrom := make(pq.StringArray, 0, len(from))
+	rollupsFrom, rollupsTo := make([]pq.StringArray, len(rollups)), make([]pq.StringArray, len(rollups))
+	for id, start := range from {
+		ranges, rest := covered(watermarks, start.UTC(), to, func(*rollup) bool { return true })
+		ids, readingsFrom = append(ids, id), append(readingsFrom, rest.Format(bucketLayout))
+		for i := range rollups {
+			rangeFrom, rangeTo := rest, rest	// The empty range of the rollup not covering any
+			for _, rollupRange := range ranges {
+				if rollupRange.rollup == &rollups[i] {
+					rangeFrom, rangeTo = rollupRange.from, rollupRange.to
+				}
+			}
+			rollupsFrom[i], rollupsTo[i] = append(rollupsFrom[i], rangeFrom.Format(bucketLayout)), append(rollupsTo[i], rangeTo.Format(bucketLayout))
+		}
+	}
+	arguments := map[string]interface{}{
+		"equipment_ids":	pq.Array(ids),
+		"metrics":			pq.StringArray(metrics),
+		"readings_from":	readingsFrom,
+		"to":				to,
+	}
+	arrays := []string{"CAST(:equipment_ids AS uuid[])", "CAST(:readings_from AS timestamp[])"}
+	columns := []string{"equipment_id", "readings_from"}
+	sums := make([]string, 0, len(rollups) + 1)
+	for i := range rollups {
+		resolution := rollups[i].resolution
+		arguments["from_" + resolution], arguments["to_" + resolution] = rollupsFrom[i], rollupsTo[i]
+		arrays = append(arrays, "CAST(:from_" + resolution + " AS timestamp[])", "CAST(:to_" + resolution + " AS timestamp[])")
+		columns = append(columns, "from_" + resolution, "to_" + resolution)
+		sums = append(sums, fmt.Sprintf(
+			`SELECT source.equipment_id, source.metric, source.value_sum FROM %s source JOIN ranges ON ranges.equipment_id=source.equipment_id
+			WHERE source.metric=ANY(:metrics) AND source.bucket>=ranges.from_%[2]s AND source.bucket<ranges.to_%[2]s`,
+			rollups[i].table, resolution,
+		))
+	}
+	sums = append(sums, `SELECT source.equipment_id, source.metric, source.value AS value_sum FROM telemetry source JOIN ranges ON ranges.equipment_id=source.equipment_id
+		WHERE source.metric=ANY(:metrics) AND source.recorded_at>=ranges.readings_from AND source.recorded_at<:to`)
+	preparedQuery, err := repository.db.PrepareNamed(
+		`WITH ranges AS (SELECT * FROM unnest(` + strings.Join(arrays, ", ") + `) AS ranges (` + strings.Join(columns, ", ") + `))
+		SELECT equipment_id, metric, sum(value_sum) AS value FROM (` + strings.Join(sums, " UNION ALL ") + `) AS partial GROUP BY equipment_id, metric`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer preparedQuery.Close()
+	var totals []model.MetricTotal
+	err = preparedQuery.Select(&totals, arguments)
+	return totals, err
+}
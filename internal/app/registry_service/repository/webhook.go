@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	webhookColumns string = `id, url, event_types, kinds, statuses, secret, active, created_at, updated_at`
+	deliveryColumns = `id, webhook_id, event, payload, status, attempts, response_status, last_error, created_at, next_attempt_at, delivered_at`
+)
+
+type Webhook struct {
+	db *sqlx.DB
+}
+
+func NewWebhook(db *sqlx.DB) Webhook {
+	return Webhook{db: db}
+}
+
+func eventTypeArray(eventTypes []model.ChangeEvent) pq.StringArray {
+	array := make(pq.StringArray, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		array = append(array, string(eventType))
+	}
+	return array
+}
+
+func integralArray[T model.EquipmentKind|model.OperationalStatus](values []T) pq.Int64Array {
+	array := make(pq.Int64Array, 0, len(values))
+	for _, value := range values {
+		array = append(array, int64(value))
+	}
+	return array
+}
+
+func (repository *Webhook) List() ([]*dtos.WebhookGet, error) {
+	var webhookModels []model.Webhook
+	if err := repository.db.Select(&webhookModels, `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY id`); err != nil {
+		return nil, err
+	}
+	webhookGets := make([]*dtos.WebhookGet, 0, len(webhookModels))
+	for _, webhookModel := range webhookModels {
+		webhookGets = append(webhookGets, dtos.WebhookGetFromModel(webhookModel))
+	}
+	return webhookGets, nil
+}
+
+func (repository *Webhook) Create(webhookCreate *dtos.WebhookCreate) (int64, error) {
+	active := webhookCreate.Active == nil || *webhookCreate.Active
+	var id int64
+	err := repository.db.QueryRow(
+		`INSERT INTO webhooks (url, event_types, kinds, statuses, secret, active) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		webhookCreate.URL, eventTypeArray(webhookCreate.EventTypes), integralArray(webhookCreate.Kinds),
+		integralArray(webhookCreate.Statuses), webhookCreate.Secret, active,
+	).Scan(&id)
+	return id, err
+}
+
+func (repository *Webhook) Update(webhookUpdate *dtos.WebhookUpdate) (bool, error) {
+	set := make([]string, 0, 6)
+	arguments := map[string]interface{}{
+		"id":			webhookUpdate.Id,
+		"updated_at":	time.Now(),
+	}
+	if webhookUpdate.URL != nil {
+		set = append(set, "url=:url")
+		arguments["url"] = *webhookUpdate.URL
+	}
+	if webhookUpdate.EventTypes != nil {
+		set = append(set, "event_types=:event_types")
+		arguments["event_types"] = eventTypeArray(*webhookUpdate.EventTypes)
+	}
+	if webhookUpdate.Kinds != nil {
+		set = append(set, "kinds=:kinds")
+		arguments["kinds"] = integralArray(*webhookUpdate.Kinds)
+	}
+	if webhookUpdate.Statuses != nil {
+		set = append(set, "statuses=:statuses")
+		arguments["statuses"] = integralArray(*webhookUpdate.Statuses)
+	}
+	if webhookUpdate.Secret != nil {
+		set = append(set, "secret=:secret")
+		arguments["secret"] = *webhookUpdate.Secret
+	}
+	if webhookUpdate.Active != nil {
+		set = append(set, "active=:active")
+		arguments["active"] = *webhookUpdate.Active
+	}
+	if len(set) == 0 {
+		return false, nil // Nothing to update
+	}
+	return checkAffect(repository.db.NamedExec(
+		`UPDATE webhooks SET ` + strings.Join(set, ", ") + `, updated_at=:updated_at WHERE id=:id`,
+		arguments,
+	))
+}
+
+func (repository *Webhook) FindById(id int64) (*dtos.WebhookGet, error) {
+	var webhookModel model.Webhook
+	if err := repository.db.Get(&webhookModel, `SELECT ` + webhookColumns + ` FROM webhooks WHERE id=$1`, id); err != nil {
+		return nil, err
+	}
+	return dtos.WebhookGetFromModel(webhookModel), nil
+}
+
+// RemoveById removes the webhook along with its deliveries.
+func (repository *Webhook) RemoveById(id int64) (bool, error) {
+	return checkAffect(repository.db.Exec(`DELETE FROM webhooks WHERE id=$1`, id))
+}
+
+// ListDeliveries returns the total number of deliveries of the webhook and the page of them from the newest.
+func (repository *Webhook) ListDeliveries(id int64, page *dtos.HistoryPage) (int, []model.WebhookDelivery, error) {
+	var total int
+	if err := repository.db.Get(&total, `SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id=$1`, id); err != nil {
+		return 0, nil, err
+	}
+	var deliveryModels []model.WebhookDelivery
+	err := repository.db.Select(
+		&deliveryModels,
+		`SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE webhook_id=$1 ORDER BY id DESC LIMIT $2 OFFSET $3`,
+		id, page.PerPage, (page.Page - 1) * page.PerPage,
+	)
+	return total, deliveryModels, err
+}
+
+// enqueueDeliveries adds the delivery of the change to each active webhook subscribed to its event
+// of the equipment with the resulting kind and status within the transaction storing the change.
+func enqueueDeliveries(tx *sqlx.Tx, change *model.AggregateChange) error {
+	event, ok := model.ChangeEventOf(change.Event.Type)
+	if !ok {
+		return nil
+	}
+	payload, err := json.Marshal(dtos.WebhookPayload {
+		Event:		event,
+		OccurredAt:	change.Event.OccurredAt,
+		Equipment:	dtos.EquipmentGetFromModel(change.State),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		`INSERT INTO webhook_deliveries (webhook_id, event, payload, created_at, next_attempt_at)
+		SELECT id, $1, $4, $5, $5 FROM webhooks
+		WHERE active
+		AND (cardinality(event_types)=0 OR $1=ANY(event_types))
+		AND (cardinality(kinds)=0 OR $2=ANY(kinds))
+		AND (cardinality(statuses)=0 OR $3=ANY(statuses))`,
+		string(event), int64(change.State.Kind), int64(change.State.Status), payload, change.Event.OccurredAt,
+	)
+	return err
+}
+
+// Dispatch leases up to limit pending deliveries which are due for the lease duration, passes each of them to deliver
+// and stores the outcome: a failed delivery is retried after the delay returned by backoff for the number of attempts made
+// or becomes dead after maxAttempts. No transaction is held while delivering; dispatchers of several replicas share the queue
+// and the deliveries of a crashed dispatcher are taken again once the lease expires.
+func (repository *Webhook) Dispatch(
+	limit int, maxAttempts int, lease time.Duration,
+	deliver func(*model.OutgoingDelivery) (*int, error),
+	backoff func(attempts int) time.Duration,
+) (int, error) {
+	var deliveries []model.OutgoingDelivery
+	now := time.Now()
+	err := repository.db.Select(
+		&deliveries,
+		`WITH leased AS (
+			UPDATE webhook_deliveries SET locked_until=$3
+			WHERE id IN (
+				SELECT id FROM webhook_deliveries
+				WHERE status='pending' AND next_attempt_at<=$1 AND (locked_until IS NULL OR locked_until<=$1)
+				ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + deliveryColumns + `
+		)
+		SELECT delivery.id, delivery.webhook_id, delivery.event, delivery.payload, delivery.status, delivery.attempts,
+			delivery.response_status, delivery.last_error, delivery.created_at, delivery.next_attempt_at, delivery.delivered_at,
+			webhook.url, webhook.secret
+		FROM leased delivery JOIN webhooks webhook ON webhook.id=delivery.webhook_id
+		ORDER BY delivery.id`,
+		now, limit, now.Add(lease),
+	)
+	if err != nil {
+		return 0, err
+	}
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		responseStatus, deliveryError := deliver(delivery)
+		delivery.Attempts++
+		now := time.Now()
+		if deliveryError == nil {
+			_, err = repository.db.Exec(
+				`UPDATE webhook_deliveries SET status=$2, attempts=$3, response_status=$4, last_error=NULL, delivered_at=$5, locked_until=NULL
+				WHERE id=$1`,
+				delivery.Id, model.DeliveryDelivered, delivery.Attempts, responseStatus, now,
+			)
+		} else {
+			status := model.DeliveryPending
+			if delivery.Attempts >= maxAttempts {
+				status = model.DeliveryDead
+			}
+			_, err = repository.db.Exec(
+				`UPDATE webhook_deliveries SET status=$2, attempts=$3, response_status=$4, last_error=$5, next_attempt_at=$6, locked_until=NULL
+				WHERE id=$1`,
+				delivery.Id, status, delivery.Attempts, responseStatus, deliveryError.Error(), now.Add(backoff(delivery.Attempts)),
+			)
+		}
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(deliveries), nil
+}
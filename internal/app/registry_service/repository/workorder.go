@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const workOrderColumns string = `id, equipment_id, plan_id, title, description, state, opened_by, assignee, closed_by, resolution, parts,
+	time_spent_seconds, manages_status, created_at, assigned_at, started_at, closed_at, updated_at`
+
+type WorkOrder struct {
+	db *sqlx.DB
+}
+
+func NewWorkOrder(db *sqlx.DB) WorkOrder {
+	return WorkOrder{db: db}
+}
+
+// lockEquipment locks the row of the equipment of the work order until the end of the transaction, so the status changes
+// by the work orders of the equipment are serialized; fails with sql.ErrNoRows for unknown equipment.
+func lockEquipment(tx *sqlx.Tx, equipmentId uuid.UUID) error {
+	var id uuid.UUID
+	return tx.Get(&id, `SELECT id FROM equipment WHERE id=$1 FOR UPDATE`, equipmentId)
+}
+
+// changeStatus moves the equipment to the status checked by check within the transaction; returns the version
+// of the aggregate after the change, 0 if the equipment already has the status.
+func changeStatus(
+	tx *sqlx.Tx, equipmentId uuid.UUID, status model.OperationalStatus, reason, actor string,
+	check func(from, to model.OperationalStatus, reason string) error,
+) (int, error) {
+	aggregate, err := loadAggregate(tx, equipmentId)
+	if err != nil {
+		return 0, err
+	} else if !aggregate.Exists() {
+		return 0, sql.ErrNoRows
+	} else if aggregate.State.Status == status {
+		return 0, nil
+	}
+	if err = check(aggregate.State.Status, status, reason); err != nil {
+		return 0, err
+	}
+	if err = aggregate.ChangeStatus(status, reason, actor, time.Now()); err != nil {
+		return 0, err
+	}
+	return aggregate.Version, saveAggregate(tx, aggregate)
+}
+
+// Create inserts the work order of the existing equipment (sql.ErrNoRows for unknown one) with the equipment row locked;
+// the work order managing the status moves the equipment UnderMaintenance (unless it is already) within the same transaction,
+// check validates the transition.
+func (repository *WorkOrder) Create(workOrder *model.WorkOrder, check func(from, to model.OperationalStatus, reason string) error) (int64, error) {
+	var id int64
+	err := inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		if err := lockEquipment(tx, workOrder.EquipmentId); err != nil {
+			return err
+		}
+		var statusVersion *int
+		if workOrder.ManagesStatus {
+			reason := fmt.Sprintf("Work order opened: %s", workOrder.Title)
+			version, err := changeStatus(tx, workOrder.EquipmentId, model.UnderMaintenance, reason, workOrder.OpenedBy, check)
+			if err != nil {
+				return err
+			} else if version > 0 {
+				statusVersion = &version
+			}
+		}
+		return wrapConflict(tx.QueryRow(
+			`INSERT INTO work_orders (equipment_id, plan_id, title, description, state, opened_by, parts, manages_status, status_version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+			workOrder.EquipmentId, workOrder.PlanId, workOrder.Title, workOrder.Description, workOrder.State,
+			workOrder.OpenedBy, workOrder.Parts, workOrder.ManagesStatus, statusVersion,
+		).Scan(&id))
+	})
+	return id, err
+}
+
+func updateWorkOrder(execer sqlx.Ext, workOrder *model.WorkOrder, fromState model.WorkOrderState) (bool, error) {
+	return checkAffect(sqlx.NamedExec(
+		execer,
+		`UPDATE work_orders SET state=:state, assignee=:assignee, closed_by=:closed_by, resolution=:resolution, parts=:parts,
+			time_spent_seconds=:time_spent_seconds, assigned_at=:assigned_at, started_at=:started_at, closed_at=:closed_at,
+			updated_at=:updated_at
+		WHERE id=:id AND state=:from_state`,
+		map[string]interface{}{
+			"id":					workOrder.Id,
+			"state":				workOrder.State,
+			"assignee":				workOrder.Assignee,
+			"closed_by":			workOrder.ClosedBy,
+			"resolution":			workOrder.Resolution,
+			"parts":				workOrder.Parts,
+			"time_spent_seconds":	workOrder.TimeSpentSeconds,
+			"assigned_at":			workOrder.AssignedAt,
+			"started_at":			workOrder.StartedAt,
+			"closed_at":			workOrder.ClosedAt,
+			"updated_at":			workOrder.UpdatedAt,
+			"from_state":			fromState,
+		},
+	))
+}
+
+// Update stores the changed work order provided it still has the state fromState.
+func (repository *WorkOrder) Update(workOrder *model.WorkOrder, fromState model.WorkOrderState) (bool, error) {
+	return updateWorkOrder(repository.db, workOrder, fromState)
+}
+
+// Close stores the closed work order provided it still has the state fromState with the equipment row locked
+// and settles its reservations of the spare parts (see settleWorkOrder) within the same transaction.
+// Once no other open work order manages the status of the equipment, the equipment is returned Operational within the same transaction
+// (check validates the transition) provided its last status change is the one made by a work order, so the status set manually stays.
+func (repository *WorkOrder) Close(
+	workOrder *model.WorkOrder, fromState model.WorkOrderState,
+	check func(from, to model.OperationalStatus, reason string) error,
+) (bool, error) {
+	var closed bool
+	err := inTransaction(repository.db, func(tx *sqlx.Tx) error {
+		err := lockEquipment(tx, workOrder.EquipmentId)
+		removed := errors.Is(err, sql.ErrNoRows)
+		if err != nil && !removed {
+			return err
+		}
+		if closed, err = updateWorkOrder(tx, workOrder, fromState); err != nil || !closed {
+			return err
+		}
+		if err = settleWorkOrder(tx, workOrder); err != nil || removed || !workOrder.ManagesStatus {
+			return err
+		}
+		var returned bool
+		if err = tx.Get(
+			&returned,
+			`SELECT NOT EXISTS (
+				SELECT 1 FROM work_orders WHERE equipment_id=$1 AND manages_status AND state NOT IN ('done', 'cancelled')
+			) AND EXISTS (
+				SELECT 1 FROM work_orders WHERE equipment_id=$1 AND manages_status AND status_version=(
+					SELECT max(version) FROM equipment_events WHERE aggregate_id=$1 AND type=ANY($2)
+				)
+			)`,
+			workOrder.EquipmentId, pq.StringArray{string(model.EquipmentRegistered), string(model.StatusChanged), string(model.EquipmentRevised)},
+		); err != nil || !returned {
+			return err
+		}
+		reason := fmt.Sprintf("Work order #%d is %s", workOrder.Id, workOrder.State)
+		_, err = changeStatus(tx, workOrder.EquipmentId, model.Operational, reason, workOrder.ClosedBy, check)
+		return err
+	})
+	return closed, err
+}
+
+func (repository *WorkOrder) FindById(id int64) (*model.WorkOrder, error) {
+	var workOrderModel model.WorkOrder
+	if err := repository.db.Get(&workOrderModel, `SELECT ` + workOrderColumns + ` FROM work_orders WHERE id=$1`, id); err != nil {
+		return nil, err
+	}
+	return &workOrderModel, nil
+}
+
+// List returns the total number of work orders matching the filter and the page of them from the newest.
+func (repository *WorkOrder) List(workOrderFilter *dtos.WorkOrderFilter) (int, []*dtos.WorkOrderGet, error) {
+	conditions := make([]string, 0, 4)
+	arguments := map[string]interface{}{
+		"limit":	workOrderFilter.PerPage,
+		"offset":	(workOrderFilter.Page - 1) * workOrderFilter.PerPage,
+	}
+	if len(workOrderFilter.EquipmentIds) > 0 {
+		conditions = append(conditions, "equipment_id=ANY(CAST(:equipment_ids AS uuid[]))")
+		arguments["equipment_ids"] = pq.Array(workOrderFilter.EquipmentIds)
+	}
+	if len(workOrderFilter.States) > 0 {
+		states := make(pq.StringArray, 0, len(workOrderFilter.States))
+		for _, state := range workOrderFilter.States {
+			states = append(states, string(state))
+		}
+		conditions = append(conditions, "state=ANY(:states)")
+		arguments["states"] = states
+	}
+	if len(workOrderFilter.Assignees) > 0 {
+		conditions = append(conditions, "assignee=ANY(:assignees)")
+		arguments["assignees"] = pq.StringArray(workOrderFilter.Assignees)
+	}
+	if len(workOrderFilter.PlanIds) > 0 {
+		conditions = append(conditions, "plan_id=ANY(:plan_ids)")
+		arguments["plan_ids"] = pq.Int64Array(workOrderFilter.PlanIds)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	var total int
+	countQuery, err := repository.db.PrepareNamed(`SELECT COUNT(*) FROM work_orders` + where)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer countQuery.Close()
+	if err = countQuery.Get(&total, arguments); err != nil {
+		return 0, nil, err
+	}
+	pageQuery, err := repository.db.PrepareNamed(`SELECT ` + workOrderColumns + ` FROM work_orders` + where + ` ORDER BY id DESC LIMIT :limit OFFSET :offset`)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer pageQuery.Close()
+	var workOrderModels []model.WorkOrder
+	if err = pageQuery.Select(&workOrderModels, arguments); err != nil {
+		return 0, nil, err
+	}
+	workOrderGets := make([]*dtos.WorkOrderGet, 0, len(workOrderModels))
+	for _, workOrderModel := range workOrderModels {
+		workOrderGets = append(workOrderGets, dtos.WorkOrderGetFromModel(workOrderModel))
+	}
+	return total, workOrderGets, nil
+}
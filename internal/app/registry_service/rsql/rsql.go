@@ -0,0 +1,383 @@
+// Package rsql parses the filter expressions in the spirit of RSQL/FIQL into the syntax tree:
+//
+//	expression := or
+//	or := and {("," | "or") and}
+//	and := unary {(";" | "and") unary}
+//	unary := ("!" | "not") unary | "(" or ")" | comparison
+//	comparison := selector operator (argument | "(" argument {"," argument} ")")
+//
+// Operators are "==" (or "="), "!=", "<" (or "=lt="), "<=" (or "=le="), ">" (or "=gt="), ">=" (or "=ge="),
+// "=in=", "=out=" and "=exists="; arguments are words or strings quoted by ' or " (with \ escaping the quote),
+// the quoted ones are marked so the caller takes them as strings.
+// Keywords are case insensitive. The meaning of the selectors and the arguments is up to the caller (see Walk).
+package rsql
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	maxLength int = 4096
+	maxDepth = 32
+	tooLong = "Filter is longer than %d characters"
+	tooDeep = "Filter is nested deeper than %d levels"
+	unexpected = "Unexpected %s at %d in the filter, expected %s"
+)
+
+type Operator string
+const (
+	Eq Operator = "=="
+	Ne Operator = "!="
+	Lt Operator = "=lt="
+	Le Operator = "=le="
+	Gt Operator = "=gt="
+	Ge Operator = "=ge="
+	In Operator = "=in="
+	Out Operator = "=out="
+	Exists Operator = "=exists="
+)
+
+var operators = map[string]Operator {
+	"=":		Eq,
+	"==":		Eq,
+	"!=":		Ne,
+	"<":		Lt,
+	"=lt=":		Lt,
+	"<=":		Le,
+	"=le=":		Le,
+	">":		Gt,
+	"=gt=":		Gt,
+	">=":		Ge,
+	"=ge=":		Ge,
+	"=in=":		In,
+	"=out=":	Out,
+	"=exists=":	Exists,
+}
+
+// IsList reports whether the operator takes a list of arguments.
+func (operator Operator) IsList() bool {
+	return operator == In || operator == Out
+}
+
+type Node interface {
+	node()
+}
+
+type And struct {
+	Operands	[]Node
+}
+
+type Or struct {
+	Operands	[]Node
+}
+
+type Not struct {
+	Operand	Node
+}
+
+// Argument is the argument of the comparison as written, without the quotes.
+type Argument struct {
+	Text	string
+	Quoted	bool
+}
+
+type Comparison struct {
+	Selector	string
+	Operator	Operator
+	Arguments	[]Argument
+	Values		[]interface{}	// The arguments typed by the caller
+	Position	int				// Of the selector in the expression
+}
+
+func (*And) node() {}
+func (*Or) node() {}
+func (*Not) node() {}
+func (*Comparison) node() {}
+
+// Walk calls the visit for each comparison of the tree in order of the expression.
+func Walk(node Node, visit func(*Comparison) error) error {
+	switch node := node.(type) {
+		case *And:
+			for _, operand := range node.Operands {
+				if err := Walk(operand, visit); err != nil {
+					return err
+				}
+			}
+		case *Or:
+			for _, operand := range node.Operands {
+				if err := Walk(operand, visit); err != nil {
+					return err
+				}
+			}
+		case *Not:
+			return Walk(node.Operand, visit)
+		case *Comparison:
+			return visit(node)
+	}
+	return nil
+}
+
+// Parse returns the tree of the expression.
+func Parse(expression string) (Node, error) {
+	if len(expression) > maxLength {
+		return nil, fmt.Errorf(tooLong, maxLength)
+	}
+	parser := parser{lexer: lexer{input: expression}}
+	if err := parser.advance(); err != nil {
+		return nil, err
+	}
+	node, err := parser.or(0)
+	if err != nil {
+		return nil, err
+	}
+	if parser.token.kind != endToken {
+		return nil, parser.unexpected("end of the filter")
+	}
+	return node, nil
+}
+
+
+type tokenKind int
+const (
+	endToken tokenKind = iota
+	wordToken
+	quotedToken
+	operatorToken
+	openToken
+	closeToken
+	andToken
+	orToken
+	notToken
+)
+
+type token struct {
+	kind		tokenKind
+	text		string
+	position	int
+}
+
+// specials end the words.
+const specials string = "()=!<>;,'\""
+
+type lexer struct {
+	input		string
+	position	int
+}
+
+func (lexer *lexer) next() (token, error) {
+	input := lexer.input
+	for lexer.position < len(input) && strings.ContainsRune(" \t\r\n", rune(input[lexer.position])) {
+		lexer.position++
+	}
+	start := lexer.position
+	if start == len(input) {
+		return token{kind: endToken, position: start}, nil
+	}
+	switch character := input[start]; character {
+		case '(':
+			lexer.position++
+			return token{kind: openToken, text: "(", position: start}, nil
+		case ')':
+			lexer.position++
+			return token{kind: closeToken, text: ")", position: start}, nil
+		case ';':
+			lexer.position++
+			return token{kind: andToken, text: ";", position: start}, nil
+		case ',':
+			lexer.position++
+			return token{kind: orToken, text: ",", position: start}, nil
+		case '\'', '"':
+			var text strings.Builder
+			for lexer.position++; lexer.position < len(input); lexer.position++ {
+				switch input[lexer.position] {
+					case '\\':
+						if lexer.position + 1 < len(input) {
+							lexer.position++
+						}
+					case character:
+						lexer.position++
+						return token{kind: quotedToken, text: text.String(), position: start}, nil
+				}
+				text.WriteByte(input[lexer.position])
+			}
+			return token{}, fmt.Errorf("Unterminated string at %d in the filter", start)
+		case '!', '<', '>':
+			lexer.position++
+			if lexer.position < len(input) && input[lexer.position] == '=' {
+				lexer.position++
+			} else if character == '!' {
+				return token{kind: notToken, text: "!", position: start}, nil
+			}
+			return token{kind: operatorToken, text: input[start:lexer.position], position: start}, nil
+		case '=':
+			lexer.position++
+			end := lexer.position
+			for end < len(input) && input[end] >= 'a' && input[end] <= 'z' {
+				end++
+			}
+			if end < len(input) && input[end] == '=' {
+				lexer.position = end + 1	// =name= or ==
+			}
+			return token{kind: operatorToken, text: input[start:lexer.position], position: start}, nil
+	}
+	for lexer.position < len(input) && !strings.ContainsRune(specials + " \t\r\n", rune(input[lexer.position])) {
+		lexer.position++
+	}
+	text := input[start:lexer.position]
+	kind := wordToken
+	switch strings.ToLower(text) {
+		case "and":
+			kind = andToken
+		case "or":
+			kind = orToken
+		case "not":
+			kind = notToken
+	}
+	return token{kind: kind, text: text, position: start}, nil
+}
+
+
+type parser struct {
+	lexer	lexer
+	token	token
+}
+
+func (parser *parser) advance() (err error) {
+	parser.token, err = parser.lexer.next()
+	return
+}
+
+func (parser *parser) unexpected(expected string) error {
+	if parser.token.kind == endToken {
+		return fmt.Errorf(unexpected, "end", parser.token.position, expected)
+	}
+	return fmt.Errorf(unexpected, "`" + parser.token.text + "`", parser.token.position, expected)
+}
+
+func (parser *parser) or(depth int) (Node, error) {
+	operands, err := parser.operands(orToken, func() (Node, error) { return parser.and(depth) })
+	if err != nil || len(operands) == 1 {
+		return first(operands), err
+	}
+	return &Or{Operands: operands}, nil
+}
+
+func (parser *parser) and(depth int) (Node, error) {
+	operands, err := parser.operands(andToken, func() (Node, error) { return parser.unary(depth) })
+	if err != nil || len(operands) == 1 {
+		return first(operands), err
+	}
+	return &And{Operands: operands}, nil
+}
+
+func first(nodes []Node) Node {
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[0]
+}
+
+// operands parses the operands separated by the token of the kind.
+func (parser *parser) operands(separator tokenKind, operand func() (Node, error)) ([]Node, error) {
+	var operands []Node
+	for {
+		node, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, node)
+		if parser.token.kind != separator {
+			return operands, nil
+		}
+		if err = parser.advance(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (parser *parser) unary(depth int) (Node, error) {
+	if depth >= maxDepth {
+		return nil, fmt.Errorf(tooDeep, maxDepth)
+	}
+	switch parser.token.kind {
+		case notToken:
+			if err := parser.advance(); err != nil {
+				return nil, err
+			}
+			operand, err := parser.unary(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			return &Not{Operand: operand}, nil
+		case openToken:
+			if err := parser.advance(); err != nil {
+				return nil, err
+			}
+			node, err := parser.or(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			if parser.token.kind != closeToken {
+				return nil, parser.unexpected("`)`")
+			}
+			return node, parser.advance()
+		case wordToken:
+			return parser.comparison()
+	}
+	return nil, parser.unexpected("a comparison")
+}
+
+func (parser *parser) comparison() (Node, error) {
+	comparison := Comparison{Selector: parser.token.text, Position: parser.token.position}
+	if err := parser.advance(); err != nil {
+		return nil, err
+	}
+	operator, ok := operators[parser.token.text]
+	if parser.token.kind != operatorToken || !ok {
+		return nil, parser.unexpected("a comparison operator")
+	}
+	comparison.Operator = operator
+	if err := parser.advance(); err != nil {
+		return nil, err
+	}
+	if parser.token.kind != openToken {
+		argument, err := parser.argument()
+		if err != nil {
+			return nil, err
+		}
+		comparison.Arguments = []Argument{argument}
+		return &comparison, nil
+	}
+	for {
+		if err := parser.advance(); err != nil {
+			return nil, err
+		}
+		argument, err := parser.argument()
+		if err != nil {
+			return nil, err
+		}
+		comparison.Arguments = append(comparison.Arguments, argument)
+		if parser.token.kind == closeToken {
+			return &comparison, parser.advance()
+		}
+		if parser.token.kind != orToken || parser.token.text != "," {
+			return nil, parser.unexpected("`,` or `)`")
+		}
+	}
+}
+
+// argument takes the word (keywords included) or the quoted string.
+func (parser *parser) argument() (Argument, error) {
+	switch parser.token.kind {
+		case andToken, orToken, notToken:
+			if strings.ContainsAny(parser.token.text, specials) {
+				return Argument{}, parser.unexpected("an argument")	// `;`, `,` or `!`
+			}
+		case wordToken, quotedToken:
+		default:
+			return Argument{}, parser.unexpected("an argument")
+	}
+	argument := Argument{Text: parser.token.text, Quoted: parser.token.kind == quotedToken}
+	return argument, parser.advance()
+}
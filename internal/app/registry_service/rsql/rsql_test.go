@@ -0,0 +1,163 @@
+package rsql
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// format writes the tree compactly: `(x;y)` for And, `(x,y)` for Or, `!x` for Not, `selector operator [arguments|...]` for the comparisons
+// (the quoted arguments in Go syntax).
+func format(node Node) string {
+	switch node := node.(type) {
+		case *And:
+			return "(" + formatOperands(node.Operands, ";") + ")"
+		case *Or:
+			return "(" + formatOperands(node.Operands, ",") + ")"
+		case *Not:
+			return "!" + format(node.Operand)
+		case *Comparison:
+			arguments := make([]string, 0, len(node.Arguments))
+			for _, argument := range node.Arguments {
+				if argument.Quoted {
+					arguments = append(arguments, strconv.Quote(argument.Text))
+				} else {
+					arguments = append(arguments, argument.Text)
+				}
+			}
+			return node.Selector + string(node.Operator) + "[" + strings.Join(arguments, "|") + "]"
+	}
+	return "?"
+}
+
+func formatOperands(operands []Node, separator string) string {
+	formatted := make([]string, 0, len(operands))
+	for _, operand := range operands {
+		formatted = append(formatted, format(operand))
+	}
+	return strings.Join(formatted, separator)
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		expression	string
+		tree		string
+	}{
+		// And binds tighter than or, not binds tighter than and
+		{"a==1", "a==[1]"},
+		{"a==1,b==2;c==3", "(a==[1],(b==[2];c==[3]))"},
+		{"a==1;b==2,c==3", "((a==[1];b==[2]),c==[3])"},
+		{"a==1;b==2;c==3", "(a==[1];b==[2];c==[3])"},
+		{"(a==1,b==2);c==3", "((a==[1],b==[2]);c==[3])"},
+		{"!a==1;b==2", "(!a==[1];b==[2])"},
+		{"!(a==1;b==2)", "!(a==[1];b==[2])"},
+		{"!!a==1", "!!a==[1]"},
+		{"((a==1))", "a==[1]"},
+		{"not a==1 or b==2 AND c==3", "(!a==[1],(b==[2];c==[3]))"},
+		{" a == 1 ;\tb == 2\n", "(a==[1];b==[2])"},
+		// Operators and their aliases
+		{"a=1", "a==[1]"},
+		{"a!=1", "a!=[1]"},
+		{"a<1", "a=lt=[1]"},
+		{"a=lt=1", "a=lt=[1]"},
+		{"a<=1", "a=le=[1]"},
+		{"a>1", "a=gt=[1]"},
+		{"a>=1", "a=ge=[1]"},
+		{"a=ge=1", "a=ge=[1]"},
+		{"a=exists=true", "a=exists=[true]"},
+		// Lists
+		{"a=in=(1,2,3)", "a=in=[1|2|3]"},
+		{"a=out=( x , 'y z' )", `a=out=[x|"y z"]`},
+		{"a=in=(1);b=in=(2)", "(a=in=[1];b=in=[2])"},
+		{"a=in=1", "a=in=[1]"},
+		// Quoting and escapes
+		{"a=='x;y,z'", `a==["x;y,z"]`},
+		{`a=="it's"`, `a==["it's"]`},
+		{`a=='it\'s'`, `a==["it's"]`},
+		{`a=="\"x\""`, `a==["\"x\""]`},
+		{`a=='back\\slash'`, `a==["back\\slash"]`},
+		{`a=='\x'`, `a==["x"]`},
+		{"a==''", `a==[""]`},
+		{"a=='(not)'", `a==["(not)"]`},
+		{`a=="0042";b==0042`, `(a==["0042"];b==[0042])`},
+		{`a=in=("1",2,'3')`, `a=in=["1"|2|"3"]`},
+		// Keywords are the arguments after the operator
+		{"a==and;b==NOT", "(a==[and];b==[NOT])"},
+		{"param.x-y==2024-01-01T00:00:00Z", "param.x-y==[2024-01-01T00:00:00Z]"},
+	} {
+		node, err := Parse(test.expression)
+		if err != nil {
+			t.Errorf("%q: %v", test.expression, err)
+		} else if tree := format(node); tree != test.tree {
+			t.Errorf("%q: tree %s, expected %s", test.expression, tree, test.tree)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	nested := func(open, close string, count int) string {
+		return strings.Repeat(open, count) + "a==1" + strings.Repeat(close, count)
+	}
+	for _, test := range []struct {
+		expression	string
+		err			string	// Empty for the expression parsed
+	}{
+		{"", "Unexpected end at 0 in the filter, expected a comparison"},
+		{"a", "Unexpected end at 1 in the filter, expected a comparison operator"},
+		{"a==", "Unexpected end at 3 in the filter, expected an argument"},
+		{"a=foo=1", "Unexpected `=foo=` at 1 in the filter, expected a comparison operator"},
+		{"a or b", "Unexpected `or` at 2 in the filter, expected a comparison operator"},
+		{"==1", "Unexpected `==` at 0 in the filter, expected a comparison"},
+		{"a==1;", "Unexpected end at 5 in the filter, expected a comparison"},
+		{"a==1 b==2", "Unexpected `b` at 5 in the filter, expected end of the filter"},
+		{"a==;", "Unexpected `;` at 3 in the filter, expected an argument"},
+		{"a==!", "Unexpected `!` at 3 in the filter, expected an argument"},
+		{"(a==1", "Unexpected end at 5 in the filter, expected `)`"},
+		{"a==1)", "Unexpected `)` at 4 in the filter, expected end of the filter"},
+		{"()", "Unexpected `)` at 1 in the filter, expected a comparison"},
+		{"a=in=()", "Unexpected `)` at 6 in the filter, expected an argument"},
+		{"a=in=(1,)", "Unexpected `)` at 8 in the filter, expected an argument"},
+		{"a=in=(1;2)", "Unexpected `;` at 7 in the filter, expected `,` or `)`"},
+		{"a=in=(1 or 2)", "Unexpected `or` at 8 in the filter, expected `,` or `)`"},
+		{"a=in=(1", "Unexpected end at 7 in the filter, expected `,` or `)`"},
+		{"a=='x", "Unterminated string at 3 in the filter"},
+		{`a=='x\'`, "Unterminated string at 3 in the filter"},
+		{`a=='x\`, "Unterminated string at 3 in the filter"},
+		// Limits
+		{nested("(", ")", maxDepth - 1), ""},
+		{nested("(", ")", maxDepth), "Filter is nested deeper than 32 levels"},
+		{nested("!", "", maxDepth - 1), ""},
+		{nested("!", "", maxDepth), "Filter is nested deeper than 32 levels"},
+		{nested("!(", ")", maxDepth / 2), "Filter is nested deeper than 32 levels"},
+		{"a==" + strings.Repeat("x", maxLength - 3), ""},
+		{"a==" + strings.Repeat("x", maxLength - 2), "Filter is longer than 4096 characters"},
+	} {
+		_, err := Parse(test.expression)
+		name := test.expression
+		if len(name) > 40 {
+			name = name[:40] + "..."
+		}
+		switch {
+			case err == nil && test.err != "":
+				t.Errorf("%q: parsed, expected error %q", name, test.err)
+			case err != nil && err.Error() != test.err:
+				t.Errorf("%q: error %q, expected %q", name, err, test.err)
+		}
+	}
+}
+
+func TestParsePositions(t *testing.T) {
+	node, err := Parse("a==1; not (bb=in=(x,y), c==2)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var positions []int
+	_ = Walk(node, func(comparison *Comparison) error {
+		positions = append(positions, comparison.Position)
+		return nil
+	})
+	if expected := []int{0, 11, 24}; !slices.Equal(positions, expected) {
+		t.Errorf("positions %v, expected %v", positions, expected)
+	}
+}
@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"google.golang.org/grpc"
+)
+
+type GrpcServer struct {
+	*grpc.Server
+}
+
+func NewGrpcServer(options ...grpc.ServerOption) GrpcServer {
+	return GrpcServer{Server: grpc.NewServer(options...)}
+}
+
+// Start serves on the port until the interrupt and then stops gracefully.
+func (gs *GrpcServer) Start(port string) {
+	listener, err := net.Listen("tcp", port)
+	if err != nil {
+		log.Fatalf("gRPC listen error: %v", err)
+	}
+	go func() {
+		if err := gs.Serve(listener); err != nil {
+			log.Fatalf("gRPC server error: %v", err)
+		}
+		log.Println("Stopped serving new connections.")
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+
+	gs.GracefulStop()
+	log.Println("Graceful shutdown complete.")
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const undeclaredMetric string = "Metric `%s` is not declared for equipment kind %s"
+
+type AlertRepository interface {
+	ListRules() ([]*dtos.AlertRuleGet, error)
+	ListActiveRules(kind model.EquipmentKind) ([]model.AlertRule, error)
+	CreateRule(ruleCreate *dtos.AlertRuleCreate) (int64, error)
+	UpdateRule(ruleUpdate *dtos.AlertRuleUpdate) (bool, error)
+	FindRuleById(id int64) (*dtos.AlertRuleGet, error)
+	RemoveRuleById(id int64) (bool, error)
+	ListAlerts(alertFilter *dtos.AlertFilter) (int, []*dtos.AlertGet, error)
+	EvaluateAlerts(equipmentId uuid.UUID, evaluate func(open []model.Alert) []*model.Alert) error
+}
+
+// Alerting manages the threshold rules and, as ReadingListener, evaluates them against the ingested readings.
+type Alerting struct {
+	repository	AlertRepository
+	metrics		KindMetrics
+}
+
+func NewAlerting(repository AlertRepository, metrics KindMetrics) Alerting {
+	return Alerting{repository: repository, metrics: metrics}
+}
+
+func parseRuleId(ruleId string) (int64, error) {
+	id, err := strconv.ParseInt(ruleId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid alert rule id `%s`", ruleId)
+	}
+	return id, nil
+}
+
+// checkMetric checks that the metric is declared for the kind.
+func checkMetric(kindMetrics KindMetrics, kind model.EquipmentKind, metric string) error {
+	metrics, err := kindMetrics.Metrics(kind)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(metrics, func(declared model.Metric) bool { return declared.Name == metric }) {
+		return fmt.Errorf(undeclaredMetric, metric, kind)
+	}
+	return nil
+}
+
+func (service *Alerting) Rules() ([]*dtos.AlertRuleGet, error) {
+	return service.repository.ListRules()
+}
+
+// CreateRule checks that the metric is declared for the kind.
+func (service *Alerting) CreateRule(ruleCreate *dtos.AlertRuleCreate) (int64, error) {
+	if err := checkMetric(service.metrics, ruleCreate.Kind, ruleCreate.Metric); err != nil {
+		return 0, err
+	}
+	return service.repository.CreateRule(ruleCreate)
+}
+
+func (service *Alerting) UpdateRule(ruleUpdate *dtos.AlertRuleUpdate) (bool, error) {
+	if ruleUpdate.Metric != nil {
+		ruleGet, err := service.repository.FindRuleById(ruleUpdate.Id)
+		if err != nil {
+			return false, err
+		}
+		if err = checkMetric(service.metrics, ruleGet.Kind, *ruleUpdate.Metric); err != nil {
+			return false, err
+		}
+	}
+	return service.repository.UpdateRule(ruleUpdate)
+}
+
+func (service *Alerting) GetRule(ruleId string) (*dtos.AlertRuleGet, error) {
+	id, err := parseRuleId(ruleId)
+	if err != nil {
+		return nil, err
+	}
+	return service.repository.FindRuleById(id)
+}
+
+func (service *Alerting) DeleteRule(ruleId string) (bool, error) {
+	id, err := parseRuleId(ruleId)
+	if err != nil {
+		return false, err
+	}
+	return service.repository.RemoveRuleById(id)
+}
+
+func (service *Alerting) Alerts(alertFilter *dtos.AlertFilter) (*dtos.AlertList, error) {
+	total, alertGets, err := service.repository.ListAlerts(alertFilter)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.AlertList{Total: total, Page: alertFilter.Page, PerPage: alertFilter.PerPage, Alerts: alertGets}, nil
+}
+
+// ReadingsIngested evaluates the active rules of the equipment kind against the readings in order of their time.
+func (service *Alerting) ReadingsIngested(equipmentGet *dtos.EquipmentGet, readings []model.Reading, receivedAt time.Time) error {
+	selected, err := activeRules(service.repository.ListActiveRules, equipmentGet.Kind, readings, func(rule *model.AlertRule) string { return rule.Metric })
+	if err != nil || selected == nil {
+		return err
+	}
+	return service.repository.EvaluateAlerts(equipmentGet.Id, func(open []model.Alert) []*model.Alert {
+		openByRule := make(map[int64]*model.Alert, len(open))
+		for i := range open {
+			openByRule[open[i].RuleId] = &open[i]
+		}
+		var evaluated []*model.Alert
+		selected.apply(func(rule *model.AlertRule, reading *model.Reading) {
+			alert := rule.Evaluate(openByRule[rule.Id], equipmentGet.Id, reading, equipmentGet.Status)
+			if alert == nil {
+				return
+			}
+			if !slices.Contains(evaluated, alert) {
+				evaluated = append(evaluated, alert)
+			}
+			if alert.State.IsOpen() {
+				openByRule[rule.Id] = alert
+			} else {
+				delete(openByRule, rule.Id)
+			}
+		})
+		return evaluated
+	})
+}
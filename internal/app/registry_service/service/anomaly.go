@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+type AnomalyRepository interface {
+	ListDetectors() ([]*dtos.AnomalyDetectorGet, error)
+	ListActiveDetectors(kind model.EquipmentKind) ([]model.AnomalyDetector, error)
+	CreateDetector(detector *model.AnomalyDetector) (int64, error)
+	UpdateDetector(detector *model.AnomalyDetector, reset bool) (bool, error)
+	FindDetectorById(id int64) (*model.AnomalyDetector, error)
+	RemoveDetectorById(id int64) (bool, error)
+	ListAnomalies(equipmentId uuid.UUID, anomalyFilter *dtos.AnomalyFilter) (int, []*dtos.AnomalyGet, error)
+	Detect(
+		equipmentId uuid.UUID,
+		detectorIds []int64,
+		detect func(states []model.DetectorState) ([]*model.DetectorState, []*model.Anomaly),
+	) error
+}
+
+// AnomalyDetection manages the detectors and, as ReadingListener, applies them to the ingested readings
+// updating the stored baselines, so the stored readings are never read back.
+type AnomalyDetection struct {
+	repository	AnomalyRepository
+	equipment	EquipmentFinder
+	metrics		KindMetrics
+}
+
+func NewAnomalyDetection(repository AnomalyRepository, equipment EquipmentFinder, metrics KindMetrics) AnomalyDetection {
+	return AnomalyDetection{repository: repository, equipment: equipment, metrics: metrics}
+}
+
+func parseDetectorId(detectorId string) (int64, error) {
+	id, err := strconv.ParseInt(detectorId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid anomaly detector id `%s`", detectorId)
+	}
+	return id, nil
+}
+
+func (service *AnomalyDetection) Detectors() ([]*dtos.AnomalyDetectorGet, error) {
+	return service.repository.ListDetectors()
+}
+
+// CreateDetector checks that the metric is declared for the kind.
+func (service *AnomalyDetection) CreateDetector(detectorCreate *dtos.AnomalyDetectorCreate) (int64, error) {
+	if err := checkMetric(service.metrics, detectorCreate.Kind, detectorCreate.Metric); err != nil {
+		return 0, err
+	}
+	return service.repository.CreateDetector(detectorCreate.Detector())
+}
+
+// UpdateDetector validates the detector with the changes applied; the baselines are reset
+// if the metric or the method parameters are changed.
+func (service *AnomalyDetection) UpdateDetector(detectorUpdate *dtos.AnomalyDetectorUpdate) (bool, error) {
+	detector, err := service.repository.FindDetectorById(detectorUpdate.Id)
+	if err != nil {
+		return false, err
+	}
+	reset := detectorUpdate.Apply(detector)
+	if err = dtos.ValidateDetector(detector); err != nil {
+		return false, err
+	}
+	if detectorUpdate.Metric != nil {
+		if err = checkMetric(service.metrics, detector.Kind, detector.Metric); err != nil {
+			return false, err
+		}
+	}
+	return service.repository.UpdateDetector(detector, reset)
+}
+
+func (service *AnomalyDetection) GetDetector(detectorId string) (*dtos.AnomalyDetectorGet, error) {
+	id, err := parseDetectorId(detectorId)
+	if err != nil {
+		return nil, err
+	}
+	detector, err := service.repository.FindDetectorById(id)
+	if err != nil {
+		return nil, err
+	}
+	return dtos.AnomalyDetectorGetFromModel(*detector), nil
+}
+
+func (service *AnomalyDetection) DeleteDetector(detectorId string) (bool, error) {
+	id, err := parseDetectorId(detectorId)
+	if err != nil {
+		return false, err
+	}
+	return service.repository.RemoveDetectorById(id)
+}
+
+// Anomalies returns the page of the anomalies of the existing equipment.
+func (service *AnomalyDetection) Anomalies(equipmentId string, anomalyFilter *dtos.AnomalyFilter) (*dtos.AnomalyList, error) {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return nil, fmt.Errorf(failedToParseUUID, "Anomalies", equipmentId, err)
+	}
+	if _, err = service.equipment.FindById(id); err != nil {
+		return nil, err
+	}
+	total, anomalyGets, err := service.repository.ListAnomalies(id, anomalyFilter)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.AnomalyList {
+		EquipmentId:	id,
+		Total:			total,
+		Page:			anomalyFilter.Page,
+		PerPage:		anomalyFilter.PerPage,
+		Anomalies:		anomalyGets,
+	}, nil
+}
+
+// ReadingsIngested applies the active detectors of the equipment kind to the readings in order of their time.
+func (service *AnomalyDetection) ReadingsIngested(equipmentGet *dtos.EquipmentGet, readings []model.Reading, receivedAt time.Time) error {
+	selected, err := activeRules(service.repository.ListActiveDetectors, equipmentGet.Kind, readings, func(detector *model.AnomalyDetector) string { return detector.Metric })
+	if err != nil || selected == nil {
+		return err
+	}
+	detectorIds := make([]int64, 0, len(selected.rules))
+	for _, detector := range selected.rules {
+		detectorIds = append(detectorIds, detector.Id)
+	}
+	return service.repository.Detect(equipmentGet.Id, detectorIds, func(states []model.DetectorState) ([]*model.DetectorState, []*model.Anomaly) {
+		stateByDetector := make(map[int64]*model.DetectorState, len(selected.rules))
+		for i := range states {
+			stateByDetector[states[i].DetectorId] = &states[i]
+		}
+		var changed []*model.DetectorState
+		var anomalies []*model.Anomaly
+		selected.apply(func(detector *model.AnomalyDetector, reading *model.Reading) {
+			state, ok := stateByDetector[detector.Id]
+			if !ok {
+				state = &model.DetectorState{DetectorId: detector.Id, EquipmentId: equipmentGet.Id}
+				stateByDetector[detector.Id] = state
+			}
+			if anomaly := detector.Detect(state, reading); anomaly != nil {
+				anomalies = append(anomalies, anomaly)
+			}
+			if !slices.Contains(changed, state) {
+				changed = append(changed, state)
+			}
+		})
+		return changed, anomalies
+	})
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+type ConnectivityRepository interface {
+	Seen(id uuid.UUID, at time.Time) (bool, error)
+	Sweep(now time.Time) ([]model.ConnectivityChange, error)
+}
+
+// Connectivity marks the equipment online on heartbeats and readings; the sweeper marks unreachable
+// the equipment that has been silent for twice the heartbeat interval of its kind.
+type Connectivity struct {
+	repository	ConnectivityRepository
+	interval	time.Duration
+}
+
+func NewConnectivity(repository ConnectivityRepository, interval time.Duration) Connectivity {
+	return Connectivity{repository: repository, interval: interval}
+}
+
+// Heartbeat marks the equipment seen now; fails with sql.ErrNoRows for unknown equipment.
+func (service *Connectivity) Heartbeat(equipmentId string) error {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return fmt.Errorf(failedToParseUUID, "Heartbeat", equipmentId, err)
+	}
+	if seen, err := service.repository.Seen(id, time.Now()); err != nil {
+		return err
+	} else if !seen {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ReadingsIngested counts the readings as a heartbeat at the time of receiving them, whatever their timestamps are.
+func (service *Connectivity) ReadingsIngested(equipmentGet *dtos.EquipmentGet, readings []model.Reading, receivedAt time.Time) error {
+	_, err := service.repository.Seen(equipmentGet.Id, receivedAt)
+	return err
+}
+
+// Run sweeps the silent equipment every interval until ctx is done.
+func (service *Connectivity) Run(ctx context.Context) {
+	for {
+		changes, err := service.repository.Sweep(time.Now())
+		if err != nil {
+			log.Printf("Connectivity sweep error: %v", err)
+		}
+		for _, change := range changes {
+			log.Printf("Equipment #%v is %s, last seen at %v", change.EquipmentId, change.State, change.LastSeenAt)
+		}
+		select {
+			case <-ctx.Done():
+				return
+			case <-time.After(service.interval):
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+type PartRepository interface {
+	List(partFilter *dtos.SparePartFilter) ([]*dtos.SparePartGet, error)
+	Create(partCreate *dtos.SparePartCreate) (int64, error)
+	Update(partUpdate *dtos.SparePartUpdate) (bool, error)
+	FindById(id int64) (*dtos.SparePartGet, error)
+	RemoveById(id int64) (bool, error)
+	AdjustStock(partId int64, stockAdjustment *dtos.StockAdjustment) error
+	Reserve(workOrderId int64, reservationCreate *dtos.PartReservationCreate) (int64, error)
+	ListReservations(workOrderId int64) ([]*dtos.PartReservationGet, error)
+	Release(workOrderId, reservationId int64) (bool, error)
+}
+
+// KindChecker fails for the kind missing from the catalog, e.g. Kind.
+type KindChecker interface {
+	Check(kind model.EquipmentKind) error
+}
+
+// WorkOrderFinder returns the work order, e.g. WorkOrders.
+type WorkOrderFinder interface {
+	Get(workOrderId string) (*dtos.WorkOrderGet, error)
+}
+
+// Inventory keeps the catalog of the spare parts and their stock per storeroom. The parts are reserved by the open work orders
+// and consumed when the work order is done or released when it is cancelled by the transaction closing the work order.
+type Inventory struct {
+	repository	PartRepository
+	equipment	EquipmentFinder
+	kinds		KindChecker
+	workOrders	WorkOrderFinder
+}
+
+func NewInventory(repository PartRepository, equipment EquipmentFinder, kinds KindChecker, workOrders WorkOrderFinder) Inventory {
+	return Inventory{repository: repository, equipment: equipment, kinds: kinds, workOrders: workOrders}
+}
+
+func parsePartId(partId string) (int64, error) {
+	id, err := strconv.ParseInt(partId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid spare part id `%s`", partId)
+	}
+	return id, nil
+}
+
+func parseReservationId(reservationId string) (int64, error) {
+	id, err := strconv.ParseInt(reservationId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid reservation id `%s`", reservationId)
+	}
+	return id, nil
+}
+
+func (service *Inventory) Parts(partFilter *dtos.SparePartFilter) ([]*dtos.SparePartGet, error) {
+	return service.repository.List(partFilter)
+}
+
+// LowStock returns the parts whose available quantity does not exceed the reorder level.
+func (service *Inventory) LowStock(partFilter *dtos.SparePartFilter) ([]*dtos.SparePartGet, error) {
+	partFilter.LowStock = true
+	return service.repository.List(partFilter)
+}
+
+// EquipmentParts returns the parts fitting the equipment.
+func (service *Inventory) EquipmentParts(equipmentId string) ([]*dtos.SparePartGet, error) {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return nil, fmt.Errorf(failedToParseUUID, "Parts", equipmentId, err)
+	}
+	equipmentGet, err := service.equipment.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	return service.repository.List(&dtos.SparePartFilter{Fits: equipmentGet})
+}
+
+// CreatePart checks that the listed kinds and equipment exist.
+func (service *Inventory) CreatePart(partCreate *dtos.SparePartCreate) (int64, error) {
+	if err := service.checkKinds(partCreate.Kinds); err != nil {
+		return 0, err
+	}
+	if err := service.checkEquipment(partCreate.EquipmentIds); err != nil {
+		return 0, err
+	}
+	return service.repository.Create(partCreate)
+}
+
+func (service *Inventory) UpdatePart(partUpdate *dtos.SparePartUpdate) (bool, error) {
+	if partUpdate.Kinds != nil {
+		if err := service.checkKinds(*partUpdate.Kinds); err != nil {
+			return false, err
+		}
+	}
+	if partUpdate.EquipmentIds != nil {
+		if err := service.checkEquipment(*partUpdate.EquipmentIds); err != nil {
+			return false, err
+		}
+	}
+	return service.repository.Update(partUpdate)
+}
+
+func (service *Inventory) checkKinds(kinds []model.EquipmentKind) error {
+	for _, kind := range kinds {
+		if err := service.kinds.Check(kind); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (service *Inventory) checkEquipment(equipmentIds []uuid.UUID) error {
+	for _, equipmentId := range equipmentIds {
+		if _, err := service.equipment.FindById(equipmentId); err != nil {
+			return fmt.Errorf("Unable to find equipment #%v: %w", equipmentId, err)
+		}
+	}
+	return nil
+}
+
+func (service *Inventory) GetPart(partId string) (*dtos.SparePartGet, error) {
+	id, err := parsePartId(partId)
+	if err != nil {
+		return nil, err
+	}
+	return service.repository.FindById(id)
+}
+
+func (service *Inventory) DeletePart(partId string) (bool, error) {
+	id, err := parsePartId(partId)
+	if err != nil {
+		return false, err
+	}
+	return service.repository.RemoveById(id)
+}
+
+func (service *Inventory) AdjustStock(partId string, stockAdjustment *dtos.StockAdjustment) error {
+	id, err := parsePartId(partId)
+	if err != nil {
+		return err
+	}
+	return service.repository.AdjustStock(id, stockAdjustment)
+}
+
+func (service *Inventory) Reserve(workOrderId string, reservationCreate *dtos.PartReservationCreate) (int64, error) {
+	id, err := parseWorkOrderId(workOrderId)
+	if err != nil {
+		return 0, err
+	}
+	return service.repository.Reserve(id, reservationCreate)
+}
+
+func (service *Inventory) Reservations(workOrderId string) ([]*dtos.PartReservationGet, error) {
+	workOrderGet, err := service.workOrders.Get(workOrderId)
+	if err != nil {
+		return nil, err
+	}
+	return service.repository.ListReservations(workOrderGet.Id)
+}
+
+// Release returns the reserved parts of the open work order to the stock; reports false unless the reservation is still reserved
+// and the work order is open.
+func (service *Inventory) Release(workOrderId, reservationId string) (bool, error) {
+	id, err := parseWorkOrderId(workOrderId)
+	if err != nil {
+		return false, err
+	}
+	rid, err := parseReservationId(reservationId)
+	if err != nil {
+		return false, err
+	}
+	return service.repository.Release(id, rid)
+}
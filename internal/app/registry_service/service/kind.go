@@ -0,0 +1,184 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/paramschema"
+)
+
+const (
+	unknownKind string = "Unknown equipment kind: %v"
+	kindNamesRefresh time.Duration = 5 * time.Second	// Limits the reloads of the names missed, e.g. added by another instance
+)
+
+type KindRepository interface {
+	List() ([]*dtos.KindGet, error)
+	Create(kindCreate *dtos.KindCreate) (model.EquipmentKind, error)
+	Update(kindUpdate *dtos.KindUpdate) (bool, error)
+	FindById(id model.EquipmentKind) (*dtos.KindGet, error)
+	FindByName(name string) (*dtos.KindGet, error)
+	RemoveById(id model.EquipmentKind) (bool, error)
+}
+
+// kindNames caches the names of the catalog; it is reloaded whenever the catalog is changed by the service.
+type kindNames struct {
+	mutex		sync.RWMutex
+	names		map[model.EquipmentKind]string
+	ids			map[string]model.EquipmentKind	// By the lowercase names
+	loadedAt	time.Time
+}
+
+// Kind manages the equipment kind catalog and serves as the source of parameter schemas for Equipment
+// and of the kind names for model.KindCatalog.
+type Kind struct {
+	repository	KindRepository
+	schemas		*paramschema.Registry
+	names		*kindNames
+}
+
+func NewKind(repository KindRepository) Kind {
+	return Kind{repository: repository, schemas: paramschema.NewRegistry(), names: &kindNames{}}
+}
+
+func (service *Kind) reloadNames() error {
+	kindGets, err := service.repository.List()
+	if err != nil {
+		return err
+	}
+	names := make(map[model.EquipmentKind]string, len(kindGets))
+	ids := make(map[string]model.EquipmentKind, len(kindGets))
+	for _, kindGet := range kindGets {
+		names[kindGet.Id] = kindGet.Name
+		ids[strings.ToLower(kindGet.Name)] = kindGet.Id
+	}
+	service.names.mutex.Lock()
+	defer service.names.mutex.Unlock()
+	service.names.names, service.names.ids, service.names.loadedAt = names, ids, time.Now()
+	return nil
+}
+
+// lookupName looks the cache up, reloading it once if lookup misses and it has not been reloaded lately.
+func (service *Kind) lookupName(lookup func(names *kindNames) bool) bool {
+	service.names.mutex.RLock()
+	found, stale := lookup(service.names), time.Since(service.names.loadedAt) > kindNamesRefresh
+	service.names.mutex.RUnlock()
+	if found || !stale {
+		return found
+	}
+	if err := service.reloadNames(); err != nil {
+		log.Printf("Failed to load equipment kind names: %v", err)
+		return false
+	}
+	service.names.mutex.RLock()
+	defer service.names.mutex.RUnlock()
+	return lookup(service.names)
+}
+
+func (service *Kind) KindName(kind model.EquipmentKind) (name string, found bool) {
+	found = service.lookupName(func(names *kindNames) bool {
+		name, found = names.names[kind]
+		return found
+	})
+	return
+}
+
+func (service *Kind) KindByName(name string) (kind model.EquipmentKind, found bool) {
+	found = service.lookupName(func(names *kindNames) bool {
+		kind, found = names.ids[strings.ToLower(name)]
+		return found
+	})
+	return
+}
+
+// changed reloads the names once the catalog is changed.
+func (service *Kind) changed(changed bool, err error) (bool, error) {
+	if changed && err == nil {
+		if err := service.reloadNames(); err != nil {
+			log.Printf("Failed to load equipment kind names: %v", err)
+		}
+	}
+	return changed, err
+}
+
+func (service *Kind) List() ([]*dtos.KindGet, error) {
+	return service.repository.List()
+}
+
+func (service *Kind) Create(kindCreate *dtos.KindCreate) (model.EquipmentKind, error) {
+	if err := paramschema.Check(kindCreate.ParameterSchema); err != nil {
+		return 0, err
+	}
+	id, err := service.repository.Create(kindCreate)
+	service.changed(err == nil, err)
+	return id, err
+}
+
+func (service *Kind) Update(kindUpdate *dtos.KindUpdate) (bool, error) {
+	if kindUpdate.ParameterSchema != nil {
+		if err := paramschema.Check(*kindUpdate.ParameterSchema); err != nil {
+			return false, err
+		}
+	}
+	return service.changed(service.repository.Update(kindUpdate))
+}
+
+// Get finds the kind either by its numeric identifier or by its case-insensitive name.
+func (service *Kind) Get(kindIdOrName string) (*dtos.KindGet, error) {
+	if id, err := strconv.ParseInt(kindIdOrName, 10, 16); err == nil {
+		return service.repository.FindById(model.EquipmentKind(id))
+	}
+	return service.repository.FindByName(kindIdOrName)
+}
+
+func (service *Kind) Delete(kindIdOrName string) (bool, error) {
+	kindGet, err := service.Get(kindIdOrName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return service.changed(service.repository.RemoveById(kindGet.Id))
+}
+
+// Validate checks parameters against the schema currently stored in the catalog for the kind;
+// compiled schemas are cached until the stored ones change.
+func (service *Kind) Validate(kind model.EquipmentKind, parameters map[string]interface{}) error {
+	kindGet, err := service.repository.FindById(kind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf(unknownKind, kind)
+	} else if err != nil {
+		return err
+	}
+	if err = service.schemas.Register(kind, kindGet.ParameterSchema); err != nil {
+		return err
+	}
+	return service.schemas.Validate(kind, parameters)
+}
+
+// Check fails unless the kind is in the catalog.
+func (service *Kind) Check(kind model.EquipmentKind) error {
+	_, err := service.repository.FindById(kind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf(unknownKind, kind)
+	}
+	return err
+}
+
+// Metrics returns the telemetry metrics declared for the kind.
+func (service *Kind) Metrics(kind model.EquipmentKind) ([]model.Metric, error) {
+	kindGet, err := service.repository.FindById(kind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf(unknownKind, kind)
+	} else if err != nil {
+		return nil, err
+	}
+	return kindGet.Metrics, nil
+}
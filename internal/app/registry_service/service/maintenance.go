@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+type MaintenanceRepository interface {
+	ListPlans() ([]*dtos.MaintenancePlanGet, error)
+	ListActivePlans() ([]model.MaintenancePlan, error)
+	CreatePlan(plan *model.MaintenancePlan) (int64, error)
+	UpdatePlan(plan *model.MaintenancePlan) (bool, error)
+	FindPlanById(id int64) (*model.MaintenancePlan, error)
+	RemovePlanById(id int64) (bool, error)
+	StoreSchedule(planId int64, dues []*model.MaintenanceDue) error
+	ListDue(now, until time.Time) ([]*dtos.MaintenanceDueGet, error)
+}
+
+type StatusHistory interface {
+	TransitionHistory
+	ListLastMaintained(ids []uuid.UUID) (map[uuid.UUID]time.Time, error)
+}
+
+// Maintenance manages the preventive maintenance plans; the scheduler recomputes the next due dates of the active plans
+// for their equipment (except decommissioned one) every interval.
+type Maintenance struct {
+	repository	MaintenanceRepository
+	equipment	EquipmentLister
+	history		StatusHistory
+	counters	CounterTotals
+	metrics		KindMetrics
+	interval	time.Duration
+}
+
+func NewMaintenance(
+	repository MaintenanceRepository,
+	equipment EquipmentLister,
+	history StatusHistory,
+	counters CounterTotals,
+	metrics KindMetrics,
+	interval time.Duration,
+) Maintenance {
+	return Maintenance {
+		repository:	repository,
+		equipment:	equipment,
+		history:	history,
+		counters:	counters,
+		metrics:	metrics,
+		interval:	interval,
+	}
+}
+
+func parsePlanId(planId string) (int64, error) {
+	id, err := strconv.ParseInt(planId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid maintenance plan id `%s`", planId)
+	}
+	return id, nil
+}
+
+// checkPlan checks that the equipment of the plan exists and the counter metric is declared for the kind.
+func (service *Maintenance) checkPlan(plan *model.MaintenancePlan) error {
+	kind := plan.Kind
+	if plan.EquipmentId != nil {
+		equipmentGet, err := service.equipment.FindById(*plan.EquipmentId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("Equipment #%v does not exist", *plan.EquipmentId)
+		} else if err != nil {
+			return err
+		}
+		kind = &equipmentGet.Kind
+	}
+	if plan.Trigger == model.CounterTrigger {
+		return checkMetric(service.metrics, *kind, plan.Metric)
+	}
+	return nil
+}
+
+func (service *Maintenance) Plans() ([]*dtos.MaintenancePlanGet, error) {
+	return service.repository.ListPlans()
+}
+
+func (service *Maintenance) CreatePlan(planCreate *dtos.MaintenancePlanCreate) (int64, error) {
+	plan := planCreate.Plan()
+	if err := service.checkPlan(plan); err != nil {
+		return 0, err
+	}
+	id, err := service.repository.CreatePlan(plan)
+	if err != nil {
+		return 0, err
+	}
+	plan.Id = id
+	service.reschedule(plan)
+	return id, nil
+}
+
+func (service *Maintenance) UpdatePlan(planUpdate *dtos.MaintenancePlanUpdate) (bool, error) {
+	plan, err := service.repository.FindPlanById(planUpdate.Id)
+	if err != nil {
+		return false, err
+	}
+	planUpdate.Apply(plan)
+	if err = dtos.ValidatePlan(plan); err != nil {
+		return false, err
+	}
+	if err = service.checkPlan(plan); err != nil {
+		return false, err
+	}
+	updated, err := service.repository.UpdatePlan(plan)
+	if updated {
+		service.reschedule(plan)
+	}
+	return updated, err
+}
+
+func (service *Maintenance) GetPlan(planId string) (*dtos.MaintenancePlanGet, error) {
+	id, err := parsePlanId(planId)
+	if err != nil {
+		return nil, err
+	}
+	planModel, err := service.repository.FindPlanById(id)
+	if err != nil {
+		return nil, err
+	}
+	return dtos.MaintenancePlanGetFromModel(*planModel), nil
+}
+
+func (service *Maintenance) DeletePlan(planId string) (bool, error) {
+	id, err := parsePlanId(planId)
+	if err != nil {
+		return false, err
+	}
+	return service.repository.RemovePlanById(id)
+}
+
+// Due lists the maintenance due within the duration from now as of the last run of the scheduler.
+func (service *Maintenance) Due(dueQuery *dtos.MaintenanceDueQuery) ([]*dtos.MaintenanceDueGet, error) {
+	now := time.Now()
+	return service.repository.ListDue(now, now.Add(dueQuery.Duration))
+}
+
+func (service *Maintenance) reschedule(plan *model.MaintenancePlan) {
+	if err := service.schedule(plan, time.Now()); err != nil {
+		log.Printf("Maintenance plan #%d scheduling error: %v", plan.Id, err)
+	}
+}
+
+// planEquipment returns the equipment (except decommissioned one) the active plan applies to.
+func (service *Maintenance) planEquipment(plan *model.MaintenancePlan) ([]*dtos.EquipmentGet, error) {
+	if !plan.Active {
+		return nil, nil
+	}
+	if plan.Kind != nil {
+		return service.equipment.List(&dtos.EquipmentFilter {
+			Kinds:		[]model.EquipmentKind{*plan.Kind},
+			NoStatuses:	[]model.OperationalStatus{model.Decommissioned},
+		})
+	}
+	equipmentGet, err := service.equipment.FindById(*plan.EquipmentId)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && equipmentGet.Status == model.Decommissioned {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return []*dtos.EquipmentGet{equipmentGet}, nil
+}
+
+// schedule computes the next due dates of the plan for its equipment since the last maintenance (or the registration).
+func (service *Maintenance) schedule(plan *model.MaintenancePlan, now time.Time) error {
+	equipmentGets, err := service.planEquipment(plan)
+	if err != nil {
+		return err
+	}
+	if len(equipmentGets) == 0 {
+		return service.repository.StoreSchedule(plan.Id, nil)
+	}
+	ids := make([]uuid.UUID, 0, len(equipmentGets))
+	for _, equipmentGet := range equipmentGets {
+		ids = append(ids, equipmentGet.Id)
+	}
+	lastMaintained, err := service.history.ListLastMaintained(ids)
+	if err != nil {
+		return err
+	}
+	lastDone := make(map[uuid.UUID]time.Time, len(equipmentGets))
+	earliest := now
+	for _, equipmentGet := range equipmentGets {
+		done, ok := lastMaintained[equipmentGet.Id]
+		if !ok {
+			done = equipmentGet.CreatedAt
+		}
+		lastDone[equipmentGet.Id] = done
+		if done.Before(earliest) {
+			earliest = done
+		}
+	}
+	var transitions map[uuid.UUID][]model.Transition
+	counted := make(map[uuid.UUID]float64, len(equipmentGets))
+	switch plan.Trigger {
+		case model.OperatingHoursTrigger:
+			if transitions, err = service.history.ListTransitionsWithin(ids, earliest, now); err != nil {
+				return err
+			}
+		case model.CounterTrigger:
+			totals, err := service.counters.Totals(lastDone, []string{plan.Metric}, now)
+			if err != nil {
+				return err
+			}
+			for _, total := range totals {
+				counted[total.EquipmentId] += total.Value
+			}
+	}
+	dues := make([]*model.MaintenanceDue, 0, len(equipmentGets))
+	for _, id := range ids {
+		usage := counted[id]
+		if plan.Trigger == model.OperatingHoursTrigger {
+			var reliability model.Reliability
+			reliability.AddStatusHistory(transitions[id], lastDone[id], now)
+			usage = reliability.UpTime.Hours()
+		}
+		dues = append(dues, plan.Due(id, lastDone[id], now, usage))
+	}
+	return service.repository.StoreSchedule(plan.Id, dues)
+}
+
+// Run schedules all the active plans every interval until ctx is done.
+func (service *Maintenance) Run(ctx context.Context) {
+	for {
+		plans, err := service.repository.ListActivePlans()
+		if err != nil {
+			log.Printf("Maintenance scheduling error: %v", err)
+		}
+		for i := range plans {
+			if err = service.schedule(&plans[i], time.Now()); err != nil {
+				log.Printf("Maintenance plan #%d scheduling error: %v", plans[i].Id, err)
+			}
+		}
+		select {
+			case <-ctx.Done():
+				return
+			case <-time.After(service.interval):
+		}
+	}
+}
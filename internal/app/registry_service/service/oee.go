@@ -0,0 +1,132 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+type EquipmentLister interface {
+	EquipmentFinder
+	List(equipmentFilter *dtos.EquipmentFilter) ([]*dtos.EquipmentGet, error)
+}
+
+type TransitionHistory interface {
+	ListTransitionsWithin(ids []uuid.UUID, from, to time.Time) (map[uuid.UUID][]model.Transition, error)
+}
+
+type CounterTotals interface {
+	Totals(from map[uuid.UUID]time.Time, metrics []string, to time.Time) ([]model.MetricTotal, error)
+}
+
+// Effectiveness computes OEE: availability from the status transitions, performance from the ideal cycle time parameter
+// and quality from the production counters reported as telemetry.
+type Effectiveness struct {
+	equipment	EquipmentLister
+	transitions	TransitionHistory
+	counters	CounterTotals
+}
+
+func NewEffectiveness(equipment EquipmentLister, transitions TransitionHistory, counters CounterTotals) Effectiveness {
+	return Effectiveness{equipment: equipment, transitions: transitions, counters: counters}
+}
+
+// idealCycleTime returns the positive ideal cycle time parameter of the equipment in seconds or nil.
+func idealCycleTime(equipmentGet *dtos.EquipmentGet) *float64 {
+	if seconds, ok := equipmentGet.Parameters[model.IdealCycleTimeParameter].(float64); ok && seconds > 0 {
+		return &seconds
+	}
+	return nil
+}
+
+func (service *Effectiveness) compute(equipmentGets []*dtos.EquipmentGet, from, to time.Time) ([]*model.Effectiveness, error) {
+	ids := make([]uuid.UUID, 0, len(equipmentGets))
+	starts := make(map[uuid.UUID]time.Time, len(equipmentGets))
+	for _, equipmentGet := range equipmentGets {
+		ids = append(ids, equipmentGet.Id)
+		starts[equipmentGet.Id] = from
+	}
+	transitions, err := service.transitions.ListTransitionsWithin(ids, from, to)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := service.counters.Totals(starts, []string{model.TotalCountMetric, model.GoodCountMetric}, to)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[uuid.UUID]map[string]float64, len(ids))
+	for _, total := range totals {
+		if counts[total.EquipmentId] == nil {
+			counts[total.EquipmentId] = make(map[string]float64, 2)
+		}
+		counts[total.EquipmentId][total.Metric] = total.Value
+	}
+	effectivenesses := make([]*model.Effectiveness, 0, len(equipmentGets))
+	for _, equipmentGet := range equipmentGets {
+		var effectiveness model.Effectiveness
+		cycleTime := idealCycleTime(equipmentGet)
+		effectiveness.AddStatusHistory(transitions[equipmentGet.Id], from, to, cycleTime)
+		effectiveness.AddCounts(counts[equipmentGet.Id][model.TotalCountMetric], counts[equipmentGet.Id][model.GoodCountMetric], cycleTime)
+		effectivenesses = append(effectivenesses, &effectiveness)
+	}
+	return effectivenesses, nil
+}
+
+func equipmentOEE(equipmentGet *dtos.EquipmentGet, from, to time.Time, effectiveness *model.Effectiveness) *dtos.EquipmentOEE {
+	return &dtos.EquipmentOEE {
+		EquipmentId:		equipmentGet.Id,
+		Kind:				equipmentGet.Kind,
+		From:				from,
+		To:					to,
+		IdealCycleTime:		idealCycleTime(equipmentGet),
+		EffectivenessGet:	dtos.EffectivenessGetFromModel(effectiveness),
+	}
+}
+
+// Equipment returns OEE of the existing equipment for the range.
+func (service *Effectiveness) Equipment(equipmentId string, reportRange *dtos.ReportRange) (*dtos.EquipmentOEE, error) {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return nil, fmt.Errorf(failedToParseUUID, "OEE", equipmentId, err)
+	}
+	equipmentGet, err := service.equipment.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	effectivenesses, err := service.compute([]*dtos.EquipmentGet{equipmentGet}, *reportRange.From, *reportRange.To)
+	if err != nil {
+		return nil, err
+	}
+	return equipmentOEE(equipmentGet, *reportRange.From, *reportRange.To, effectivenesses[0]), nil
+}
+
+// Report returns OEE of the existing equipment matching the filter for the range, combined by kinds.
+func (service *Effectiveness) Report(reportQuery *dtos.ReportQuery) (*dtos.OEEReport, error) {
+	from, to := *reportQuery.From, *reportQuery.To
+	oeeReport := dtos.OEEReport{From: from, To: to, Kinds: []*dtos.KindOEE{}}
+	equipmentGets, err := service.equipment.List(&reportQuery.EquipmentFilter)
+	if err != nil || len(equipmentGets) == 0 {
+		return &oeeReport, err
+	}
+	slices.SortStableFunc(equipmentGets, func(a, b *dtos.EquipmentGet) int { return int(a.Kind) - int(b.Kind) })
+	effectivenesses, err := service.compute(equipmentGets, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var kindEffectiveness model.Effectiveness
+	var kindOEE *dtos.KindOEE
+	for i, equipmentGet := range equipmentGets {
+		if kindOEE == nil || kindOEE.Kind != equipmentGet.Kind {
+			kindEffectiveness = model.Effectiveness{}
+			kindOEE = &dtos.KindOEE{Kind: equipmentGet.Kind}
+			oeeReport.Kinds = append(oeeReport.Kinds, kindOEE)
+		}
+		kindEffectiveness.Add(effectivenesses[i])
+		kindOEE.EffectivenessGet = dtos.EffectivenessGetFromModel(&kindEffectiveness)
+		kindOEE.Equipment = append(kindOEE.Equipment, equipmentOEE(equipmentGet, from, to, effectivenesses[i]))
+	}
+	return &oeeReport, nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/publisher"
+)
+
+const (
+	minBackoff time.Duration = time.Second
+	maxBackoff = 5 * time.Minute
+	publishTimeout = 10 * time.Second
+)
+
+type OutboxRepository interface {
+	Relay(limit int, lease time.Duration, publish func(*model.OutboxMessage) error, backoff func(attempts int) time.Duration) (int, error)
+}
+
+// OutboxRelay moves messages from the transactional outbox to the publisher with at-least-once delivery.
+type OutboxRelay struct {
+	repository	OutboxRepository
+	publisher	publisher.Publisher
+	interval	time.Duration
+	batchSize	int
+}
+
+func NewOutboxRelay(repository OutboxRepository, publisher publisher.Publisher, interval time.Duration, batchSize int) OutboxRelay {
+	return OutboxRelay{repository: repository, publisher: publisher, interval: interval, batchSize: batchSize}
+}
+
+// exponentialBackoff doubles the delay with every failed attempt up to maxBackoff adding up to 20% of jitter.
+func exponentialBackoff(attempts int) time.Duration {
+	delay := maxBackoff
+	if attempts < 20 {
+		delay = min(minBackoff << (attempts - 1), maxBackoff)
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay / 5) + 1))
+}
+
+func (relay *OutboxRelay) publish(ctx context.Context) func(*model.OutboxMessage) error {
+	return func(message *model.OutboxMessage) error {
+		publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+		defer cancel()
+		return relay.publisher.Publish(publishCtx, message)
+	}
+}
+
+// Run relays the messages until ctx is done; the full batch is followed by the next one immediately,
+// otherwise the relay waits for the interval.
+func (relay *OutboxRelay) Run(ctx context.Context) {
+	for {
+		// The lease outlasts the publishing of the whole batch
+		lease := time.Duration(relay.batchSize) * publishTimeout
+		count, err := relay.repository.Relay(relay.batchSize, lease, relay.publish(ctx), exponentialBackoff)
+		if err != nil {
+			log.Printf("Outbox relay error: %v", err)
+		}
+		if err != nil || count < relay.batchSize {
+			select {
+				case <-ctx.Done():
+					return
+				case <-time.After(relay.interval):
+			}
+		} else if ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"slices"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+// Reliability computes MTBF and MTTR from the status transitions.
+type Reliability struct {
+	equipment	EquipmentLister
+	transitions	TransitionHistory
+}
+
+func NewReliability(equipment EquipmentLister, transitions TransitionHistory) Reliability {
+	return Reliability{equipment: equipment, transitions: transitions}
+}
+
+// Report returns the reliability of the existing equipment matching the filter for the range, combined by kinds and over the fleet.
+func (service *Reliability) Report(reportQuery *dtos.ReportQuery) (*dtos.ReliabilityReport, error) {
+	from, to := *reportQuery.From, *reportQuery.To
+	reliabilityReport := dtos.ReliabilityReport{From: from, To: to, Kinds: []*dtos.KindReliability{}}
+	equipmentGets, err := service.equipment.List(&reportQuery.EquipmentFilter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, 0, len(equipmentGets))
+	for _, equipmentGet := range equipmentGets {
+		ids = append(ids, equipmentGet.Id)
+	}
+	transitions, err := service.transitions.ListTransitionsWithin(ids, from, to)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(equipmentGets, func(a, b *dtos.EquipmentGet) int { return int(a.Kind) - int(b.Kind) })
+	var fleetReliability, kindReliability model.Reliability
+	var kindGet *dtos.KindReliability
+	for _, equipmentGet := range equipmentGets {
+		if kindGet == nil || kindGet.Kind != equipmentGet.Kind {
+			kindReliability = model.Reliability{}
+			kindGet = &dtos.KindReliability{Kind: equipmentGet.Kind}
+			reliabilityReport.Kinds = append(reliabilityReport.Kinds, kindGet)
+		}
+		var reliability model.Reliability
+		reliability.AddStatusHistory(transitions[equipmentGet.Id], from, to)
+		kindReliability.Add(&reliability)
+		fleetReliability.Add(&reliability)
+		kindGet.ReliabilityGet = dtos.ReliabilityGetFromModel(&kindReliability)
+		kindGet.Equipment = append(kindGet.Equipment, &dtos.EquipmentReliability {
+			EquipmentId:	equipmentGet.Id,
+			Kind:			equipmentGet.Kind,
+			ReliabilityGet:	dtos.ReliabilityGetFromModel(&reliability),
+		})
+	}
+	reliabilityReport.Fleet = dtos.ReliabilityGetFromModel(&fleetReliability)
+	return &reliabilityReport, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// rollupDelay lets the ingestion commit the readings of the bucket before it is rolled up;
+// the buckets of the readings committed later than that are rolled up again.
+const rollupDelay time.Duration = time.Minute
+
+type TelemetryStorage interface {
+	RollUp(until time.Time) error
+	DropPartitions(before time.Time) ([]string, error)
+	DropRollups(minutesBefore, hoursBefore time.Time) (int64, error)
+}
+
+// TelemetryRollup rolls the readings up into the aggregates by minutes and by hours
+// and drops the rolled up partitions of the readings and the aggregates beyond their retentions.
+type TelemetryRollup struct {
+	storage				TelemetryStorage
+	interval			time.Duration
+	retention			time.Duration
+	minutesRetention	time.Duration	// Not shorter than the retention, so the late readings find the minutes of their hour
+	hoursRetention		time.Duration
+}
+
+func NewTelemetryRollup(storage TelemetryStorage, interval, retention, minutesRetention, hoursRetention time.Duration) TelemetryRollup {
+	return TelemetryRollup {
+		storage:			storage,
+		interval:			interval,
+		retention:			retention,
+		minutesRetention:	minutesRetention,
+		hoursRetention:		hoursRetention,
+	}
+}
+
+// Run rolls up and drops partitions every interval until ctx is done.
+func (rollup *TelemetryRollup) Run(ctx context.Context) {
+	for {
+		now := time.Now()
+		if err := rollup.storage.RollUp(now.Add(-rollupDelay)); err != nil {
+			log.Printf("Telemetry rollup error: %v", err)
+		}
+		if dropped, err := rollup.storage.DropPartitions(now.Add(-rollup.retention)); err != nil {
+			log.Printf("Telemetry retention error: %v", err)
+		} else if len(dropped) > 0 {
+			log.Printf("Telemetry partitions beyond the retention of %v are dropped: %v", rollup.retention, dropped)
+		}
+		// The minutes are kept for the whole hour the hours may be rolled up again from
+		if _, err := rollup.storage.DropRollups(now.Add(-rollup.minutesRetention).Truncate(time.Hour), now.Add(-rollup.hoursRetention)); err != nil {
+			log.Printf("Telemetry rollup retention error: %v", err)
+		}
+		select {
+			case <-ctx.Done():
+				return
+			case <-time.After(rollup.interval):
+		}
+	}
+}
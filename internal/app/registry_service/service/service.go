@@ -1,39 +1,131 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 	"github.com/gofrs/uuid"
 	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
 )
 
 const failedToParseUUID string = "%s: failed to parse `%s` as UUID: %v"
 
 type EquipmentRepository interface {
 	List(equipmentFilter *dtos.EquipmentFilter) ([]*dtos.EquipmentGet, error)
+	ListPage(equipmentQuery *dtos.EquipmentQuery) (int, []*dtos.EquipmentGet, error)
 	Create(equipmentCreate *dtos.EquipmentCreate) (uuid.UUID, error)
-	Update(equipmentUpdate *dtos.EquipmentUpdate) (bool, error)
+	Update(equipmentUpdate *dtos.EquipmentUpdate, fromStatus model.OperationalStatus) (bool, error)
 	FindById(id uuid.UUID) (*dtos.EquipmentGet, error)
+	FindByIdAsOf(id uuid.UUID, asOf time.Time) (*dtos.EquipmentGet, error)
 	RemoveById(id uuid.UUID) (bool, error)
+	ListTransitions(id uuid.UUID) ([]*dtos.TransitionGet, error)
+	ListRevisions(id uuid.UUID, historyPage *dtos.HistoryPage) (int, []model.Revision, error)
+}
+
+type ParameterSchemas interface {
+	Validate(kind model.EquipmentKind, parameters map[string]interface{}) error
 }
 
 type Equipment struct {
-	repository EquipmentRepository
+	repository	EquipmentRepository
+	schemas		ParameterSchemas
+	transitions	model.TransitionGraph
 }
 
-func NewEquipment(repository EquipmentRepository) Equipment {
-	return Equipment{repository: repository}
+func NewEquipment(repository EquipmentRepository, schemas ParameterSchemas, transitions model.TransitionGraph) Equipment {
+	return Equipment{repository: repository, schemas: schemas, transitions: transitions}
 }
 
 func (service *Equipment) List(equipmentFilter *dtos.EquipmentFilter) ([]*dtos.EquipmentGet, error) {
 	return service.repository.List(equipmentFilter)
 }
 
+// ListPage returns the page of the equipment with the cursor of the next page unless the page is the last one.
+func (service *Equipment) ListPage(equipmentQuery *dtos.EquipmentQuery) (*dtos.EquipmentList, error) {
+	total, equipmentGets, err := service.repository.ListPage(equipmentQuery)
+	if err != nil {
+		return nil, err
+	}
+	equipmentList := dtos.EquipmentList{Items: equipmentGets}
+	if len(equipmentGets) > equipmentQuery.Limit {
+		equipmentList.Items = equipmentGets[:equipmentQuery.Limit]
+		nextCursor := equipmentQuery.CursorAfter(equipmentList.Items[equipmentQuery.Limit - 1]).Encode()
+		equipmentList.NextCursor = &nextCursor
+	}
+	if equipmentQuery.Total {
+		equipmentList.Total = &total
+	}
+	return &equipmentList, nil
+}
+
 func (service *Equipment) Create(equipmentCreate *dtos.EquipmentCreate) (uuid.UUID, error) {
+	if err := service.schemas.Validate(equipmentCreate.Kind, equipmentCreate.Parameters); err != nil {
+		return uuid.UUID{}, err
+	}
 	return service.repository.Create(equipmentCreate)
 }
 
+// Update checks the change of the status against the transition graph
+// and new parameters against the schema of the equipment kind.
 func (service *Equipment) Update(equipmentUpdate *dtos.EquipmentUpdate) (bool, error) {
-	return service.repository.Update(equipmentUpdate)
+	if equipmentUpdate.Status == nil && equipmentUpdate.Parameters == nil {
+		return false, nil // Nothing to update
+	}
+	equipmentGet, err := service.repository.FindById(equipmentUpdate.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if equipmentUpdate.Status != nil && *equipmentUpdate.Status != equipmentGet.Status {
+		if err = service.transitions.Check(equipmentGet.Status, *equipmentUpdate.Status, equipmentUpdate.Reason); err != nil {
+			return false, err
+		}
+	}
+	if equipmentUpdate.Parameters != nil {
+		// Parameters are replaced as a whole, so the new set must satisfy the schema of the current kind
+		if err = service.schemas.Validate(equipmentGet.Kind, *equipmentUpdate.Parameters); err != nil {
+			return false, err
+		}
+	}
+	return service.repository.Update(equipmentUpdate, equipmentGet.Status)
+}
+
+// Transition changes the status of the equipment recording the reason and the actor;
+// transitions not allowed by the graph fail with model.ErrConflict.
+func (service *Equipment) Transition(equipmentId string, equipmentTransition *dtos.EquipmentTransition) (bool, error) {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return false, fmt.Errorf(failedToParseUUID, "Transition", equipmentId, err)
+	}
+	equipmentGet, err := service.repository.FindById(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if err = service.transitions.Check(equipmentGet.Status, *equipmentTransition.Status, equipmentTransition.Reason); err != nil {
+		return false, err
+	}
+	return service.repository.Update(
+		&dtos.EquipmentUpdate {
+			Id:		id,
+			Status:	equipmentTransition.Status,
+			Reason:	equipmentTransition.Reason,
+			Actor:	equipmentTransition.Actor,
+		},
+		equipmentGet.Status,
+	)
+}
+
+func (service *Equipment) Transitions(equipmentId string) ([]*dtos.TransitionGet, error) {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return nil, fmt.Errorf(failedToParseUUID, "Transitions", equipmentId, err)
+	}
+	return service.repository.ListTransitions(id)
 }
 
 func (service *Equipment) Get(equipmentId string) (*dtos.EquipmentGet, error) {
@@ -44,6 +136,15 @@ func (service *Equipment) Get(equipmentId string) (*dtos.EquipmentGet, error) {
 	return service.repository.FindById(id)
 }
 
+// GetAsOf returns the state of the equipment at given instant.
+func (service *Equipment) GetAsOf(equipmentId string, asOf time.Time) (*dtos.EquipmentGet, error) {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return nil, fmt.Errorf(failedToParseUUID, "Get", equipmentId, err)
+	}
+	return service.repository.FindByIdAsOf(id, asOf)
+}
+
 func (service *Equipment) Delete(equipmentId string) (bool, error) {
 	id, err := uuid.FromString(equipmentId)
 	if err != nil {
@@ -51,3 +152,18 @@ func (service *Equipment) Delete(equipmentId string) (bool, error) {
 	}
 	return service.repository.RemoveById(id)
 }
+
+// History returns the page of revisions of the equipment (including deleted one) with diffs of parameters.
+func (service *Equipment) History(equipmentId string, historyPage *dtos.HistoryPage) (*dtos.EquipmentHistory, error) {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return nil, fmt.Errorf(failedToParseUUID, "History", equipmentId, err)
+	}
+	total, revisionModels, err := service.repository.ListRevisions(id, historyPage)
+	if err != nil {
+		return nil, err
+	} else if total == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return dtos.EquipmentHistoryFromModels(id, total, historyPage, revisionModels), nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	subscriptionBuffer int = 64
+	maxBacklog = 1000
+	catchUpBatch = 100
+	pollInterval = time.Second	// Catches up with the messages held back by the transactions which have finished without notifications
+)
+
+const (
+	StreamCreated string = "created"
+	StreamUpdated = "updated"
+	StreamDeleted = "deleted"
+	StreamLeft = "left"	// The equipment does not match the filter any more
+	StreamReset = "reset"	// The changes missed exceed maxBacklog, so the subscriber should reload the equipment
+)
+
+type StreamRepository interface {
+	ListAfter(position model.OutboxPosition, limit int) ([]model.OutboxMessage, error)
+	LastPosition() (model.OutboxPosition, error)
+}
+
+// StreamChange is the event delivered to a subscriber; Position is the one of the outbox message
+// which the subscriber may resume after. The reset event has no change.
+type StreamChange struct {
+	Position	model.OutboxPosition
+	Event		string
+	Change		*dtos.EquipmentChange
+}
+
+type StreamSubscription struct {
+	filter	*dtos.EquipmentFilter
+	changes	chan StreamChange
+}
+
+// Changes is closed when the subscriber falls behind or the stream stops.
+func (subscription *StreamSubscription) Changes() <-chan StreamChange {
+	return subscription.changes
+}
+
+// event returns the name of the event the change makes for the subscriber: the change of the equipment matching the filter
+// after the change (before the deletion) is sent as is, the one of the equipment matching the filter only before the change
+// is sent as left.
+func (subscription *StreamSubscription) event(equipmentChange *dtos.EquipmentChange) (string, bool) {
+	switch {
+		case subscription.filter.Matches(equipmentChange.Equipment):
+			switch equipmentChange.Type {
+				case model.EquipmentRegistered:
+					return StreamCreated, true
+				case model.EquipmentRemoved:
+					return StreamDeleted, true
+			}
+			return StreamUpdated, true
+		case equipmentChange.Previous != nil && subscription.filter.Matches(equipmentChange.Previous):
+			return StreamLeft, true
+	}
+	return "", false
+}
+
+// EquipmentStream fans out committed changes of equipment to the subscribers in order of the commits;
+// the changes are read from the outbox when LISTEN/NOTIFY reports new ones or every pollInterval,
+// so all replicas see all the changes.
+type EquipmentStream struct {
+	repository	StreamRepository
+	mutex		sync.Mutex
+	subscribers	map[*StreamSubscription]struct{}
+	position	model.OutboxPosition
+	stopped		bool
+}
+
+func NewEquipmentStream(repository StreamRepository) EquipmentStream {
+	return EquipmentStream{repository: repository, subscribers: make(map[*StreamSubscription]struct{})}
+}
+
+func equipmentChangeFromMessage(message *model.OutboxMessage) (*dtos.EquipmentChange, error) {
+	var equipmentChange dtos.EquipmentChange
+	err := json.Unmarshal(message.Payload, &equipmentChange)
+	return &equipmentChange, err
+}
+
+// Run broadcasts the messages until ctx is done or notifications is closed; the ids notified only trigger the reading.
+func (stream *EquipmentStream) Run(ctx context.Context, notifications <-chan int64) {
+	defer stream.stop()
+	var err error
+	if stream.position, err = stream.repository.LastPosition(); err != nil {
+		log.Printf("Equipment stream error: %v", err)
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-notifications:
+				if !ok {
+					return
+				}
+				stream.catchUp()
+			case <-ticker.C:
+				stream.catchUp()
+		}
+	}
+}
+
+func (stream *EquipmentStream) catchUp() {
+	for {
+		messages, err := stream.repository.ListAfter(stream.position, catchUpBatch)
+		if err != nil {
+			log.Printf("Equipment stream error: %v", err)
+			return
+		}
+		for i := range messages {
+			stream.broadcast(&messages[i])
+		}
+		if len(messages) < catchUpBatch {
+			return
+		}
+	}
+}
+
+func (stream *EquipmentStream) broadcast(message *model.OutboxMessage) {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	stream.position = message.Position()
+	equipmentChange, err := equipmentChangeFromMessage(message)
+	if err != nil {
+		log.Printf("Equipment stream error: %v", err)
+		return
+	}
+	for subscription := range stream.subscribers {
+		event, ok := subscription.event(equipmentChange)
+		if !ok {
+			continue
+		}
+		select {
+			case subscription.changes <- StreamChange{Position: stream.position, Event: event, Change: equipmentChange}:
+			default:
+				// The subscriber falls behind: it is disconnected and may resume by the last position received
+				delete(stream.subscribers, subscription)
+				close(subscription.changes)
+		}
+	}
+}
+
+func (stream *EquipmentStream) stop() {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	stream.stopped = true
+	for subscription := range stream.subscribers {
+		close(subscription.changes)
+	}
+	clear(stream.subscribers)
+}
+
+// Subscribe registers the subscriber of the changes matching the filter; if after is given,
+// the changes stored after it are returned to be sent before the ones from the subscription,
+// which may repeat some of them, so the subscriber should skip changes with positions not greater than the last sent.
+// If more than maxBacklog changes have been stored since, the reset event is returned instead of them.
+func (stream *EquipmentStream) Subscribe(filter *dtos.EquipmentFilter, after *model.OutboxPosition) (*StreamSubscription, []StreamChange, error) {
+	subscription := StreamSubscription{filter: filter, changes: make(chan StreamChange, subscriptionBuffer)}
+	stream.mutex.Lock()
+	if stream.stopped {
+		close(subscription.changes)
+	} else {
+		stream.subscribers[&subscription] = struct{}{}
+	}
+	// The subscription receives the changes after the position, so the client reloading the equipment on reset misses nothing
+	position := stream.position
+	stream.mutex.Unlock()
+	if after == nil {
+		return &subscription, nil, nil
+	}
+	messages, err := stream.repository.ListAfter(*after, maxBacklog + 1)
+	if err != nil {
+		stream.Unsubscribe(&subscription)
+		return nil, nil, err
+	}
+	if len(messages) > maxBacklog {
+		return &subscription, []StreamChange{{Position: position, Event: StreamReset}}, nil
+	}
+	backlog := make([]StreamChange, 0, len(messages))
+	for i := range messages {
+		equipmentChange, err := equipmentChangeFromMessage(&messages[i])
+		if err != nil {
+			continue
+		}
+		if event, ok := subscription.event(equipmentChange); ok {
+			backlog = append(backlog, StreamChange{Position: messages[i].Position(), Event: event, Change: equipmentChange})
+		}
+	}
+	return &subscription, backlog, nil
+}
+
+func (stream *EquipmentStream) Unsubscribe(subscription *StreamSubscription) {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+	if _, ok := stream.subscribers[subscription]; ok {
+		delete(stream.subscribers, subscription)
+		close(subscription.changes)
+	}
+}
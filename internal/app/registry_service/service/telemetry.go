@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+	"github.com/gofrs/uuid"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/paramschema"
+)
+
+const (
+	maxClockSkew time.Duration = 5 * time.Minute
+	batchLease = 5 * time.Minute	// Outlasts the delivery of the leased batches to the listeners
+)
+
+// ErrBeyondRetention is wrapped by the errors of the p95 series starting before the retention of the readings,
+// as the rollups keep no percentiles.
+var ErrBeyondRetention = errors.New("Beyond the retention of the readings")
+
+type TelemetryRepository interface {
+	Insert(readings []model.Reading, listeners []string) error
+	DeliverBatches(
+		limit int, lease time.Duration,
+		deliver func(*model.ReadingBatch) error,
+		backoff func(attempts int) time.Duration,
+	) (int, error)
+	Series(id uuid.UUID, telemetryQuery *dtos.TelemetryQuery) ([]dtos.TelemetryPoint, error)
+	FleetSeries(fleetTelemetryQuery *dtos.FleetTelemetryQuery) ([]dtos.TelemetryPoint, error)
+}
+
+type EquipmentFinder interface {
+	FindById(id uuid.UUID) (*dtos.EquipmentGet, error)
+}
+
+type KindMetrics interface {
+	Metrics(kind model.EquipmentKind) ([]model.Metric, error)
+}
+
+// ReadingsError lists every reading of the batch not matching the metrics of the equipment kind.
+type ReadingsError struct {
+	Kind	model.EquipmentKind
+	Fields	[]paramschema.FieldError
+}
+
+func (readingsError *ReadingsError) Error() string {
+	messages := make([]string, 0, len(readingsError.Fields))
+	for _, field := range readingsError.Fields {
+		messages = append(messages, field.Field + ": " + field.Message)
+	}
+	return fmt.Sprintf("Readings do not match the metrics of equipment kind %s: %s", readingsError.Kind, strings.Join(messages, "; "))
+}
+
+// ReadingListener is passed each batch of readings of the equipment received at receivedAt after it is stored,
+// in order of the batches of the equipment; the failed batch is passed again until the listener succeeds.
+type ReadingListener interface {
+	ReadingsIngested(equipmentGet *dtos.EquipmentGet, readings []model.Reading, receivedAt time.Time) error
+}
+
+// ruleReadings are the active rules (alert rules, anomaly detectors) of the equipment kind
+// of the metrics of the batch along with the readings of the batch in order of their time.
+type ruleReadings[Rule any] struct {
+	rules		[]Rule
+	readings	[]model.Reading
+	metric		func(rule *Rule) string
+}
+
+// activeRules returns the active rules of the kind listed by list which are of the metrics of the readings, nil if there are none.
+func activeRules[Rule any](
+	list func(kind model.EquipmentKind) ([]Rule, error),
+	kind model.EquipmentKind,
+	readings []model.Reading,
+	metric func(rule *Rule) string,
+) (*ruleReadings[Rule], error) {
+	rules, err := list(kind)
+	if err != nil {
+		return nil, err
+	}
+	rules = slices.DeleteFunc(rules, func(rule Rule) bool {
+		return !slices.ContainsFunc(readings, func(reading model.Reading) bool { return reading.Metric == metric(&rule) })
+	})
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	ordered := slices.Clone(readings)
+	slices.SortStableFunc(ordered, func(a, b model.Reading) int { return a.RecordedAt.Compare(b.RecordedAt) })
+	return &ruleReadings[Rule]{rules: rules, readings: ordered, metric: metric}, nil
+}
+
+// apply calls apply for each reading in order of time and each rule of its metric.
+func (selected *ruleReadings[Rule]) apply(apply func(rule *Rule, reading *model.Reading)) {
+	for i := range selected.readings {
+		for j := range selected.rules {
+			if selected.metric(&selected.rules[j]) == selected.readings[i].Metric {
+				apply(&selected.rules[j], &selected.readings[i])
+			}
+		}
+	}
+}
+
+type Telemetry struct {
+	repository	TelemetryRepository
+	equipment	EquipmentFinder
+	metrics		KindMetrics
+	retention	time.Duration	// Readings older than that are rejected as they would be dropped
+	listeners	map[string]ReadingListener
+	interval	time.Duration
+	batchSize	int
+}
+
+func NewTelemetry(
+	repository TelemetryRepository, equipment EquipmentFinder, metrics KindMetrics, retention time.Duration,
+	interval time.Duration, batchSize int,
+) Telemetry {
+	return Telemetry {
+		repository:	repository,
+		equipment:	equipment,
+		metrics:	metrics,
+		retention:	retention,
+		listeners:	make(map[string]ReadingListener),
+		interval:	interval,
+		batchSize:	batchSize,
+	}
+}
+
+// AddListener registers the listener under the name the batches awaiting it are stored with.
+func (service *Telemetry) AddListener(name string, listener ReadingListener) {
+	service.listeners[name] = listener
+}
+
+// Ingest stores the batch of readings of the existing equipment; the whole batch is rejected with *ReadingsError
+// if any reading has a metric not declared for the kind, another unit, a value out of bounds or a timestamp
+// in the future or beyond the retention.
+func (service *Telemetry) Ingest(equipmentId string, telemetryBatch dtos.TelemetryBatch) (int, error) {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return 0, fmt.Errorf(failedToParseUUID, "Ingest", equipmentId, err)
+	}
+	equipmentGet, err := service.equipment.FindById(id)
+	if err != nil {
+		return 0, err
+	}
+	metricList, err := service.metrics.Metrics(equipmentGet.Kind)
+	if err != nil {
+		return 0, err
+	}
+	metrics := make(map[string]model.Metric, len(metricList))
+	for _, metric := range metricList {
+		metrics[metric.Name] = metric
+	}
+	now := time.Now().UTC()
+	readings := make([]model.Reading, 0, len(telemetryBatch))
+	readingsError := ReadingsError{Kind: equipmentGet.Kind}
+	fail := func(i int, field, message string, parameters ...interface{}) {
+		readingsError.Fields = append(readingsError.Fields, paramschema.FieldError {
+			Field:		fmt.Sprintf("readings.%d.%s", i, field),
+			Message:	fmt.Sprintf(message, parameters...),
+		})
+	}
+	for i, readingCreate := range telemetryBatch {
+		metric, ok := metrics[readingCreate.Metric]
+		if !ok {
+			fail(i, "metric", "unknown metric `%s`", readingCreate.Metric)
+			continue
+		}
+		reading := model.Reading {
+			EquipmentId:	id,
+			Metric:			metric.Name,
+			Value:			*readingCreate.Value,
+			Unit:			metric.Unit,
+			RecordedAt:		now,
+		}
+		if readingCreate.Unit != "" && readingCreate.Unit != metric.Unit {
+			fail(i, "unit", "expected `%s`, got `%s`", metric.Unit, readingCreate.Unit)
+		}
+		if metric.Min != nil && reading.Value < *metric.Min || metric.Max != nil && reading.Value > *metric.Max {
+			fail(i, "value", "%g is out of bounds of `%s`", reading.Value, metric.Name)
+		}
+		if readingCreate.Timestamp != nil {
+			if readingCreate.Timestamp.After(now.Add(maxClockSkew)) {
+				fail(i, "timestamp", "%s is in the future", readingCreate.Timestamp.Format(time.RFC3339))
+			} else if readingCreate.Timestamp.Before(now.Add(-service.retention)) {
+				fail(i, "timestamp", "%s is beyond the retention of %v", readingCreate.Timestamp.Format(time.RFC3339), service.retention)
+			}
+			reading.RecordedAt = readingCreate.Timestamp.UTC()
+		}
+		readings = append(readings, reading)
+	}
+	if len(readingsError.Fields) > 0 {
+		return 0, &readingsError
+	}
+	listeners := make([]string, 0, len(service.listeners))
+	for name := range service.listeners {
+		listeners = append(listeners, name)
+	}
+	if err = service.repository.Insert(readings, listeners); err != nil {
+		return 0, err
+	}
+	return len(readings), nil
+}
+
+func (service *Telemetry) deliver(batch *model.ReadingBatch) error {
+	listener, ok := service.listeners[batch.Listener]
+	if !ok {
+		return fmt.Errorf("Unknown reading listener `%s`", batch.Listener)
+	}
+	equipmentGet, err := service.equipment.FindById(batch.EquipmentId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil // The equipment has been removed since
+	} else if err != nil {
+		return err
+	}
+	return listener.ReadingsIngested(equipmentGet, batch.Readings, batch.ReceivedAt)
+}
+
+// Run passes the stored batches of readings to the listeners until ctx is done, retrying failures with exponential backoff;
+// the batches are delivered as long as there are any, otherwise the delivery waits for the interval.
+func (service *Telemetry) Run(ctx context.Context) {
+	for {
+		count, err := service.repository.DeliverBatches(service.batchSize, batchLease, service.deliver, exponentialBackoff)
+		if err != nil {
+			log.Printf("Reading listener error: %v", err)
+		}
+		if err != nil || count == 0 {
+			select {
+				case <-ctx.Done():
+					return
+				case <-time.After(service.interval):
+			}
+		} else if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func telemetrySeries(telemetryQuery *dtos.TelemetryQuery, points []dtos.TelemetryPoint) *dtos.TelemetrySeries {
+	return &dtos.TelemetrySeries {
+		Metric:	telemetryQuery.Metric,
+		Agg:	telemetryQuery.Agg,
+		Step:	telemetryQuery.Interval.String(),
+		From:	*telemetryQuery.From,
+		To:		*telemetryQuery.To,
+		Points:	points,
+	}
+}
+
+// checkRetention rejects the p95 series reaching the readings already dropped.
+func (service *Telemetry) checkRetention(telemetryQuery *dtos.TelemetryQuery) error {
+	if retainedFrom := time.Now().UTC().Add(-service.retention); telemetryQuery.Agg == dtos.AggP95 && telemetryQuery.From.Before(retainedFrom) {
+		return fmt.Errorf(
+			"%w: p95 is computed from the readings kept for %v, `from` must not precede %s",
+			ErrBeyondRetention, service.retention, retainedFrom.Format(time.RFC3339),
+		)
+	}
+	return nil
+}
+
+// Series returns the readings of the metric of the existing equipment aggregated by steps.
+func (service *Telemetry) Series(equipmentId string, telemetryQuery *dtos.TelemetryQuery) (*dtos.TelemetrySeries, error) {
+	id, err := uuid.FromString(equipmentId)
+	if err != nil {
+		return nil, fmt.Errorf(failedToParseUUID, "Series", equipmentId, err)
+	}
+	if err = service.checkRetention(telemetryQuery); err != nil {
+		return nil, err
+	}
+	if _, err = service.equipment.FindById(id); err != nil {
+		return nil, err
+	}
+	points, err := service.repository.Series(id, telemetryQuery)
+	if err != nil {
+		return nil, err
+	}
+	telemetrySeries := telemetrySeries(telemetryQuery, points)
+	telemetrySeries.EquipmentId = &id
+	return telemetrySeries, nil
+}
+
+// FleetSeries returns the readings of the metric of all the equipment matching the filter aggregated together by steps.
+func (service *Telemetry) FleetSeries(fleetTelemetryQuery *dtos.FleetTelemetryQuery) (*dtos.TelemetrySeries, error) {
+	if err := service.checkRetention(&fleetTelemetryQuery.TelemetryQuery); err != nil {
+		return nil, err
+	}
+	points, err := service.repository.FleetSeries(fleetTelemetryQuery)
+	if err != nil {
+		return nil, err
+	}
+	return telemetrySeries(&fleetTelemetryQuery.TelemetryQuery, points), nil
+}
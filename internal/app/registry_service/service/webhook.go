@@ -0,0 +1,177 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+const (
+	maxDeliveryAttempts int = 12
+	deliveryTimeout = 10 * time.Second
+	signatureHeader string = "X-Webhook-Signature"
+	timestampHeader = "X-Webhook-Timestamp"
+	eventHeader = "X-Webhook-Event"
+	deliveryHeader = "X-Webhook-Delivery"
+)
+
+type WebhookRepository interface {
+	List() ([]*dtos.WebhookGet, error)
+	Create(webhookCreate *dtos.WebhookCreate) (int64, error)
+	Update(webhookUpdate *dtos.WebhookUpdate) (bool, error)
+	FindById(id int64) (*dtos.WebhookGet, error)
+	RemoveById(id int64) (bool, error)
+	ListDeliveries(id int64, page *dtos.HistoryPage) (int, []model.WebhookDelivery, error)
+	Dispatch(
+		limit int, maxAttempts int, lease time.Duration,
+		deliver func(*model.OutgoingDelivery) (*int, error),
+		backoff func(attempts int) time.Duration,
+	) (int, error)
+}
+
+// Webhook manages the subscriptions to the changes of equipment and delivers them:
+// the deliveries are enqueued by the repository storing the changes and posted to the subscribers by Run.
+type Webhook struct {
+	repository	WebhookRepository
+	client		*http.Client
+	interval	time.Duration
+	batchSize	int
+}
+
+func NewWebhook(repository WebhookRepository, interval time.Duration, batchSize int) Webhook {
+	return Webhook {
+		repository:	repository,
+		client:		&http.Client{Timeout: deliveryTimeout},
+		interval:	interval,
+		batchSize:	batchSize,
+	}
+}
+
+func parseWebhookId(webhookId string) (int64, error) {
+	id, err := strconv.ParseInt(webhookId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid webhook id `%s`", webhookId)
+	}
+	return id, nil
+}
+
+func (service *Webhook) List() ([]*dtos.WebhookGet, error) {
+	return service.repository.List()
+}
+
+func (service *Webhook) Create(webhookCreate *dtos.WebhookCreate) (int64, error) {
+	return service.repository.Create(webhookCreate)
+}
+
+func (service *Webhook) Update(webhookUpdate *dtos.WebhookUpdate) (bool, error) {
+	return service.repository.Update(webhookUpdate)
+}
+
+func (service *Webhook) Get(webhookId string) (*dtos.WebhookGet, error) {
+	id, err := parseWebhookId(webhookId)
+	if err != nil {
+		return nil, err
+	}
+	return service.repository.FindById(id)
+}
+
+func (service *Webhook) Delete(webhookId string) (bool, error) {
+	id, err := parseWebhookId(webhookId)
+	if err != nil {
+		return false, err
+	}
+	return service.repository.RemoveById(id)
+}
+
+// Deliveries returns the page of the delivery log of the webhook, the newest delivery first.
+func (service *Webhook) Deliveries(webhookId string, page *dtos.HistoryPage) (*dtos.WebhookDeliveries, error) {
+	id, err := parseWebhookId(webhookId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = service.repository.FindById(id); err != nil {
+		return nil, err
+	}
+	total, deliveryModels, err := service.repository.ListDeliveries(id, page)
+	if err != nil {
+		return nil, err
+	}
+	webhookDeliveries := dtos.WebhookDeliveries {
+		WebhookId:	id,
+		Total:		total,
+		Page:		page.Page,
+		PerPage:	page.PerPage,
+		Deliveries:	make([]*dtos.WebhookDeliveryGet, 0, len(deliveryModels)),
+	}
+	for _, deliveryModel := range deliveryModels {
+		webhookDeliveries.Deliveries = append(webhookDeliveries.Deliveries, dtos.WebhookDeliveryGetFromModel(deliveryModel))
+	}
+	return &webhookDeliveries, nil
+}
+
+// sign returns the value of signatureHeader: HMAC-SHA256 of the timestamp and the body joined by a dot.
+func sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliver posts the payload to the webhook; any response but 2xx is a failure.
+func (service *Webhook) deliver(ctx context.Context) func(*model.OutgoingDelivery) (*int, error) {
+	return func(delivery *model.OutgoingDelivery) (*int, error) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+		if err != nil {
+			return nil, err
+		}
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set(eventHeader, string(delivery.Event))
+		request.Header.Set(deliveryHeader, strconv.FormatInt(delivery.Id, 10))
+		request.Header.Set(timestampHeader, timestamp)
+		request.Header.Set(signatureHeader, sign(delivery.Secret, timestamp, delivery.Payload))
+		response, err := service.client.Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+		_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 1 << 16))
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return &response.StatusCode, fmt.Errorf("Webhook responded with %s", response.Status)
+		}
+		return &response.StatusCode, nil
+	}
+}
+
+// Run dispatches the deliveries until ctx is done, retrying failures with exponential backoff;
+// the delivery failed maxDeliveryAttempts times is dead-lettered.
+func (service *Webhook) Run(ctx context.Context) {
+	for {
+		// The lease outlasts the delivery of the whole batch
+		lease := time.Duration(service.batchSize) * deliveryTimeout
+		count, err := service.repository.Dispatch(service.batchSize, maxDeliveryAttempts, lease, service.deliver(ctx), exponentialBackoff)
+		if err != nil {
+			log.Printf("Webhook dispatch error: %v", err)
+		}
+		if err != nil || count < service.batchSize {
+			select {
+				case <-ctx.Done():
+					return
+				case <-time.After(service.interval):
+			}
+		} else if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
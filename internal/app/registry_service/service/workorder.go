@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/dtos"
+	"github.com/Melanjnk/equipment-monitor/internal/app/registry_service/model"
+)
+
+type WorkOrderRepository interface {
+	Create(workOrder *model.WorkOrder, check func(from, to model.OperationalStatus, reason string) error) (int64, error)
+	Update(workOrder *model.WorkOrder, fromState model.WorkOrderState) (bool, error)
+	Close(
+		workOrder *model.WorkOrder, fromState model.WorkOrderState,
+		check func(from, to model.OperationalStatus, reason string) error,
+	) (bool, error)
+	FindById(id int64) (*model.WorkOrder, error)
+	List(workOrderFilter *dtos.WorkOrderFilter) (int, []*dtos.WorkOrderGet, error)
+}
+
+// WorkOrders records the maintenance of the equipment: open -> assigned -> in_progress -> done, any open work order may be cancelled.
+// The work orders managing the status move the equipment UnderMaintenance when opened
+// and return it Operational when the last of them is closed, within the transaction storing the work order.
+type WorkOrders struct {
+	repository	WorkOrderRepository
+	transitions	model.TransitionGraph
+}
+
+func NewWorkOrders(repository WorkOrderRepository, transitions model.TransitionGraph) WorkOrders {
+	return WorkOrders{repository: repository, transitions: transitions}
+}
+
+func parseWorkOrderId(workOrderId string) (int64, error) {
+	id, err := strconv.ParseInt(workOrderId, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid work order id `%s`", workOrderId)
+	}
+	return id, nil
+}
+
+// Create opens the work order of the existing equipment; fails with sql.ErrNoRows for unknown equipment
+// and with the error wrapping model.ErrConflict if the equipment cannot be moved UnderMaintenance.
+func (service *WorkOrders) Create(workOrderCreate *dtos.WorkOrderCreate) (int64, error) {
+	return service.repository.Create(workOrderCreate.WorkOrder(), service.transitions.Check)
+}
+
+// change applies the change to the work order and stores it; fails with sql.ErrNoRows for unknown work order
+// and with the error wrapping model.ErrConflict if the state cannot be changed.
+func (service *WorkOrders) change(
+	workOrderId string,
+	apply func(workOrder *model.WorkOrder, now time.Time),
+	store func(workOrder *model.WorkOrder, fromState model.WorkOrderState) (bool, error),
+) (*model.WorkOrder, error) {
+	id, err := parseWorkOrderId(workOrderId)
+	if err != nil {
+		return nil, err
+	}
+	workOrder, err := service.repository.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	fromState, now := workOrder.State, time.Now()
+	apply(workOrder, now)
+	if err = fromState.CheckTransition(workOrder.State); err != nil {
+		return nil, err
+	}
+	workOrder.UpdatedAt = now
+	if updated, err := store(workOrder, fromState); err != nil {
+		return nil, err
+	} else if !updated {
+		return nil, fmt.Errorf("%w: work order #%d has been changed concurrently", model.ErrConflict, id)
+	}
+	return workOrder, nil
+}
+
+func (service *WorkOrders) Assign(workOrderId string, workOrderAssign *dtos.WorkOrderAssign) error {
+	_, err := service.change(workOrderId, func(workOrder *model.WorkOrder, now time.Time) {
+		workOrder.State = model.WorkOrderAssigned
+		workOrder.Assignee = workOrderAssign.Assignee
+		workOrder.AssignedAt = &now
+	}, service.repository.Update)
+	return err
+}
+
+func (service *WorkOrders) Start(workOrderId string) error {
+	_, err := service.change(workOrderId, func(workOrder *model.WorkOrder, now time.Time) {
+		workOrder.State = model.WorkOrderInProgress
+		workOrder.StartedAt = &now
+	}, service.repository.Update)
+	return err
+}
+
+// Close marks the work order done or cancelled settling its reservations of the spare parts; the equipment is returned Operational within the same transaction
+// once no other open work order manages its status, unless its status has been changed since a work order moved it UnderMaintenance.
+func (service *WorkOrders) Close(workOrderId string, workOrderClose *dtos.WorkOrderClose) error {
+	_, err := service.change(workOrderId, func(workOrder *model.WorkOrder, now time.Time) {
+		workOrderClose.Apply(workOrder, now)
+	}, func(workOrder *model.WorkOrder, fromState model.WorkOrderState) (bool, error) {
+		return service.repository.Close(workOrder, fromState, service.transitions.Check)
+	})
+	return err
+}
+
+func (service *WorkOrders) Get(workOrderId string) (*dtos.WorkOrderGet, error) {
+	id, err := parseWorkOrderId(workOrderId)
+	if err != nil {
+		return nil, err
+	}
+	workOrderModel, err := service.repository.FindById(id)
+	if err != nil {
+		return nil, err
+	}
+	return dtos.WorkOrderGetFromModel(*workOrderModel), nil
+}
+
+func (service *WorkOrders) List(workOrderFilter *dtos.WorkOrderFilter) (*dtos.WorkOrderList, error) {
+	total, workOrderGets, err := service.repository.List(workOrderFilter)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.WorkOrderList{Total: total, Page: workOrderFilter.Page, PerPage: workOrderFilter.PerPage, WorkOrders: workOrderGets}, nil
+}
@@ -1,185 +0,0 @@
-// Code generated by protoc-gen-grpc-gateway. DO NOT EDIT.
-// source: ozonmp/omp_template_api/v1/omp_template_api.proto
-
-/*
-Package omp_template_api is a reverse proxy.
-
-It translates gRPC into RESTful JSON APIs.
-*/
-package equipment_api
-
-import (
-	"context"
-	"io"
-	"net/http"
-
-	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/grpclog"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/proto"
-)
-
-// Suppress "imported and not used" errors
-var _ codes.Code
-var _ io.Reader
-var _ status.Status
-var _ = runtime.String
-var _ = utilities.NewDoubleArray
-var _ = metadata.Join
-
-func request_OmpTemplateApiService_DescribeTemplateV1_0(ctx context.Context, marshaler runtime.Marshaler, client OmpTemplateApiServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
-	var protoReq DescribeTemplateV1Request
-	var metadata runtime.ServerMetadata
-
-	var (
-		val string
-		ok  bool
-		err error
-		_   = err
-	)
-
-	val, ok = pathParams["template_id"]
-	if !ok {
-		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "template_id")
-	}
-
-	protoReq.TemplateId, err = runtime.Uint64(val)
-	if err != nil {
-		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "template_id", err)
-	}
-
-	msg, err := client.DescribeTemplateV1(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
-	return msg, metadata, err
-
-}
-
-func local_request_OmpTemplateApiService_DescribeTemplateV1_0(ctx context.Context, marshaler runtime.Marshaler, server OmpTemplateApiServiceServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
-	var protoReq DescribeTemplateV1Request
-	var metadata runtime.ServerMetadata
-
-	var (
-		val string
-		ok  bool
-		err error
-		_   = err
-	)
-
-	val, ok = pathParams["template_id"]
-	if !ok {
-		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "template_id")
-	}
-
-	protoReq.TemplateId, err = runtime.Uint64(val)
-	if err != nil {
-		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "template_id", err)
-	}
-
-	msg, err := server.DescribeTemplateV1(ctx, &protoReq)
-	return msg, metadata, err
-
-}
-
-// RegisterOmpTemplateApiServiceHandlerServer registers the http handlers for service OmpTemplateApiService to "mux".
-// UnaryRPC     :call OmpTemplateApiServiceServer directly.
-// StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
-// Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterOmpTemplateApiServiceHandlerFromEndpoint instead.
-func RegisterOmpTemplateApiServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server OmpTemplateApiServiceServer) error {
-
-	mux.Handle("GET", pattern_OmpTemplateApiService_DescribeTemplateV1_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		ctx, cancel := context.WithCancel(req.Context())
-		defer cancel()
-		var stream runtime.ServerTransportStream
-		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
-		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		rctx, err := runtime.AnnotateIncomingContext(ctx, mux, req, "/ozonmp.omp_template_api.v1.OmpTemplateApiService/DescribeTemplateV1", runtime.WithHTTPPathPattern("/v1/templates/{template_id}"))
-		if err != nil {
-			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-			return
-		}
-		resp, md, err := local_request_OmpTemplateApiService_DescribeTemplateV1_0(rctx, inboundMarshaler, server, req, pathParams)
-		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
-		ctx = runtime.NewServerMetadataContext(ctx, md)
-		if err != nil {
-			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-			return
-		}
-
-		forward_OmpTemplateApiService_DescribeTemplateV1_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-
-	})
-
-	return nil
-}
-
-// RegisterOmpTemplateApiServiceHandlerFromEndpoint is same as RegisterOmpTemplateApiServiceHandler but
-// automatically dials to "endpoint" and closes the connection when "ctx" gets done.
-func RegisterOmpTemplateApiServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
-	conn, err := grpc.Dial(endpoint, opts...)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if err != nil {
-			if cerr := conn.Close(); cerr != nil {
-				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
-			}
-			return
-		}
-		go func() {
-			<-ctx.Done()
-			if cerr := conn.Close(); cerr != nil {
-				grpclog.Infof("Failed to close conn to %s: %v", endpoint, cerr)
-			}
-		}()
-	}()
-
-	return RegisterOmpTemplateApiServiceHandler(ctx, mux, conn)
-}
-
-// RegisterOmpTemplateApiServiceHandler registers the http handlers for service OmpTemplateApiService to "mux".
-// The handlers forward requests to the grpc endpoint over "conn".
-func RegisterOmpTemplateApiServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterOmpTemplateApiServiceHandlerClient(ctx, mux, NewOmpTemplateApiServiceClient(conn))
-}
-
-// RegisterOmpTemplateApiServiceHandlerClient registers the http handlers for service OmpTemplateApiService
-// to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "OmpTemplateApiServiceClient".
-// Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "OmpTemplateApiServiceClient"
-// doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
-// "OmpTemplateApiServiceClient" to call the correct interceptors.
-func RegisterOmpTemplateApiServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client OmpTemplateApiServiceClient) error {
-
-	mux.Handle("GET", pattern_OmpTemplateApiService_DescribeTemplateV1_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
-		ctx, cancel := context.WithCancel(req.Context())
-		defer cancel()
-		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		rctx, err := runtime.AnnotateContext(ctx, mux, req, "/ozonmp.omp_template_api.v1.OmpTemplateApiService/DescribeTemplateV1", runtime.WithHTTPPathPattern("/v1/templates/{template_id}"))
-		if err != nil {
-			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-			return
-		}
-		resp, md, err := request_OmpTemplateApiService_DescribeTemplateV1_0(rctx, inboundMarshaler, client, req, pathParams)
-		ctx = runtime.NewServerMetadataContext(ctx, md)
-		if err != nil {
-			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
-			return
-		}
-
-		forward_OmpTemplateApiService_DescribeTemplateV1_0(ctx, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-
-	})
-
-	return nil
-}
-
-var (
-	pattern_OmpTemplateApiService_DescribeTemplateV1_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2}, []string{"v1", "templates", "template_id"}, ""))
-)
-
-var (
-	forward_OmpTemplateApiService_DescribeTemplateV1_0 = runtime.ForwardResponseMessage
-)
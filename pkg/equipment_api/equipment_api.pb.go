@@ -1,8 +1,8 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.27.1
-// 	protoc        v3.18.1
-// source: ozonmp/omp_template_api/v1/omp_template_api.proto
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: melanjnk/equipment-api/v1/equipment_api.proto
 
 package equipment_api
 
@@ -11,6 +11,7 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -23,33 +24,638 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type Template struct {
+// EquipmentKind - The kinds seeded on the catalog creation; other kinds of the catalog are passed by their identifiers
+type EquipmentKind int32
+
+const (
+	EquipmentKind_CNC_MACHINE   EquipmentKind = 0
+	EquipmentKind_CONVEYOR_BELT EquipmentKind = 1
+	EquipmentKind_DRILL_MACHINE EquipmentKind = 2
+	EquipmentKind_ROBOTIC_ARM   EquipmentKind = 3
+)
+
+// Enum value maps for EquipmentKind.
+var (
+	EquipmentKind_name = map[int32]string{
+		0: "CNC_MACHINE",
+		1: "CONVEYOR_BELT",
+		2: "DRILL_MACHINE",
+		3: "ROBOTIC_ARM",
+	}
+	EquipmentKind_value = map[string]int32{
+		"CNC_MACHINE":   0,
+		"CONVEYOR_BELT": 1,
+		"DRILL_MACHINE": 2,
+		"ROBOTIC_ARM":   3,
+	}
+)
+
+func (x EquipmentKind) Enum() *EquipmentKind {
+	p := new(EquipmentKind)
+	*p = x
+	return p
+}
+
+func (x EquipmentKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EquipmentKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_enumTypes[0].Descriptor()
+}
+
+func (EquipmentKind) Type() protoreflect.EnumType {
+	return &file_melanjnk_equipment_api_v1_equipment_api_proto_enumTypes[0]
+}
+
+func (x EquipmentKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EquipmentKind.Descriptor instead.
+func (EquipmentKind) EnumDescriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{0}
+}
+
+type OperationalStatus int32
+
+const (
+	OperationalStatus_OPERATIONAL       OperationalStatus = 0
+	OperationalStatus_UNDER_MAINTENANCE OperationalStatus = 1
+	OperationalStatus_DECOMMISSIONED    OperationalStatus = 2
+)
+
+// Enum value maps for OperationalStatus.
+var (
+	OperationalStatus_name = map[int32]string{
+		0: "OPERATIONAL",
+		1: "UNDER_MAINTENANCE",
+		2: "DECOMMISSIONED",
+	}
+	OperationalStatus_value = map[string]int32{
+		"OPERATIONAL":       0,
+		"UNDER_MAINTENANCE": 1,
+		"DECOMMISSIONED":    2,
+	}
+)
+
+func (x OperationalStatus) Enum() *OperationalStatus {
+	p := new(OperationalStatus)
+	*p = x
+	return p
+}
+
+func (x OperationalStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationalStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_enumTypes[1].Descriptor()
+}
+
+func (OperationalStatus) Type() protoreflect.EnumType {
+	return &file_melanjnk_equipment_api_v1_equipment_api_proto_enumTypes[1]
+}
+
+func (x OperationalStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationalStatus.Descriptor instead.
+func (OperationalStatus) EnumDescriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{1}
+}
+
+type Equipment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind       EquipmentKind          `protobuf:"varint,2,opt,name=kind,proto3,enum=melanjnk.equipment_api.v1.EquipmentKind" json:"kind,omitempty"`
+	Status     OperationalStatus      `protobuf:"varint,3,opt,name=status,proto3,enum=melanjnk.equipment_api.v1.OperationalStatus" json:"status,omitempty"`
+	Parameters *structpb.Struct       `protobuf:"bytes,4,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Omitted for the past states
+	LastSeenAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	// unknown, online or unreachable; omitted for the past states
+	Connectivity string `protobuf:"bytes,8,opt,name=connectivity,proto3" json:"connectivity,omitempty"`
+}
+
+func (x *Equipment) Reset() {
+	*x = Equipment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Equipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Equipment) ProtoMessage() {}
+
+func (x *Equipment) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Equipment.ProtoReflect.Descriptor instead.
+func (*Equipment) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Equipment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Equipment) GetKind() EquipmentKind {
+	if x != nil {
+		return x.Kind
+	}
+	return EquipmentKind_CNC_MACHINE
+}
+
+func (x *Equipment) GetStatus() OperationalStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OperationalStatus_OPERATIONAL
+}
+
+func (x *Equipment) GetParameters() *structpb.Struct {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Equipment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Equipment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Equipment) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *Equipment) GetConnectivity() string {
+	if x != nil {
+		return x.Connectivity
+	}
+	return ""
+}
+
+type CreateEquipmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Required
+	Kind       *EquipmentKind   `protobuf:"varint,1,opt,name=kind,proto3,enum=melanjnk.equipment_api.v1.EquipmentKind,oneof" json:"kind,omitempty"`
+	Parameters *structpb.Struct `protobuf:"bytes,2,opt,name=parameters,proto3" json:"parameters,omitempty"`
+}
+
+func (x *CreateEquipmentRequest) Reset() {
+	*x = CreateEquipmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateEquipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEquipmentRequest) ProtoMessage() {}
+
+func (x *CreateEquipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEquipmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateEquipmentRequest) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateEquipmentRequest) GetKind() EquipmentKind {
+	if x != nil && x.Kind != nil {
+		return *x.Kind
+	}
+	return EquipmentKind_CNC_MACHINE
+}
+
+func (x *CreateEquipmentRequest) GetParameters() *structpb.Struct {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type CreateEquipmentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CreateEquipmentResponse) Reset() {
+	*x = CreateEquipmentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateEquipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEquipmentResponse) ProtoMessage() {}
+
+func (x *CreateEquipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEquipmentResponse.ProtoReflect.Descriptor instead.
+func (*CreateEquipmentResponse) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateEquipmentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetEquipmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The instant the state is restored at, the current state if omitted
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+}
+
+func (x *GetEquipmentRequest) Reset() {
+	*x = GetEquipmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEquipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEquipmentRequest) ProtoMessage() {}
+
+func (x *GetEquipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEquipmentRequest.ProtoReflect.Descriptor instead.
+func (*GetEquipmentRequest) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetEquipmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetEquipmentRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+type GetEquipmentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *Equipment `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *GetEquipmentResponse) Reset() {
+	*x = GetEquipmentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEquipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEquipmentResponse) ProtoMessage() {}
+
+func (x *GetEquipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEquipmentResponse.ProtoReflect.Descriptor instead.
+func (*GetEquipmentResponse) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetEquipmentResponse) GetValue() *Equipment {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// ListEquipmentRequest - The filters are combined by AND; kinds and no_kinds (statuses and no_statuses) cannot be used together
+// (kinds and no_kinds take the seeded kinds only)
+type ListEquipmentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kinds        []EquipmentKind        `protobuf:"varint,1,rep,packed,name=kinds,proto3,enum=melanjnk.equipment_api.v1.EquipmentKind" json:"kinds,omitempty"`
+	NoKinds      []EquipmentKind        `protobuf:"varint,2,rep,packed,name=no_kinds,json=noKinds,proto3,enum=melanjnk.equipment_api.v1.EquipmentKind" json:"no_kinds,omitempty"`
+	Statuses     []OperationalStatus    `protobuf:"varint,3,rep,packed,name=statuses,proto3,enum=melanjnk.equipment_api.v1.OperationalStatus" json:"statuses,omitempty"`
+	NoStatuses   []OperationalStatus    `protobuf:"varint,4,rep,packed,name=no_statuses,json=noStatuses,proto3,enum=melanjnk.equipment_api.v1.OperationalStatus" json:"no_statuses,omitempty"`
+	CreatedSince *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_since,json=createdSince,proto3" json:"created_since,omitempty"`
+	CreatedUntil *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_until,json=createdUntil,proto3" json:"created_until,omitempty"`
+	UpdatedSince *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	UpdatedUntil *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_until,json=updatedUntil,proto3" json:"updated_until,omitempty"`
+	// Filter the state of the equipment at the instant; cannot be used with the connectivity filters
+	AsOf          *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	LastSeenSince *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_seen_since,json=lastSeenSince,proto3" json:"last_seen_since,omitempty"`
+	LastSeenUntil *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_seen_until,json=lastSeenUntil,proto3" json:"last_seen_until,omitempty"`
+	Connectivity  []string               `protobuf:"bytes,12,rep,name=connectivity,proto3" json:"connectivity,omitempty"`
+	// The filter expression of the REST API, AND-ed with the other filters
+	Filter string `protobuf:"bytes,13,opt,name=filter,proto3" json:"filter,omitempty"`
+	// The page: up to limit (100 by default) pieces of equipment ordered by sort (id by default), the ties by the id
+	Limit int32 `protobuf:"varint,14,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of the previous page issued for the same sort and descending
+	Cursor     string `protobuf:"bytes,15,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Sort       string `protobuf:"bytes,16,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending bool   `protobuf:"varint,17,opt,name=descending,proto3" json:"descending,omitempty"`
+	// Count the equipment matching the filters
+	Total bool `protobuf:"varint,18,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *ListEquipmentRequest) Reset() {
+	*x = ListEquipmentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListEquipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEquipmentRequest) ProtoMessage() {}
+
+func (x *ListEquipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEquipmentRequest.ProtoReflect.Descriptor instead.
+func (*ListEquipmentRequest) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListEquipmentRequest) GetKinds() []EquipmentKind {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetNoKinds() []EquipmentKind {
+	if x != nil {
+		return x.NoKinds
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetStatuses() []OperationalStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetNoStatuses() []OperationalStatus {
+	if x != nil {
+		return x.NoStatuses
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetCreatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedSince
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetCreatedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedUntil
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetUpdatedSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetUpdatedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedUntil
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetLastSeenSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenSince
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetLastSeenUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenUntil
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetConnectivity() []string {
+	if x != nil {
+		return x.Connectivity
+	}
+	return nil
+}
+
+func (x *ListEquipmentRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListEquipmentRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListEquipmentRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListEquipmentRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListEquipmentRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListEquipmentRequest) GetTotal() bool {
+	if x != nil {
+		return x.Total
+	}
+	return false
+}
+
+type ListEquipmentResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Foo     uint64                 `protobuf:"varint,2,opt,name=foo,proto3" json:"foo,omitempty"`
-	Created *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created,proto3" json:"created,omitempty"`
+	Items []*Equipment `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Empty on the last page
+	NextCursor string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// If requested
+	Total *int64 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
 }
 
-func (x *Template) Reset() {
-	*x = Template{}
+func (x *ListEquipmentResponse) Reset() {
+	*x = ListEquipmentResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes[0]
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Template) String() string {
+func (x *ListEquipmentResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Template) ProtoMessage() {}
+func (*ListEquipmentResponse) ProtoMessage() {}
 
-func (x *Template) ProtoReflect() protoreflect.Message {
-	mi := &file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes[0]
+func (x *ListEquipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -60,57 +666,63 @@ func (x *Template) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Template.ProtoReflect.Descriptor instead.
-func (*Template) Descriptor() ([]byte, []int) {
-	return file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ListEquipmentResponse.ProtoReflect.Descriptor instead.
+func (*ListEquipmentResponse) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Template) GetId() uint64 {
+func (x *ListEquipmentResponse) GetItems() []*Equipment {
 	if x != nil {
-		return x.Id
+		return x.Items
 	}
-	return 0
+	return nil
 }
 
-func (x *Template) GetFoo() uint64 {
+func (x *ListEquipmentResponse) GetNextCursor() string {
 	if x != nil {
-		return x.Foo
+		return x.NextCursor
 	}
-	return 0
+	return ""
 }
 
-func (x *Template) GetCreated() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Created
+func (x *ListEquipmentResponse) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
 	}
-	return nil
+	return 0
 }
 
-type DescribeTemplateV1Request struct {
+type UpdateEquipmentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TemplateId uint64 `protobuf:"varint,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Id     string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status *OperationalStatus `protobuf:"varint,2,opt,name=status,proto3,enum=melanjnk.equipment_api.v1.OperationalStatus,oneof" json:"status,omitempty"`
+	// Replaces the parameters as a whole
+	Parameters *structpb.Struct `protobuf:"bytes,3,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	// Recorded with the status transition
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor  string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
 }
 
-func (x *DescribeTemplateV1Request) Reset() {
-	*x = DescribeTemplateV1Request{}
+func (x *UpdateEquipmentRequest) Reset() {
+	*x = UpdateEquipmentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes[1]
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DescribeTemplateV1Request) String() string {
+func (x *UpdateEquipmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DescribeTemplateV1Request) ProtoMessage() {}
+func (*UpdateEquipmentRequest) ProtoMessage() {}
 
-func (x *DescribeTemplateV1Request) ProtoReflect() protoreflect.Message {
-	mi := &file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes[1]
+func (x *UpdateEquipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -121,43 +733,109 @@ func (x *DescribeTemplateV1Request) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DescribeTemplateV1Request.ProtoReflect.Descriptor instead.
-func (*DescribeTemplateV1Request) Descriptor() ([]byte, []int) {
-	return file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use UpdateEquipmentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEquipmentRequest) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateEquipmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateEquipmentRequest) GetStatus() OperationalStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return OperationalStatus_OPERATIONAL
 }
 
-func (x *DescribeTemplateV1Request) GetTemplateId() uint64 {
+func (x *UpdateEquipmentRequest) GetParameters() *structpb.Struct {
 	if x != nil {
-		return x.TemplateId
+		return x.Parameters
 	}
-	return 0
+	return nil
+}
+
+func (x *UpdateEquipmentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateEquipmentRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type UpdateEquipmentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateEquipmentResponse) Reset() {
+	*x = UpdateEquipmentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateEquipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEquipmentResponse) ProtoMessage() {}
+
+func (x *UpdateEquipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEquipmentResponse.ProtoReflect.Descriptor instead.
+func (*UpdateEquipmentResponse) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{8}
 }
 
-type DescribeTemplateV1Response struct {
+type DeleteEquipmentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value *Template `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *DescribeTemplateV1Response) Reset() {
-	*x = DescribeTemplateV1Response{}
+func (x *DeleteEquipmentRequest) Reset() {
+	*x = DeleteEquipmentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes[2]
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DescribeTemplateV1Response) String() string {
+func (x *DeleteEquipmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DescribeTemplateV1Response) ProtoMessage() {}
+func (*DeleteEquipmentRequest) ProtoMessage() {}
 
-func (x *DescribeTemplateV1Response) ProtoReflect() protoreflect.Message {
-	mi := &file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes[2]
+func (x *DeleteEquipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,107 +846,467 @@ func (x *DescribeTemplateV1Response) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DescribeTemplateV1Response.ProtoReflect.Descriptor instead.
-func (*DescribeTemplateV1Response) Descriptor() ([]byte, []int) {
-	return file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use DeleteEquipmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEquipmentRequest) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *DescribeTemplateV1Response) GetValue() *Template {
+func (x *DeleteEquipmentRequest) GetId() string {
 	if x != nil {
-		return x.Value
+		return x.Id
 	}
-	return nil
+	return ""
+}
+
+type DeleteEquipmentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteEquipmentResponse) Reset() {
+	*x = DeleteEquipmentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteEquipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEquipmentResponse) ProtoMessage() {}
+
+func (x *DeleteEquipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var File_ozonmp_omp_template_api_v1_omp_template_api_proto protoreflect.FileDescriptor
-
-var file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDesc = []byte{
-	0x0a, 0x31, 0x6f, 0x7a, 0x6f, 0x6e, 0x6d, 0x70, 0x2f, 0x6f, 0x6d, 0x70, 0x5f, 0x74, 0x65, 0x6d,
-	0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x6d, 0x70,
-	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x6f, 0x7a, 0x6f, 0x6e, 0x6d, 0x70, 0x2e, 0x6f, 0x6d, 0x70, 0x5f,
-	0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a,
-	0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
-	0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x62, 0x0a, 0x08, 0x54, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x6f, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x03, 0x66, 0x6f, 0x6f, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x19, 0x44,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x56,
-	0x31, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x0b, 0x74, 0x65, 0x6d, 0x70,
-	0x6c, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x42, 0x07, 0xfa,
-	0x42, 0x04, 0x32, 0x02, 0x20, 0x00, 0x52, 0x0a, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
-	0x49, 0x64, 0x22, 0x58, 0x0a, 0x1a, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x65,
-	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x56, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x24, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x6d, 0x70, 0x2e, 0x6f, 0x6d, 0x70, 0x5f, 0x74, 0x65, 0x6d,
-	0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d,
-	0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x32, 0xc2, 0x01, 0x0a,
-	0x15, 0x4f, 0x6d, 0x70, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x41, 0x70, 0x69, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0xa8, 0x01, 0x0a, 0x12, 0x44, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x62, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x56, 0x31, 0x12, 0x35, 0x2e,
-	0x6f, 0x7a, 0x6f, 0x6e, 0x6d, 0x70, 0x2e, 0x6f, 0x6d, 0x70, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x62, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x56, 0x31, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x36, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x6d, 0x70, 0x2e, 0x6f, 0x6d,
-	0x70, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
-	0x74, 0x65, 0x56, 0x31, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x1d, 0x12, 0x1b, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61,
-	0x74, 0x65, 0x73, 0x2f, 0x7b, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64,
-	0x7d, 0x42, 0x4a, 0x5a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x6f, 0x7a, 0x6f, 0x6e, 0x6d, 0x70, 0x2f, 0x6f, 0x6d, 0x70, 0x2d, 0x74, 0x65, 0x6d, 0x70, 0x6c,
-	0x61, 0x74, 0x65, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x6f, 0x6d, 0x70, 0x2d,
-	0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x2d, 0x61, 0x70, 0x69, 0x3b, 0x6f, 0x6d, 0x70,
-	0x5f, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+// Deprecated: Use DeleteEquipmentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteEquipmentResponse) Descriptor() ([]byte, []int) {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP(), []int{10}
+}
+
+var File_melanjnk_equipment_api_v1_equipment_api_proto protoreflect.FileDescriptor
+
+var file_melanjnk_equipment_api_v1_equipment_api_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2f, 0x65, 0x71, 0x75, 0x69, 0x70,
+	0x6d, 0x65, 0x6e, 0x74, 0x2d, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x71, 0x75, 0x69,
+	0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x19, 0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xb0, 0x03, 0x0a, 0x09, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3c,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6d,
+	0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65,
+	0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x44, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x6d,
+	0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x37, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x3c, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x61,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x41, 0x74, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x76,
+	0x69, 0x74, 0x79, 0x22, 0xa7, 0x01, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x71,
+	0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6d,
+	0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65,
+	0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x48, 0x00, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x41, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x42, 0x08,
+	0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x29, 0x0a,
+	0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x60, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x45,
+	0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05,
+	0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2f, 0x0a, 0x05, 0x61, 0x73, 0x5f,
+	0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x22, 0x52, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75,
+	0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71,
+	0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xd4,
+	0x08, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x05, 0x6b, 0x69, 0x6e, 0x64, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e,
+	0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64,
+	0x42, 0x0d, 0xfa, 0x42, 0x0a, 0x92, 0x01, 0x07, 0x22, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52,
+	0x05, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x12, 0x52, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x6b, 0x69, 0x6e,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x6d, 0x65, 0x6c, 0x61, 0x6e,
+	0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x4b, 0x69,
+	0x6e, 0x64, 0x42, 0x0d, 0xfa, 0x42, 0x0a, 0x92, 0x01, 0x07, 0x22, 0x05, 0x82, 0x01, 0x02, 0x10,
+	0x01, 0x52, 0x07, 0x6e, 0x6f, 0x4b, 0x69, 0x6e, 0x64, 0x73, 0x12, 0x57, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x6d,
+	0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0d, 0xfa, 0x42, 0x0a, 0x92,
+	0x01, 0x07, 0x22, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x65, 0x73, 0x12, 0x5c, 0x0a, 0x0b, 0x6e, 0x6f, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x6d, 0x65, 0x6c, 0x61, 0x6e,
+	0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x0d, 0xfa, 0x42, 0x0a, 0x92, 0x01, 0x07, 0x22, 0x05,
+	0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0a, 0x6e, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65,
+	0x73, 0x12, 0x3f, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x6e,
+	0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x6e,
+	0x74, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x55, 0x6e,
+	0x74, 0x69, 0x6c, 0x12, 0x3f, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x53,
+	0x69, 0x6e, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x2f, 0x0a, 0x05, 0x61, 0x73, 0x5f, 0x6f, 0x66, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x12, 0x42, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73,
+	0x65, 0x65, 0x6e, 0x5f, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x6c, 0x61, 0x73,
+	0x74, 0x53, 0x65, 0x65, 0x6e, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x0f, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0d, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x4c,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x18, 0x0c,
+	0x20, 0x03, 0x28, 0x09, 0x42, 0x28, 0xfa, 0x42, 0x25, 0x92, 0x01, 0x22, 0x22, 0x20, 0x72, 0x1e,
+	0x52, 0x07, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x52, 0x06, 0x6f, 0x6e, 0x6c, 0x69, 0x6e,
+	0x65, 0x52, 0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x0c,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x06,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0x72, 0x03, 0x18, 0x80, 0x20, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x20,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x42, 0x0a, 0xfa,
+	0x42, 0x07, 0x1a, 0x05, 0x18, 0xe8, 0x07, 0x28, 0x00, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x45, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x42, 0x31, 0xfa, 0x42, 0x2e, 0x72, 0x2c, 0x52, 0x00, 0x52,
+	0x02, 0x69, 0x64, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x52,
+	0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x52, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x99, 0x01, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x71,
+	0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3a, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24,
+	0x2e, 0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d,
+	0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x71, 0x75, 0x69, 0x70,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x05, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x22, 0xf9, 0x01, 0x0a, 0x16, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x71, 0x75, 0x69,
+	0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0,
+	0x01, 0x01, 0x52, 0x02, 0x69, 0x64, 0x12, 0x53, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e,
+	0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x48, 0x00,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x37, 0x0a, 0x0a, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x19, 0x0a,
+	0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x32, 0x0a, 0x16, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
+	0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x02, 0x69, 0x64, 0x22, 0x19, 0x0a, 0x17,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x57, 0x0a, 0x0d, 0x45, 0x71, 0x75, 0x69, 0x70,
+	0x6d, 0x65, 0x6e, 0x74, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x4e, 0x43, 0x5f,
+	0x4d, 0x41, 0x43, 0x48, 0x49, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4e,
+	0x56, 0x45, 0x59, 0x4f, 0x52, 0x5f, 0x42, 0x45, 0x4c, 0x54, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d,
+	0x44, 0x52, 0x49, 0x4c, 0x4c, 0x5f, 0x4d, 0x41, 0x43, 0x48, 0x49, 0x4e, 0x45, 0x10, 0x02, 0x12,
+	0x0f, 0x0a, 0x0b, 0x52, 0x4f, 0x42, 0x4f, 0x54, 0x49, 0x43, 0x5f, 0x41, 0x52, 0x4d, 0x10, 0x03,
+	0x2a, 0x4f, 0x0a, 0x11, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x55, 0x4e, 0x44, 0x45, 0x52, 0x5f,
+	0x4d, 0x41, 0x49, 0x4e, 0x54, 0x45, 0x4e, 0x41, 0x4e, 0x43, 0x45, 0x10, 0x01, 0x12, 0x12, 0x0a,
+	0x0e, 0x44, 0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x45, 0x44, 0x10,
+	0x02, 0x32, 0xf2, 0x05, 0x0a, 0x10, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x92, 0x01, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x31, 0x2e, 0x6d, 0x65, 0x6c,
+	0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x71, 0x75,
+	0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e,
+	0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x3a, 0x01, 0x2a, 0x22, 0x0d, 0x2f, 0x76,
+	0x31, 0x2f, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x8b, 0x01, 0x0a, 0x0c,
+	0x47, 0x65, 0x74, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x2e, 0x6d,
+	0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x71, 0x75, 0x69,
+	0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2f, 0x2e, 0x6d,
+	0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x71, 0x75, 0x69,
+	0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x71, 0x75, 0x69, 0x70,
+	0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x89, 0x01, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2f, 0x2e, 0x6d, 0x65,
+	0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x71, 0x75, 0x69,
+	0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x6d,
+	0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x71, 0x75,
+	0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x12, 0x0d, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x71, 0x75, 0x69,
+	0x70, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x97, 0x01, 0x0a, 0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x31, 0x2e, 0x6d, 0x65, 0x6c, 0x61,
+	0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45, 0x71, 0x75, 0x69,
+	0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x6d,
+	0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x45,
+	0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x3a, 0x01, 0x2a, 0x32, 0x12, 0x2f, 0x76, 0x31,
+	0x2f, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12,
+	0x94, 0x01, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x31, 0x2e, 0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2e, 0x65,
+	0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x32, 0x2e, 0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e,
+	0x6b, 0x2e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x14, 0x2a, 0x12, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x42, 0x47, 0x5a, 0x45, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x65, 0x6c, 0x61, 0x6e, 0x6a, 0x6e, 0x6b, 0x2f, 0x65, 0x71,
+	0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x2d, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70,
+	0x69, 0x3b, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x70, 0x69, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
-	file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescOnce sync.Once
-	file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescData = file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDesc
+	file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescOnce sync.Once
+	file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescData = file_melanjnk_equipment_api_v1_equipment_api_proto_rawDesc
 )
 
-func file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescGZIP() []byte {
-	file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescOnce.Do(func() {
-		file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescData)
+func file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescGZIP() []byte {
+	file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescOnce.Do(func() {
+		file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescData = protoimpl.X.CompressGZIP(file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescData)
 	})
-	return file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDescData
-}
-
-var file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
-var file_ozonmp_omp_template_api_v1_omp_template_api_proto_goTypes = []interface{}{
-	(*Template)(nil),                   // 0: ozonmp.omp_template_api.v1.Template
-	(*DescribeTemplateV1Request)(nil),  // 1: ozonmp.omp_template_api.v1.DescribeTemplateV1Request
-	(*DescribeTemplateV1Response)(nil), // 2: ozonmp.omp_template_api.v1.DescribeTemplateV1Response
-	(*timestamppb.Timestamp)(nil),      // 3: google.protobuf.Timestamp
-}
-var file_ozonmp_omp_template_api_v1_omp_template_api_proto_depIdxs = []int32{
-	3, // 0: ozonmp.omp_template_api.v1.Template.created:type_name -> google.protobuf.Timestamp
-	0, // 1: ozonmp.omp_template_api.v1.DescribeTemplateV1Response.value:type_name -> ozonmp.omp_template_api.v1.Template
-	1, // 2: ozonmp.omp_template_api.v1.OmpTemplateApiService.DescribeTemplateV1:input_type -> ozonmp.omp_template_api.v1.DescribeTemplateV1Request
-	2, // 3: ozonmp.omp_template_api.v1.OmpTemplateApiService.DescribeTemplateV1:output_type -> ozonmp.omp_template_api.v1.DescribeTemplateV1Response
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
-}
-
-func init() { file_ozonmp_omp_template_api_v1_omp_template_api_proto_init() }
-func file_ozonmp_omp_template_api_v1_omp_template_api_proto_init() {
-	if File_ozonmp_omp_template_api_v1_omp_template_api_proto != nil {
+	return file_melanjnk_equipment_api_v1_equipment_api_proto_rawDescData
+}
+
+var file_melanjnk_equipment_api_v1_equipment_api_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_melanjnk_equipment_api_v1_equipment_api_proto_goTypes = []any{
+	(EquipmentKind)(0),              // 0: melanjnk.equipment_api.v1.EquipmentKind
+	(OperationalStatus)(0),          // 1: melanjnk.equipment_api.v1.OperationalStatus
+	(*Equipment)(nil),               // 2: melanjnk.equipment_api.v1.Equipment
+	(*CreateEquipmentRequest)(nil),  // 3: melanjnk.equipment_api.v1.CreateEquipmentRequest
+	(*CreateEquipmentResponse)(nil), // 4: melanjnk.equipment_api.v1.CreateEquipmentResponse
+	(*GetEquipmentRequest)(nil),     // 5: melanjnk.equipment_api.v1.GetEquipmentRequest
+	(*GetEquipmentResponse)(nil),    // 6: melanjnk.equipment_api.v1.GetEquipmentResponse
+	(*ListEquipmentRequest)(nil),    // 7: melanjnk.equipment_api.v1.ListEquipmentRequest
+	(*ListEquipmentResponse)(nil),   // 8: melanjnk.equipment_api.v1.ListEquipmentResponse
+	(*UpdateEquipmentRequest)(nil),  // 9: melanjnk.equipment_api.v1.UpdateEquipmentRequest
+	(*UpdateEquipmentResponse)(nil), // 10: melanjnk.equipment_api.v1.UpdateEquipmentResponse
+	(*DeleteEquipmentRequest)(nil),  // 11: melanjnk.equipment_api.v1.DeleteEquipmentRequest
+	(*DeleteEquipmentResponse)(nil), // 12: melanjnk.equipment_api.v1.DeleteEquipmentResponse
+	(*structpb.Struct)(nil),         // 13: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),   // 14: google.protobuf.Timestamp
+}
+var file_melanjnk_equipment_api_v1_equipment_api_proto_depIdxs = []int32{
+	0,  // 0: melanjnk.equipment_api.v1.Equipment.kind:type_name -> melanjnk.equipment_api.v1.EquipmentKind
+	1,  // 1: melanjnk.equipment_api.v1.Equipment.status:type_name -> melanjnk.equipment_api.v1.OperationalStatus
+	13, // 2: melanjnk.equipment_api.v1.Equipment.parameters:type_name -> google.protobuf.Struct
+	14, // 3: melanjnk.equipment_api.v1.Equipment.created_at:type_name -> google.protobuf.Timestamp
+	14, // 4: melanjnk.equipment_api.v1.Equipment.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 5: melanjnk.equipment_api.v1.Equipment.last_seen_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: melanjnk.equipment_api.v1.CreateEquipmentRequest.kind:type_name -> melanjnk.equipment_api.v1.EquipmentKind
+	13, // 7: melanjnk.equipment_api.v1.CreateEquipmentRequest.parameters:type_name -> google.protobuf.Struct
+	14, // 8: melanjnk.equipment_api.v1.GetEquipmentRequest.as_of:type_name -> google.protobuf.Timestamp
+	2,  // 9: melanjnk.equipment_api.v1.GetEquipmentResponse.value:type_name -> melanjnk.equipment_api.v1.Equipment
+	0,  // 10: melanjnk.equipment_api.v1.ListEquipmentRequest.kinds:type_name -> melanjnk.equipment_api.v1.EquipmentKind
+	0,  // 11: melanjnk.equipment_api.v1.ListEquipmentRequest.no_kinds:type_name -> melanjnk.equipment_api.v1.EquipmentKind
+	1,  // 12: melanjnk.equipment_api.v1.ListEquipmentRequest.statuses:type_name -> melanjnk.equipment_api.v1.OperationalStatus
+	1,  // 13: melanjnk.equipment_api.v1.ListEquipmentRequest.no_statuses:type_name -> melanjnk.equipment_api.v1.OperationalStatus
+	14, // 14: melanjnk.equipment_api.v1.ListEquipmentRequest.created_since:type_name -> google.protobuf.Timestamp
+	14, // 15: melanjnk.equipment_api.v1.ListEquipmentRequest.created_until:type_name -> google.protobuf.Timestamp
+	14, // 16: melanjnk.equipment_api.v1.ListEquipmentRequest.updated_since:type_name -> google.protobuf.Timestamp
+	14, // 17: melanjnk.equipment_api.v1.ListEquipmentRequest.updated_until:type_name -> google.protobuf.Timestamp
+	14, // 18: melanjnk.equipment_api.v1.ListEquipmentRequest.as_of:type_name -> google.protobuf.Timestamp
+	14, // 19: melanjnk.equipment_api.v1.ListEquipmentRequest.last_seen_since:type_name -> google.protobuf.Timestamp
+	14, // 20: melanjnk.equipment_api.v1.ListEquipmentRequest.last_seen_until:type_name -> google.protobuf.Timestamp
+	2,  // 21: melanjnk.equipment_api.v1.ListEquipmentResponse.items:type_name -> melanjnk.equipment_api.v1.Equipment
+	1,  // 22: melanjnk.equipment_api.v1.UpdateEquipmentRequest.status:type_name -> melanjnk.equipment_api.v1.OperationalStatus
+	13, // 23: melanjnk.equipment_api.v1.UpdateEquipmentRequest.parameters:type_name -> google.protobuf.Struct
+	3,  // 24: melanjnk.equipment_api.v1.EquipmentService.CreateEquipment:input_type -> melanjnk.equipment_api.v1.CreateEquipmentRequest
+	5,  // 25: melanjnk.equipment_api.v1.EquipmentService.GetEquipment:input_type -> melanjnk.equipment_api.v1.GetEquipmentRequest
+	7,  // 26: melanjnk.equipment_api.v1.EquipmentService.ListEquipment:input_type -> melanjnk.equipment_api.v1.ListEquipmentRequest
+	9,  // 27: melanjnk.equipment_api.v1.EquipmentService.UpdateEquipment:input_type -> melanjnk.equipment_api.v1.UpdateEquipmentRequest
+	11, // 28: melanjnk.equipment_api.v1.EquipmentService.DeleteEquipment:input_type -> melanjnk.equipment_api.v1.DeleteEquipmentRequest
+	4,  // 29: melanjnk.equipment_api.v1.EquipmentService.CreateEquipment:output_type -> melanjnk.equipment_api.v1.CreateEquipmentResponse
+	6,  // 30: melanjnk.equipment_api.v1.EquipmentService.GetEquipment:output_type -> melanjnk.equipment_api.v1.GetEquipmentResponse
+	8,  // 31: melanjnk.equipment_api.v1.EquipmentService.ListEquipment:output_type -> melanjnk.equipment_api.v1.ListEquipmentResponse
+	10, // 32: melanjnk.equipment_api.v1.EquipmentService.UpdateEquipment:output_type -> melanjnk.equipment_api.v1.UpdateEquipmentResponse
+	12, // 33: melanjnk.equipment_api.v1.EquipmentService.DeleteEquipment:output_type -> melanjnk.equipment_api.v1.DeleteEquipmentResponse
+	29, // [29:34] is the sub-list for method output_type
+	24, // [24:29] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
+}
+
+func init() { file_melanjnk_equipment_api_v1_equipment_api_proto_init() }
+func file_melanjnk_equipment_api_v1_equipment_api_proto_init() {
+	if File_melanjnk_equipment_api_v1_equipment_api_proto != nil {
 		return
 	}
 	if !protoimpl.UnsafeEnabled {
-		file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Template); i {
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Equipment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateEquipmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateEquipmentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetEquipmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetEquipmentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ListEquipmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ListEquipmentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateEquipmentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateEquipmentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -279,8 +1317,8 @@ func file_ozonmp_omp_template_api_v1_omp_template_api_proto_init() {
 				return nil
 			}
 		}
-		file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DescribeTemplateV1Request); i {
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteEquipmentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -291,8 +1329,8 @@ func file_ozonmp_omp_template_api_v1_omp_template_api_proto_init() {
 				return nil
 			}
 		}
-		file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DescribeTemplateV1Response); i {
+		file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteEquipmentResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -304,22 +1342,26 @@ func file_ozonmp_omp_template_api_v1_omp_template_api_proto_init() {
 			}
 		}
 	}
+	file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[1].OneofWrappers = []any{}
+	file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[6].OneofWrappers = []any{}
+	file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes[7].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   3,
+			RawDescriptor: file_melanjnk_equipment_api_v1_equipment_api_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
-		GoTypes:           file_ozonmp_omp_template_api_v1_omp_template_api_proto_goTypes,
-		DependencyIndexes: file_ozonmp_omp_template_api_v1_omp_template_api_proto_depIdxs,
-		MessageInfos:      file_ozonmp_omp_template_api_v1_omp_template_api_proto_msgTypes,
+		GoTypes:           file_melanjnk_equipment_api_v1_equipment_api_proto_goTypes,
+		DependencyIndexes: file_melanjnk_equipment_api_v1_equipment_api_proto_depIdxs,
+		EnumInfos:         file_melanjnk_equipment_api_v1_equipment_api_proto_enumTypes,
+		MessageInfos:      file_melanjnk_equipment_api_v1_equipment_api_proto_msgTypes,
 	}.Build()
-	File_ozonmp_omp_template_api_v1_omp_template_api_proto = out.File
-	file_ozonmp_omp_template_api_v1_omp_template_api_proto_rawDesc = nil
-	file_ozonmp_omp_template_api_v1_omp_template_api_proto_goTypes = nil
-	file_ozonmp_omp_template_api_v1_omp_template_api_proto_depIdxs = nil
+	File_melanjnk_equipment_api_v1_equipment_api_proto = out.File
+	file_melanjnk_equipment_api_v1_equipment_api_proto_rawDesc = nil
+	file_melanjnk_equipment_api_v1_equipment_api_proto_goTypes = nil
+	file_melanjnk_equipment_api_v1_equipment_api_proto_depIdxs = nil
 }
@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-validate. DO NOT EDIT.
-// source: ozonmp/omp_template_api/v1/omp_template_api.proto
+// source: melanjnk/equipment-api/v1/equipment_api.proto
 
 package equipment_api
 
@@ -11,6 +11,7 @@ import (
 	"net/mail"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -31,35 +32,184 @@ var (
 	_ = (*url.URL)(nil)
 	_ = (*mail.Address)(nil)
 	_ = anypb.Any{}
+	_ = sort.Sort
 )
 
-// Validate checks the field values on Template with the rules defined in the
-// proto definition for this message. If any rules are violated, an error is returned.
-func (m *Template) Validate() error {
+// define the regex for a UUID once up-front
+var _equipment_api_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on Equipment with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Equipment) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Equipment with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EquipmentMultiError, or nil
+// if none found.
+func (m *Equipment) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Equipment) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
+	var errors []error
+
 	// no validation rules for Id
 
-	// no validation rules for Foo
+	// no validation rules for Kind
+
+	// no validation rules for Status
+
+	if all {
+		switch v := interface{}(m.GetParameters()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EquipmentValidationError{
+					field:  "Parameters",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EquipmentValidationError{
+					field:  "Parameters",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetParameters()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EquipmentValidationError{
+				field:  "Parameters",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EquipmentValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EquipmentValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EquipmentValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EquipmentValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EquipmentValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EquipmentValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
 
-	if v, ok := interface{}(m.GetCreated()).(interface{ Validate() error }); ok {
+	if all {
+		switch v := interface{}(m.GetLastSeenAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EquipmentValidationError{
+					field:  "LastSeenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EquipmentValidationError{
+					field:  "LastSeenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastSeenAt()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return TemplateValidationError{
-				field:  "Created",
+			return EquipmentValidationError{
+				field:  "LastSeenAt",
 				reason: "embedded message failed validation",
 				cause:  err,
 			}
 		}
 	}
 
+	// no validation rules for Connectivity
+
+	if len(errors) > 0 {
+		return EquipmentMultiError(errors)
+	}
+
 	return nil
 }
 
-// TemplateValidationError is the validation error returned by
-// Template.Validate if the designated constraints aren't met.
-type TemplateValidationError struct {
+// EquipmentMultiError is an error wrapping multiple validation errors returned
+// by Equipment.ValidateAll() if the designated constraints aren't met.
+type EquipmentMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EquipmentMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EquipmentMultiError) AllErrors() []error { return m }
+
+// EquipmentValidationError is the validation error returned by
+// Equipment.Validate if the designated constraints aren't met.
+type EquipmentValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -67,22 +217,22 @@ type TemplateValidationError struct {
 }
 
 // Field function returns field value.
-func (e TemplateValidationError) Field() string { return e.field }
+func (e EquipmentValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e TemplateValidationError) Reason() string { return e.reason }
+func (e EquipmentValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e TemplateValidationError) Cause() error { return e.cause }
+func (e EquipmentValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e TemplateValidationError) Key() bool { return e.key }
+func (e EquipmentValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e TemplateValidationError) ErrorName() string { return "TemplateValidationError" }
+func (e EquipmentValidationError) ErrorName() string { return "EquipmentValidationError" }
 
 // Error satisfies the builtin error interface
-func (e TemplateValidationError) Error() string {
+func (e EquipmentValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -94,14 +244,14 @@ func (e TemplateValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sTemplate.%s: %s%s",
+		"invalid %sEquipment.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = TemplateValidationError{}
+var _ error = EquipmentValidationError{}
 
 var _ interface {
 	Field() string
@@ -109,29 +259,101 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = TemplateValidationError{}
+} = EquipmentValidationError{}
 
-// Validate checks the field values on DescribeTemplateV1Request with the rules
+// Validate checks the field values on CreateEquipmentRequest with the rules
 // defined in the proto definition for this message. If any rules are
-// violated, an error is returned.
-func (m *DescribeTemplateV1Request) Validate() error {
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateEquipmentRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateEquipmentRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateEquipmentRequestMultiError, or nil if none found.
+func (m *CreateEquipmentRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateEquipmentRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
-	if m.GetTemplateId() <= 0 {
-		return DescribeTemplateV1RequestValidationError{
-			field:  "TemplateId",
-			reason: "value must be greater than 0",
+	var errors []error
+
+	if m.GetParameters() == nil {
+		err := CreateEquipmentRequestValidationError{
+			field:  "Parameters",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetParameters()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateEquipmentRequestValidationError{
+					field:  "Parameters",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateEquipmentRequestValidationError{
+					field:  "Parameters",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetParameters()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateEquipmentRequestValidationError{
+				field:  "Parameters",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
 		}
 	}
 
+	if m.Kind != nil {
+		// no validation rules for Kind
+	}
+
+	if len(errors) > 0 {
+		return CreateEquipmentRequestMultiError(errors)
+	}
+
 	return nil
 }
 
-// DescribeTemplateV1RequestValidationError is the validation error returned by
-// DescribeTemplateV1Request.Validate if the designated constraints aren't met.
-type DescribeTemplateV1RequestValidationError struct {
+// CreateEquipmentRequestMultiError is an error wrapping multiple validation
+// errors returned by CreateEquipmentRequest.ValidateAll() if the designated
+// constraints aren't met.
+type CreateEquipmentRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateEquipmentRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateEquipmentRequestMultiError) AllErrors() []error { return m }
+
+// CreateEquipmentRequestValidationError is the validation error returned by
+// CreateEquipmentRequest.Validate if the designated constraints aren't met.
+type CreateEquipmentRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -139,24 +361,24 @@ type DescribeTemplateV1RequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e DescribeTemplateV1RequestValidationError) Field() string { return e.field }
+func (e CreateEquipmentRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DescribeTemplateV1RequestValidationError) Reason() string { return e.reason }
+func (e CreateEquipmentRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DescribeTemplateV1RequestValidationError) Cause() error { return e.cause }
+func (e CreateEquipmentRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DescribeTemplateV1RequestValidationError) Key() bool { return e.key }
+func (e CreateEquipmentRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DescribeTemplateV1RequestValidationError) ErrorName() string {
-	return "DescribeTemplateV1RequestValidationError"
+func (e CreateEquipmentRequestValidationError) ErrorName() string {
+	return "CreateEquipmentRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e DescribeTemplateV1RequestValidationError) Error() string {
+func (e CreateEquipmentRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -168,14 +390,14 @@ func (e DescribeTemplateV1RequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDescribeTemplateV1Request.%s: %s%s",
+		"invalid %sCreateEquipmentRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DescribeTemplateV1RequestValidationError{}
+var _ error = CreateEquipmentRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -183,19 +405,307 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DescribeTemplateV1RequestValidationError{}
+} = CreateEquipmentRequestValidationError{}
+
+// Validate checks the field values on CreateEquipmentResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateEquipmentResponse) Validate() error {
+	return m.validate(false)
+}
 
-// Validate checks the field values on DescribeTemplateV1Response with the
+// ValidateAll checks the field values on CreateEquipmentResponse with the
 // rules defined in the proto definition for this message. If any rules are
-// violated, an error is returned.
-func (m *DescribeTemplateV1Response) Validate() error {
+// violated, the result is a list of violation errors wrapped in
+// CreateEquipmentResponseMultiError, or nil if none found.
+func (m *CreateEquipmentResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateEquipmentResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	if len(errors) > 0 {
+		return CreateEquipmentResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateEquipmentResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateEquipmentResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CreateEquipmentResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateEquipmentResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateEquipmentResponseMultiError) AllErrors() []error { return m }
+
+// CreateEquipmentResponseValidationError is the validation error returned by
+// CreateEquipmentResponse.Validate if the designated constraints aren't met.
+type CreateEquipmentResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateEquipmentResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateEquipmentResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateEquipmentResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateEquipmentResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateEquipmentResponseValidationError) ErrorName() string {
+	return "CreateEquipmentResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateEquipmentResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateEquipmentResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateEquipmentResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateEquipmentResponseValidationError{}
+
+// Validate checks the field values on GetEquipmentRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetEquipmentRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetEquipmentRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetEquipmentRequestMultiError, or nil if none found.
+func (m *GetEquipmentRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetEquipmentRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = GetEquipmentRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetAsOf()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetEquipmentRequestValidationError{
+					field:  "AsOf",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetEquipmentRequestValidationError{
+					field:  "AsOf",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAsOf()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GetEquipmentRequestValidationError{
+				field:  "AsOf",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GetEquipmentRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *GetEquipmentRequest) _validateUuid(uuid string) error {
+	if matched := _equipment_api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// GetEquipmentRequestMultiError is an error wrapping multiple validation
+// errors returned by GetEquipmentRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetEquipmentRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetEquipmentRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetEquipmentRequestMultiError) AllErrors() []error { return m }
+
+// GetEquipmentRequestValidationError is the validation error returned by
+// GetEquipmentRequest.Validate if the designated constraints aren't met.
+type GetEquipmentRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetEquipmentRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetEquipmentRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetEquipmentRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetEquipmentRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetEquipmentRequestValidationError) ErrorName() string {
+	return "GetEquipmentRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetEquipmentRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetEquipmentRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetEquipmentRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetEquipmentRequestValidationError{}
+
+// Validate checks the field values on GetEquipmentResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetEquipmentResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetEquipmentResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetEquipmentResponseMultiError, or nil if none found.
+func (m *GetEquipmentResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetEquipmentResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
-	if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetValue()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GetEquipmentResponseValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GetEquipmentResponseValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return DescribeTemplateV1ResponseValidationError{
+			return GetEquipmentResponseValidationError{
 				field:  "Value",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -203,12 +713,1110 @@ func (m *DescribeTemplateV1Response) Validate() error {
 		}
 	}
 
+	if len(errors) > 0 {
+		return GetEquipmentResponseMultiError(errors)
+	}
+
 	return nil
 }
 
-// DescribeTemplateV1ResponseValidationError is the validation error returned
-// by DescribeTemplateV1Response.Validate if the designated constraints aren't met.
-type DescribeTemplateV1ResponseValidationError struct {
+// GetEquipmentResponseMultiError is an error wrapping multiple validation
+// errors returned by GetEquipmentResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetEquipmentResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetEquipmentResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetEquipmentResponseMultiError) AllErrors() []error { return m }
+
+// GetEquipmentResponseValidationError is the validation error returned by
+// GetEquipmentResponse.Validate if the designated constraints aren't met.
+type GetEquipmentResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetEquipmentResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetEquipmentResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetEquipmentResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetEquipmentResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetEquipmentResponseValidationError) ErrorName() string {
+	return "GetEquipmentResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetEquipmentResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetEquipmentResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetEquipmentResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetEquipmentResponseValidationError{}
+
+// Validate checks the field values on ListEquipmentRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListEquipmentRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListEquipmentRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListEquipmentRequestMultiError, or nil if none found.
+func (m *ListEquipmentRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListEquipmentRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetKinds() {
+		_, _ = idx, item
+
+		if _, ok := EquipmentKind_name[int32(item)]; !ok {
+			err := ListEquipmentRequestValidationError{
+				field:  fmt.Sprintf("Kinds[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	for idx, item := range m.GetNoKinds() {
+		_, _ = idx, item
+
+		if _, ok := EquipmentKind_name[int32(item)]; !ok {
+			err := ListEquipmentRequestValidationError{
+				field:  fmt.Sprintf("NoKinds[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	for idx, item := range m.GetStatuses() {
+		_, _ = idx, item
+
+		if _, ok := OperationalStatus_name[int32(item)]; !ok {
+			err := ListEquipmentRequestValidationError{
+				field:  fmt.Sprintf("Statuses[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	for idx, item := range m.GetNoStatuses() {
+		_, _ = idx, item
+
+		if _, ok := OperationalStatus_name[int32(item)]; !ok {
+			err := ListEquipmentRequestValidationError{
+				field:  fmt.Sprintf("NoStatuses[%v]", idx),
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreatedSince()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "CreatedSince",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "CreatedSince",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedSince()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListEquipmentRequestValidationError{
+				field:  "CreatedSince",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCreatedUntil()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "CreatedUntil",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "CreatedUntil",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedUntil()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListEquipmentRequestValidationError{
+				field:  "CreatedUntil",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedSince()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "UpdatedSince",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "UpdatedSince",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedSince()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListEquipmentRequestValidationError{
+				field:  "UpdatedSince",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedUntil()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "UpdatedUntil",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "UpdatedUntil",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedUntil()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListEquipmentRequestValidationError{
+				field:  "UpdatedUntil",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetAsOf()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "AsOf",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "AsOf",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAsOf()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListEquipmentRequestValidationError{
+				field:  "AsOf",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLastSeenSince()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "LastSeenSince",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "LastSeenSince",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastSeenSince()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListEquipmentRequestValidationError{
+				field:  "LastSeenSince",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLastSeenUntil()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "LastSeenUntil",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ListEquipmentRequestValidationError{
+					field:  "LastSeenUntil",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLastSeenUntil()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ListEquipmentRequestValidationError{
+				field:  "LastSeenUntil",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetConnectivity() {
+		_, _ = idx, item
+
+		if _, ok := _ListEquipmentRequest_Connectivity_InLookup[item]; !ok {
+			err := ListEquipmentRequestValidationError{
+				field:  fmt.Sprintf("Connectivity[%v]", idx),
+				reason: "value must be in list [unknown online unreachable]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if utf8.RuneCountInString(m.GetFilter()) > 4096 {
+		err := ListEquipmentRequestValidationError{
+			field:  "Filter",
+			reason: "value length must be at most 4096 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetLimit(); val < 0 || val > 1000 {
+		err := ListEquipmentRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range [0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Cursor
+
+	if _, ok := _ListEquipmentRequest_Sort_InLookup[m.GetSort()]; !ok {
+		err := ListEquipmentRequestValidationError{
+			field:  "Sort",
+			reason: "value must be in list [ id created_at updated_at kind status]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Descending
+
+	// no validation rules for Total
+
+	if len(errors) > 0 {
+		return ListEquipmentRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListEquipmentRequestMultiError is an error wrapping multiple validation
+// errors returned by ListEquipmentRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListEquipmentRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListEquipmentRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListEquipmentRequestMultiError) AllErrors() []error { return m }
+
+// ListEquipmentRequestValidationError is the validation error returned by
+// ListEquipmentRequest.Validate if the designated constraints aren't met.
+type ListEquipmentRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListEquipmentRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListEquipmentRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListEquipmentRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListEquipmentRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListEquipmentRequestValidationError) ErrorName() string {
+	return "ListEquipmentRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListEquipmentRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListEquipmentRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListEquipmentRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListEquipmentRequestValidationError{}
+
+var _ListEquipmentRequest_Connectivity_InLookup = map[string]struct{}{
+	"unknown":     {},
+	"online":      {},
+	"unreachable": {},
+}
+
+var _ListEquipmentRequest_Sort_InLookup = map[string]struct{}{
+	"":           {},
+	"id":         {},
+	"created_at": {},
+	"updated_at": {},
+	"kind":       {},
+	"status":     {},
+}
+
+// Validate checks the field values on ListEquipmentResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListEquipmentResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListEquipmentResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListEquipmentResponseMultiError, or nil if none found.
+func (m *ListEquipmentResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListEquipmentResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListEquipmentResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListEquipmentResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListEquipmentResponseValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for NextCursor
+
+	if m.Total != nil {
+		// no validation rules for Total
+	}
+
+	if len(errors) > 0 {
+		return ListEquipmentResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListEquipmentResponseMultiError is an error wrapping multiple validation
+// errors returned by ListEquipmentResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListEquipmentResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListEquipmentResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListEquipmentResponseMultiError) AllErrors() []error { return m }
+
+// ListEquipmentResponseValidationError is the validation error returned by
+// ListEquipmentResponse.Validate if the designated constraints aren't met.
+type ListEquipmentResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListEquipmentResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListEquipmentResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListEquipmentResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListEquipmentResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListEquipmentResponseValidationError) ErrorName() string {
+	return "ListEquipmentResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListEquipmentResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListEquipmentResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListEquipmentResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListEquipmentResponseValidationError{}
+
+// Validate checks the field values on UpdateEquipmentRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateEquipmentRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateEquipmentRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateEquipmentRequestMultiError, or nil if none found.
+func (m *UpdateEquipmentRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateEquipmentRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = UpdateEquipmentRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetParameters()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateEquipmentRequestValidationError{
+					field:  "Parameters",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateEquipmentRequestValidationError{
+					field:  "Parameters",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetParameters()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateEquipmentRequestValidationError{
+				field:  "Parameters",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Reason
+
+	// no validation rules for Actor
+
+	if m.Status != nil {
+
+		if _, ok := OperationalStatus_name[int32(m.GetStatus())]; !ok {
+			err := UpdateEquipmentRequestValidationError{
+				field:  "Status",
+				reason: "value must be one of the defined enum values",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UpdateEquipmentRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *UpdateEquipmentRequest) _validateUuid(uuid string) error {
+	if matched := _equipment_api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// UpdateEquipmentRequestMultiError is an error wrapping multiple validation
+// errors returned by UpdateEquipmentRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateEquipmentRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateEquipmentRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateEquipmentRequestMultiError) AllErrors() []error { return m }
+
+// UpdateEquipmentRequestValidationError is the validation error returned by
+// UpdateEquipmentRequest.Validate if the designated constraints aren't met.
+type UpdateEquipmentRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateEquipmentRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateEquipmentRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateEquipmentRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateEquipmentRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateEquipmentRequestValidationError) ErrorName() string {
+	return "UpdateEquipmentRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateEquipmentRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateEquipmentRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateEquipmentRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateEquipmentRequestValidationError{}
+
+// Validate checks the field values on UpdateEquipmentResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateEquipmentResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateEquipmentResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateEquipmentResponseMultiError, or nil if none found.
+func (m *UpdateEquipmentResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateEquipmentResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return UpdateEquipmentResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateEquipmentResponseMultiError is an error wrapping multiple validation
+// errors returned by UpdateEquipmentResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateEquipmentResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateEquipmentResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateEquipmentResponseMultiError) AllErrors() []error { return m }
+
+// UpdateEquipmentResponseValidationError is the validation error returned by
+// UpdateEquipmentResponse.Validate if the designated constraints aren't met.
+type UpdateEquipmentResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateEquipmentResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateEquipmentResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateEquipmentResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateEquipmentResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateEquipmentResponseValidationError) ErrorName() string {
+	return "UpdateEquipmentResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateEquipmentResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateEquipmentResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateEquipmentResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateEquipmentResponseValidationError{}
+
+// Validate checks the field values on DeleteEquipmentRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteEquipmentRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteEquipmentRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteEquipmentRequestMultiError, or nil if none found.
+func (m *DeleteEquipmentRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteEquipmentRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetId()); err != nil {
+		err = DeleteEquipmentRequestValidationError{
+			field:  "Id",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DeleteEquipmentRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *DeleteEquipmentRequest) _validateUuid(uuid string) error {
+	if matched := _equipment_api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// DeleteEquipmentRequestMultiError is an error wrapping multiple validation
+// errors returned by DeleteEquipmentRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteEquipmentRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteEquipmentRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteEquipmentRequestMultiError) AllErrors() []error { return m }
+
+// DeleteEquipmentRequestValidationError is the validation error returned by
+// DeleteEquipmentRequest.Validate if the designated constraints aren't met.
+type DeleteEquipmentRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DeleteEquipmentRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DeleteEquipmentRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DeleteEquipmentRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DeleteEquipmentRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DeleteEquipmentRequestValidationError) ErrorName() string {
+	return "DeleteEquipmentRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DeleteEquipmentRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDeleteEquipmentRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DeleteEquipmentRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DeleteEquipmentRequestValidationError{}
+
+// Validate checks the field values on DeleteEquipmentResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DeleteEquipmentResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DeleteEquipmentResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DeleteEquipmentResponseMultiError, or nil if none found.
+func (m *DeleteEquipmentResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DeleteEquipmentResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return DeleteEquipmentResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DeleteEquipmentResponseMultiError is an error wrapping multiple validation
+// errors returned by DeleteEquipmentResponse.ValidateAll() if the designated
+// constraints aren't met.
+type DeleteEquipmentResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DeleteEquipmentResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DeleteEquipmentResponseMultiError) AllErrors() []error { return m }
+
+// DeleteEquipmentResponseValidationError is the validation error returned by
+// DeleteEquipmentResponse.Validate if the designated constraints aren't met.
+type DeleteEquipmentResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -216,24 +1824,24 @@ type DescribeTemplateV1ResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e DescribeTemplateV1ResponseValidationError) Field() string { return e.field }
+func (e DeleteEquipmentResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e DescribeTemplateV1ResponseValidationError) Reason() string { return e.reason }
+func (e DeleteEquipmentResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e DescribeTemplateV1ResponseValidationError) Cause() error { return e.cause }
+func (e DeleteEquipmentResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e DescribeTemplateV1ResponseValidationError) Key() bool { return e.key }
+func (e DeleteEquipmentResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e DescribeTemplateV1ResponseValidationError) ErrorName() string {
-	return "DescribeTemplateV1ResponseValidationError"
+func (e DeleteEquipmentResponseValidationError) ErrorName() string {
+	return "DeleteEquipmentResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e DescribeTemplateV1ResponseValidationError) Error() string {
+func (e DeleteEquipmentResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -245,14 +1853,14 @@ func (e DescribeTemplateV1ResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sDescribeTemplateV1Response.%s: %s%s",
+		"invalid %sDeleteEquipmentResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = DescribeTemplateV1ResponseValidationError{}
+var _ error = DeleteEquipmentResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -260,4 +1868,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = DescribeTemplateV1ResponseValidationError{}
+} = DeleteEquipmentResponseValidationError{}
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: melanjnk/equipment-api/v1/equipment_api.proto
 
 package equipment_api
 
@@ -14,90 +18,250 @@ import (
 // Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
-// OmpTemplateApiServiceClient is the client API for OmpTemplateApiService service.
+const (
+	EquipmentService_CreateEquipment_FullMethodName = "/melanjnk.equipment_api.v1.EquipmentService/CreateEquipment"
+	EquipmentService_GetEquipment_FullMethodName    = "/melanjnk.equipment_api.v1.EquipmentService/GetEquipment"
+	EquipmentService_ListEquipment_FullMethodName   = "/melanjnk.equipment_api.v1.EquipmentService/ListEquipment"
+	EquipmentService_UpdateEquipment_FullMethodName = "/melanjnk.equipment_api.v1.EquipmentService/UpdateEquipment"
+	EquipmentService_DeleteEquipment_FullMethodName = "/melanjnk.equipment_api.v1.EquipmentService/DeleteEquipment"
+)
+
+// EquipmentServiceClient is the client API for EquipmentService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type OmpTemplateApiServiceClient interface {
-	// DescribeTemplateV1 - Describe a template
-	DescribeTemplateV1(ctx context.Context, in *DescribeTemplateV1Request, opts ...grpc.CallOption) (*DescribeTemplateV1Response, error)
+type EquipmentServiceClient interface {
+	// CreateEquipment - Register new equipment; the parameters are checked against the schema of the kind
+	CreateEquipment(ctx context.Context, in *CreateEquipmentRequest, opts ...grpc.CallOption) (*CreateEquipmentResponse, error)
+	// GetEquipment - The current state of the equipment or the state at as_of
+	GetEquipment(ctx context.Context, in *GetEquipmentRequest, opts ...grpc.CallOption) (*GetEquipmentResponse, error)
+	// ListEquipment - The equipment matching the filters
+	ListEquipment(ctx context.Context, in *ListEquipmentRequest, opts ...grpc.CallOption) (*ListEquipmentResponse, error)
+	// UpdateEquipment - Change the status (checked against the transition graph) and/or replace the parameters
+	UpdateEquipment(ctx context.Context, in *UpdateEquipmentRequest, opts ...grpc.CallOption) (*UpdateEquipmentResponse, error)
+	// DeleteEquipment - Delete the equipment keeping its history
+	DeleteEquipment(ctx context.Context, in *DeleteEquipmentRequest, opts ...grpc.CallOption) (*DeleteEquipmentResponse, error)
 }
 
-type ompTemplateApiServiceClient struct {
+type equipmentServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewOmpTemplateApiServiceClient(cc grpc.ClientConnInterface) OmpTemplateApiServiceClient {
-	return &ompTemplateApiServiceClient{cc}
+func NewEquipmentServiceClient(cc grpc.ClientConnInterface) EquipmentServiceClient {
+	return &equipmentServiceClient{cc}
+}
+
+func (c *equipmentServiceClient) CreateEquipment(ctx context.Context, in *CreateEquipmentRequest, opts ...grpc.CallOption) (*CreateEquipmentResponse, error) {
+	out := new(CreateEquipmentResponse)
+	err := c.cc.Invoke(ctx, EquipmentService_CreateEquipment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *ompTemplateApiServiceClient) DescribeTemplateV1(ctx context.Context, in *DescribeTemplateV1Request, opts ...grpc.CallOption) (*DescribeTemplateV1Response, error) {
-	out := new(DescribeTemplateV1Response)
-	err := c.cc.Invoke(ctx, "/melanjnk.equipment_api.v1.OmpTemplateApiService/DescribeTemplateV1", in, out, opts...)
+func (c *equipmentServiceClient) GetEquipment(ctx context.Context, in *GetEquipmentRequest, opts ...grpc.CallOption) (*GetEquipmentResponse, error) {
+	out := new(GetEquipmentResponse)
+	err := c.cc.Invoke(ctx, EquipmentService_GetEquipment_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// OmpTemplateApiServiceServer is the server API for OmpTemplateApiService service.
-// All implementations must embed UnimplementedOmpTemplateApiServiceServer
+func (c *equipmentServiceClient) ListEquipment(ctx context.Context, in *ListEquipmentRequest, opts ...grpc.CallOption) (*ListEquipmentResponse, error) {
+	out := new(ListEquipmentResponse)
+	err := c.cc.Invoke(ctx, EquipmentService_ListEquipment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *equipmentServiceClient) UpdateEquipment(ctx context.Context, in *UpdateEquipmentRequest, opts ...grpc.CallOption) (*UpdateEquipmentResponse, error) {
+	out := new(UpdateEquipmentResponse)
+	err := c.cc.Invoke(ctx, EquipmentService_UpdateEquipment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *equipmentServiceClient) DeleteEquipment(ctx context.Context, in *DeleteEquipmentRequest, opts ...grpc.CallOption) (*DeleteEquipmentResponse, error) {
+	out := new(DeleteEquipmentResponse)
+	err := c.cc.Invoke(ctx, EquipmentService_DeleteEquipment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EquipmentServiceServer is the server API for EquipmentService service.
+// All implementations must embed UnimplementedEquipmentServiceServer
 // for forward compatibility
-type OmpTemplateApiServiceServer interface {
-	// DescribeTemplateV1 - Describe a template
-	DescribeTemplateV1(context.Context, *DescribeTemplateV1Request) (*DescribeTemplateV1Response, error)
-	mustEmbedUnimplementedOmpTemplateApiServiceServer()
+type EquipmentServiceServer interface {
+	// CreateEquipment - Register new equipment; the parameters are checked against the schema of the kind
+	CreateEquipment(context.Context, *CreateEquipmentRequest) (*CreateEquipmentResponse, error)
+	// GetEquipment - The current state of the equipment or the state at as_of
+	GetEquipment(context.Context, *GetEquipmentRequest) (*GetEquipmentResponse, error)
+	// ListEquipment - The equipment matching the filters
+	ListEquipment(context.Context, *ListEquipmentRequest) (*ListEquipmentResponse, error)
+	// UpdateEquipment - Change the status (checked against the transition graph) and/or replace the parameters
+	UpdateEquipment(context.Context, *UpdateEquipmentRequest) (*UpdateEquipmentResponse, error)
+	// DeleteEquipment - Delete the equipment keeping its history
+	DeleteEquipment(context.Context, *DeleteEquipmentRequest) (*DeleteEquipmentResponse, error)
+	mustEmbedUnimplementedEquipmentServiceServer()
 }
 
-// UnimplementedOmpTemplateApiServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedOmpTemplateApiServiceServer struct {
+// UnimplementedEquipmentServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedEquipmentServiceServer struct {
 }
 
-func (UnimplementedOmpTemplateApiServiceServer) DescribeTemplateV1(context.Context, *DescribeTemplateV1Request) (*DescribeTemplateV1Response, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DescribeTemplateV1 not implemented")
+func (UnimplementedEquipmentServiceServer) CreateEquipment(context.Context, *CreateEquipmentRequest) (*CreateEquipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEquipment not implemented")
+}
+func (UnimplementedEquipmentServiceServer) GetEquipment(context.Context, *GetEquipmentRequest) (*GetEquipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEquipment not implemented")
+}
+func (UnimplementedEquipmentServiceServer) ListEquipment(context.Context, *ListEquipmentRequest) (*ListEquipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEquipment not implemented")
+}
+func (UnimplementedEquipmentServiceServer) UpdateEquipment(context.Context, *UpdateEquipmentRequest) (*UpdateEquipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEquipment not implemented")
 }
-func (UnimplementedOmpTemplateApiServiceServer) mustEmbedUnimplementedOmpTemplateApiServiceServer() {}
+func (UnimplementedEquipmentServiceServer) DeleteEquipment(context.Context, *DeleteEquipmentRequest) (*DeleteEquipmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEquipment not implemented")
+}
+func (UnimplementedEquipmentServiceServer) mustEmbedUnimplementedEquipmentServiceServer() {}
 
-// UnsafeOmpTemplateApiServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to OmpTemplateApiServiceServer will
+// UnsafeEquipmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EquipmentServiceServer will
 // result in compilation errors.
-type UnsafeOmpTemplateApiServiceServer interface {
-	mustEmbedUnimplementedOmpTemplateApiServiceServer()
+type UnsafeEquipmentServiceServer interface {
+	mustEmbedUnimplementedEquipmentServiceServer()
+}
+
+func RegisterEquipmentServiceServer(s grpc.ServiceRegistrar, srv EquipmentServiceServer) {
+	s.RegisterService(&EquipmentService_ServiceDesc, srv)
+}
+
+func _EquipmentService_CreateEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EquipmentServiceServer).CreateEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EquipmentService_CreateEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EquipmentServiceServer).CreateEquipment(ctx, req.(*CreateEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterOmpTemplateApiServiceServer(s grpc.ServiceRegistrar, srv OmpTemplateApiServiceServer) {
-	s.RegisterService(&OmpTemplateApiService_ServiceDesc, srv)
+func _EquipmentService_GetEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EquipmentServiceServer).GetEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EquipmentService_GetEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EquipmentServiceServer).GetEquipment(ctx, req.(*GetEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _OmpTemplateApiService_DescribeTemplateV1_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DescribeTemplateV1Request)
+func _EquipmentService_ListEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEquipmentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(OmpTemplateApiServiceServer).DescribeTemplateV1(ctx, in)
+		return srv.(EquipmentServiceServer).ListEquipment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/melanjnk.equipment_api.v1.OmpTemplateApiService/DescribeTemplateV1",
+		FullMethod: EquipmentService_ListEquipment_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(OmpTemplateApiServiceServer).DescribeTemplateV1(ctx, req.(*DescribeTemplateV1Request))
+		return srv.(EquipmentServiceServer).ListEquipment(ctx, req.(*ListEquipmentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// OmpTemplateApiService_ServiceDesc is the grpc.ServiceDesc for OmpTemplateApiService service.
+func _EquipmentService_UpdateEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EquipmentServiceServer).UpdateEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EquipmentService_UpdateEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EquipmentServiceServer).UpdateEquipment(ctx, req.(*UpdateEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EquipmentService_DeleteEquipment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEquipmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EquipmentServiceServer).DeleteEquipment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EquipmentService_DeleteEquipment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EquipmentServiceServer).DeleteEquipment(ctx, req.(*DeleteEquipmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EquipmentService_ServiceDesc is the grpc.ServiceDesc for EquipmentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var OmpTemplateApiService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "melanjnk.equipment_api.v1.OmpTemplateApiService",
-	HandlerType: (*OmpTemplateApiServiceServer)(nil),
+var EquipmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "melanjnk.equipment_api.v1.EquipmentService",
+	HandlerType: (*EquipmentServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "DescribeTemplateV1",
-			Handler:    _OmpTemplateApiService_DescribeTemplateV1_Handler,
+			MethodName: "CreateEquipment",
+			Handler:    _EquipmentService_CreateEquipment_Handler,
+		},
+		{
+			MethodName: "GetEquipment",
+			Handler:    _EquipmentService_GetEquipment_Handler,
+		},
+		{
+			MethodName: "ListEquipment",
+			Handler:    _EquipmentService_ListEquipment_Handler,
+		},
+		{
+			MethodName: "UpdateEquipment",
+			Handler:    _EquipmentService_UpdateEquipment_Handler,
+		},
+		{
+			MethodName: "DeleteEquipment",
+			Handler:    _EquipmentService_DeleteEquipment_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "melanjnk/equipment_api/v1/equipment_api.proto",
+	Metadata: "melanjnk/equipment-api/v1/equipment_api.proto",
 }